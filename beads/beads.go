@@ -0,0 +1,59 @@
+// Package beads is the public face of the abacus backend API: the Client
+// interface a custom backend implements, the issue types it exchanges with
+// abacus, and ServePlugin to run a Client as a backend plugin. The
+// implementations abacus ships live in internal/beads;
+// everything here is an alias of the type abacus uses, so values pass
+// between the two without conversion.
+package beads
+
+import (
+	"context"
+	"io"
+
+	internalbeads "abacus/internal/beads"
+)
+
+// Client, Reader and Writer are the operations abacus calls on a backend.
+// Their doc comments in internal/beads/client.go describe each one.
+type (
+	Client = internalbeads.Client
+	Reader = internalbeads.Reader
+	Writer = internalbeads.Writer
+)
+
+// Issue data exchanged through Client.
+type (
+	LiteIssue    = internalbeads.LiteIssue
+	FullIssue    = internalbeads.FullIssue
+	Comment      = internalbeads.Comment
+	Dependency   = internalbeads.Dependency
+	Dependent    = internalbeads.Dependent
+	HistoryEvent = internalbeads.HistoryEvent
+)
+
+var (
+	// ErrNotFound is returned, or wrapped, when an issue does not exist.
+	ErrNotFound = internalbeads.ErrNotFound
+	// ErrNotSupported is returned, or wrapped, for operations the backend
+	// does not offer. Abacus hides or degrades the matching feature.
+	ErrNotSupported = internalbeads.ErrNotSupported
+)
+
+// History fields reported in HistoryEvent.Field.
+const (
+	HistoryCreated      = internalbeads.HistoryCreated
+	HistoryStatus       = internalbeads.HistoryStatus
+	HistoryPriority     = internalbeads.HistoryPriority
+	HistoryAssignee     = internalbeads.HistoryAssignee
+	HistoryTitle        = internalbeads.HistoryTitle
+	HistoryLabels       = internalbeads.HistoryLabels
+	HistoryDependencies = internalbeads.HistoryDependencies
+)
+
+// ServePlugin answers plugin protocol requests read from r by calling
+// client, writing responses to w, until r is closed or ctx is done. A plugin
+// executable calls it with os.Stdin and os.Stdout; see
+// docs/BACKEND_PLUGINS.md for the protocol.
+func ServePlugin(ctx context.Context, client Client, r io.Reader, w io.Writer) error {
+	return internalbeads.ServePlugin(ctx, client, r, w)
+}
@@ -0,0 +1,129 @@
+package beadstest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"abacus/beads"
+)
+
+func mustCreate(t *testing.T, ctx context.Context, c beads.Client, title string) string {
+	t.Helper()
+	id, err := c.Create(ctx, title, "task", 2, nil, "")
+	if err != nil {
+		t.Fatalf("Create %q: %v", title, err)
+	}
+	return id
+}
+
+func mustShow(t *testing.T, ctx context.Context, c beads.Client, id string) beads.FullIssue {
+	t.Helper()
+	issues, err := c.Show(ctx, []string{id})
+	if err != nil {
+		t.Fatalf("Show %s: %v", id, err)
+	}
+	if len(issues) != 1 || issues[0].ID != id {
+		t.Fatalf("Show %s returned %d issues, want exactly %s", id, len(issues), id)
+	}
+	return issues[0]
+}
+
+// mustExport returns every visible issue keyed by ID.
+func mustExport(t *testing.T, ctx context.Context, c beads.Client) map[string]beads.FullIssue {
+	t.Helper()
+	issues, err := c.Export(ctx)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	byID := make(map[string]beads.FullIssue, len(issues))
+	for _, iss := range issues {
+		byID[iss.ID] = iss
+	}
+	return byID
+}
+
+// skipIfNotSupported skips the subtest when a backend declines an optional
+// operation and fails it on any other error.
+func skipIfNotSupported(t *testing.T, err error, op string) {
+	t.Helper()
+	if errors.Is(err, beads.ErrNotSupported) {
+		t.Skipf("%s not supported by this backend", op)
+	}
+	if err != nil {
+		t.Fatalf("%s: %v", op, err)
+	}
+}
+
+// assertGone checks that no reader returns a deleted issue.
+func assertGone(t *testing.T, ctx context.Context, c beads.Client, id string) {
+	t.Helper()
+	list, err := c.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	for _, iss := range list {
+		if iss.ID == id {
+			t.Errorf("List still returns deleted issue %s", id)
+		}
+	}
+	if _, ok := mustExport(t, ctx, c)[id]; ok {
+		t.Errorf("Export still returns deleted issue %s", id)
+	}
+	shown, err := c.Show(ctx, []string{id})
+	if err != nil && !errors.Is(err, beads.ErrNotFound) {
+		t.Errorf("Show of deleted issue %s failed with %v, want no issues or beads.ErrNotFound", id, err)
+	}
+	if len(shown) != 0 {
+		t.Errorf("Show still returns deleted issue %s", id)
+	}
+}
+
+// assertLabels compares labels as a set; backends need not keep them in
+// insertion order.
+func assertLabels(t *testing.T, iss beads.FullIssue, want ...string) {
+	t.Helper()
+	got := slices.Clone(iss.Labels)
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("labels of %s = %v, want %v", iss.ID, iss.Labels, want)
+	}
+}
+
+func hasDependency(iss beads.FullIssue, targetID string) bool {
+	return slices.ContainsFunc(iss.Dependencies, func(d beads.Dependency) bool { return d.TargetID == targetID })
+}
+
+func assertDependency(t *testing.T, iss beads.FullIssue, targetID, depType string) {
+	t.Helper()
+	if !slices.Contains(iss.Dependencies, beads.Dependency{TargetID: targetID, Type: depType}) {
+		t.Errorf("%s should depend on %s (%s), has %+v", iss.ID, targetID, depType, iss.Dependencies)
+	}
+}
+
+func assertDependent(t *testing.T, iss beads.FullIssue, id, depType string) {
+	t.Helper()
+	if !slices.Contains(iss.Dependents, beads.Dependent{ID: id, Type: depType}) {
+		t.Errorf("%s should list %s as a dependent (%s), has %+v", iss.ID, id, depType, iss.Dependents)
+	}
+}
+
+// assertCommentTexts checks comments are returned oldest first.
+func assertCommentTexts(t *testing.T, comments []beads.Comment, want []string) {
+	t.Helper()
+	got := make([]string, 0, len(comments))
+	for _, cmt := range comments {
+		got = append(got, cmt.Text)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("comment texts = %q, want %q", got, want)
+	}
+}
+
+// hasDatePrefix accepts dates stored with or without a time part.
+func hasDatePrefix(value, date string) bool {
+	return strings.HasPrefix(value, date)
+}
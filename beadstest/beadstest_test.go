@@ -0,0 +1,16 @@
+package beadstest
+
+import "testing"
+
+func TestParseFakeBrArgs(t *testing.T) {
+	got := parseFakeBrArgs([]string{"ab-1", "--status=closed", "--assignee", "", "--json", "--set-labels", "a,b"})
+	if len(got.positional) != 1 || got.positional[0] != "ab-1" {
+		t.Fatalf("positional = %q", got.positional)
+	}
+	want := map[string]string{"status": "closed", "assignee": "", "json": "true", "set-labels": "a,b"}
+	for k, v := range want {
+		if got.flags[k] != v {
+			t.Errorf("flag %s = %q, want %q", k, got.flags[k], v)
+		}
+	}
+}
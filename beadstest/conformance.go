@@ -0,0 +1,561 @@
+// Package beadstest checks that a beads.Client behaves the way abacus
+// expects, so custom backends and plugins can prove compatibility with the
+// built-in ones.
+//
+// RunConformance exercises every Reader and Writer method against a fresh
+// client per subtest. The fake br harness in this package backs the suite
+// with a real br SQLite schema without needing the br binary:
+//
+//	func TestMain(m *testing.M) {
+//		beadstest.RunFakeBrIfRequested()
+//		os.Exit(m.Run())
+//	}
+//
+//	func TestConformance(t *testing.T) {
+//		beadstest.RunConformance(t, beadstest.FakeBrFactory())
+//	}
+package beadstest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"abacus/beads"
+)
+
+// Factory returns a client backed by empty storage. It is called once per
+// subtest and should register any cleanup with t.Cleanup.
+type Factory func(t *testing.T) beads.Client
+
+// missingID names an issue no backend should have.
+const missingID = "beadstest-missing-1"
+
+// suiteTimeout bounds each subtest, including backend process startup.
+const suiteTimeout = 30 * time.Second
+
+// RunConformance runs the conformance suite against clients from factory.
+//
+// Operations a backend does not offer may fail with beads.ErrNotSupported
+// where the built-in backends do too (close reasons, scheduling, external
+// references, comment editing and history); those subtests are skipped.
+// Any other error fails the suite.
+func RunConformance(t *testing.T, factory Factory) {
+	t.Helper()
+	tests := []struct {
+		name string
+		run  func(t *testing.T, ctx context.Context, c beads.Client)
+	}{
+		{"CreateFull", testCreateFull},
+		{"Create", testCreate},
+		{"CreateRequiresTitle", testCreateRequiresTitle},
+		{"ListAndExport", testListAndExport},
+		{"ShowMissing", testShowMissing},
+		{"StatusLifecycle", testStatusLifecycle},
+		{"CloseWithReason", testCloseWithReason},
+		{"Labels", testLabels},
+		{"PriorityAndAssignee", testPriorityAndAssignee},
+		{"UpdateFull", testUpdateFull},
+		{"Schedule", testSchedule},
+		{"ExternalRef", testExternalRef},
+		{"Dependencies", testDependencies},
+		{"ParentChild", testParentChild},
+		{"Comments", testComments},
+		{"CommentEditing", testCommentEditing},
+		{"History", testHistory},
+		{"Delete", testDelete},
+		{"DeleteCascade", testDeleteCascade},
+		{"MissingIssueErrors", testMissingIssueErrors},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), suiteTimeout)
+			defer cancel()
+			tt.run(t, ctx, factory(t))
+		})
+	}
+}
+
+func testCreateFull(t *testing.T, ctx context.Context, c beads.Client) {
+	created, err := c.CreateFull(ctx, "Crash on save", "bug", 1, []string{"ui", "backend"}, "alice", "Steps to reproduce", "")
+	if err != nil {
+		t.Fatalf("CreateFull: %v", err)
+	}
+	if created.ID == "" || created.Title != "Crash on save" {
+		t.Fatalf("CreateFull returned %+v, want the new issue's ID and title", created)
+	}
+
+	got := mustShow(t, ctx, c, created.ID)
+	if got.Title != "Crash on save" || got.IssueType != "bug" || got.Priority != 1 {
+		t.Errorf("title/type/priority = %q/%q/%d, want %q/%q/%d", got.Title, got.IssueType, got.Priority, "Crash on save", "bug", 1)
+	}
+	if got.Status != "open" {
+		t.Errorf("status = %q, want open", got.Status)
+	}
+	if got.Assignee != "alice" || got.Description != "Steps to reproduce" {
+		t.Errorf("assignee/description = %q/%q", got.Assignee, got.Description)
+	}
+	assertLabels(t, got, "backend", "ui")
+	if got.CreatedAt == "" {
+		t.Error("created_at is empty")
+	}
+}
+
+func testCreate(t *testing.T, ctx context.Context, c beads.Client) {
+	id, err := c.Create(ctx, "Write docs", "task", 3, nil, "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if id == "" {
+		t.Fatal("Create returned an empty ID")
+	}
+	got := mustShow(t, ctx, c, id)
+	if got.Title != "Write docs" || got.IssueType != "task" || got.Priority != 3 || got.Status != "open" {
+		t.Errorf("created issue = %+v", got)
+	}
+	assertLabels(t, got)
+}
+
+func testCreateRequiresTitle(t *testing.T, ctx context.Context, c beads.Client) {
+	if _, err := c.Create(ctx, "", "task", 2, nil, ""); err == nil {
+		t.Error("Create without a title succeeded")
+	}
+}
+
+func testListAndExport(t *testing.T, ctx context.Context, c beads.Client) {
+	first := mustCreate(t, ctx, c, "First")
+	second := mustCreate(t, ctx, c, "Second")
+
+	list, err := c.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	listed := make([]string, 0, len(list))
+	for _, iss := range list {
+		listed = append(listed, iss.ID)
+	}
+	for _, id := range []string{first, second} {
+		if !slices.Contains(listed, id) {
+			t.Errorf("List = %v, missing %s", listed, id)
+		}
+	}
+
+	exported := mustExport(t, ctx, c)
+	for id, title := range map[string]string{first: "First", second: "Second"} {
+		iss, ok := exported[id]
+		if !ok {
+			t.Errorf("Export is missing %s", id)
+			continue
+		}
+		if iss.Title != title {
+			t.Errorf("Export title of %s = %q, want %q", id, iss.Title, title)
+		}
+	}
+
+	shown, err := c.Show(ctx, []string{first, second})
+	if err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	if len(shown) != 2 {
+		t.Errorf("Show of two issues returned %d", len(shown))
+	}
+}
+
+func testShowMissing(t *testing.T, ctx context.Context, c beads.Client) {
+	issues, err := c.Show(ctx, []string{missingID})
+	if err != nil {
+		if !errors.Is(err, beads.ErrNotFound) {
+			t.Fatalf("Show of a missing issue failed with %v, want no issues or beads.ErrNotFound", err)
+		}
+		return
+	}
+	if len(issues) != 0 {
+		t.Fatalf("Show of a missing issue returned %+v", issues)
+	}
+}
+
+func testStatusLifecycle(t *testing.T, ctx context.Context, c beads.Client) {
+	id := mustCreate(t, ctx, c, "Lifecycle")
+
+	if err := c.UpdateStatus(ctx, id, "in_progress"); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if got := mustShow(t, ctx, c, id); got.Status != "in_progress" {
+		t.Fatalf("status after UpdateStatus = %q, want in_progress", got.Status)
+	}
+
+	if err := c.Close(ctx, id); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	closed := mustShow(t, ctx, c, id)
+	if closed.Status != "closed" {
+		t.Fatalf("status after Close = %q, want closed", closed.Status)
+	}
+	if closed.ClosedAt == "" {
+		t.Error("closed_at is empty after Close")
+	}
+
+	if err := c.Reopen(ctx, id); err != nil {
+		t.Fatalf("Reopen: %v", err)
+	}
+	if got := mustShow(t, ctx, c, id); got.Status != "open" {
+		t.Fatalf("status after Reopen = %q, want open", got.Status)
+	}
+}
+
+func testCloseWithReason(t *testing.T, ctx context.Context, c beads.Client) {
+	id := mustCreate(t, ctx, c, "Fixed elsewhere")
+	skipIfNotSupported(t, c.CloseWithReason(ctx, id, "Fixed in abc123"), "CloseWithReason")
+
+	got := mustShow(t, ctx, c, id)
+	if got.Status != "closed" || got.CloseReason != "Fixed in abc123" {
+		t.Errorf("status/close_reason = %q/%q, want closed/%q", got.Status, got.CloseReason, "Fixed in abc123")
+	}
+}
+
+func testLabels(t *testing.T, ctx context.Context, c beads.Client) {
+	id := mustCreate(t, ctx, c, "Labeled")
+	for _, label := range []string{"zeta", "alpha"} {
+		if err := c.AddLabel(ctx, id, label); err != nil {
+			t.Fatalf("AddLabel %s: %v", label, err)
+		}
+	}
+	assertLabels(t, mustShow(t, ctx, c, id), "alpha", "zeta")
+
+	if err := c.RemoveLabel(ctx, id, "zeta"); err != nil {
+		t.Fatalf("RemoveLabel: %v", err)
+	}
+	assertLabels(t, mustShow(t, ctx, c, id), "alpha")
+	assertLabels(t, mustExport(t, ctx, c)[id], "alpha")
+}
+
+func testPriorityAndAssignee(t *testing.T, ctx context.Context, c beads.Client) {
+	id := mustCreate(t, ctx, c, "Urgent")
+
+	// Priority 0 is the most urgent and must not be mistaken for "unset".
+	if err := c.UpdatePriority(ctx, id, 0); err != nil {
+		t.Fatalf("UpdatePriority: %v", err)
+	}
+	if got := mustShow(t, ctx, c, id); got.Priority != 0 {
+		t.Errorf("priority = %d, want 0", got.Priority)
+	}
+
+	if err := c.UpdateAssignee(ctx, id, "bob"); err != nil {
+		t.Fatalf("UpdateAssignee: %v", err)
+	}
+	if got := mustShow(t, ctx, c, id); got.Assignee != "bob" {
+		t.Errorf("assignee = %q, want bob", got.Assignee)
+	}
+	if err := c.UpdateAssignee(ctx, id, ""); err != nil {
+		t.Fatalf("UpdateAssignee to clear: %v", err)
+	}
+	if got := mustShow(t, ctx, c, id); got.Assignee != "" {
+		t.Errorf("assignee = %q after clearing", got.Assignee)
+	}
+}
+
+func testUpdateFull(t *testing.T, ctx context.Context, c beads.Client) {
+	created, err := c.CreateFull(ctx, "Draft", "task", 2, []string{"old"}, "alice", "v1", "")
+	if err != nil {
+		t.Fatalf("CreateFull: %v", err)
+	}
+	id := created.ID
+
+	if err := c.UpdateFull(ctx, id, "Final", "feature", 4, []string{"new", "docs"}, "carol", "v2"); err != nil {
+		t.Fatalf("UpdateFull: %v", err)
+	}
+	got := mustShow(t, ctx, c, id)
+	if got.Title != "Final" || got.IssueType != "feature" || got.Priority != 4 {
+		t.Errorf("title/type/priority = %q/%q/%d", got.Title, got.IssueType, got.Priority)
+	}
+	if got.Assignee != "carol" || got.Description != "v2" {
+		t.Errorf("assignee/description = %q/%q", got.Assignee, got.Description)
+	}
+	assertLabels(t, got, "docs", "new")
+
+	// Labels are replaced, not merged, so an empty set clears them.
+	if err := c.UpdateFull(ctx, id, "Final", "feature", 4, nil, "", "v2"); err != nil {
+		t.Fatalf("UpdateFull clearing labels: %v", err)
+	}
+	got = mustShow(t, ctx, c, id)
+	assertLabels(t, got)
+	if got.Assignee != "" {
+		t.Errorf("assignee = %q after clearing", got.Assignee)
+	}
+}
+
+func testSchedule(t *testing.T, ctx context.Context, c beads.Client) {
+	id := mustCreate(t, ctx, c, "Scheduled")
+	skipIfNotSupported(t, c.UpdateSchedule(ctx, id, "2026-12-01", "2026-11-15", 90), "UpdateSchedule")
+
+	got := mustShow(t, ctx, c, id)
+	if !hasDatePrefix(got.DueAt, "2026-12-01") || !hasDatePrefix(got.DeferUntil, "2026-11-15") || got.EstimatedMinutes != 90 {
+		t.Errorf("due/defer/estimate = %q/%q/%d", got.DueAt, got.DeferUntil, got.EstimatedMinutes)
+	}
+
+	if err := c.UpdateSchedule(ctx, id, "", "", 0); err != nil {
+		t.Fatalf("UpdateSchedule to clear: %v", err)
+	}
+	got = mustShow(t, ctx, c, id)
+	if got.DueAt != "" || got.DeferUntil != "" || got.EstimatedMinutes != 0 {
+		t.Errorf("schedule not cleared: %q/%q/%d", got.DueAt, got.DeferUntil, got.EstimatedMinutes)
+	}
+}
+
+func testExternalRef(t *testing.T, ctx context.Context, c beads.Client) {
+	id := mustCreate(t, ctx, c, "Imported")
+	const ref = "https://example.com/issues/7"
+	skipIfNotSupported(t, c.UpdateExternalRef(ctx, id, ref), "UpdateExternalRef")
+
+	if got := mustShow(t, ctx, c, id); got.ExternalRef != ref {
+		t.Errorf("external_ref = %q, want %q", got.ExternalRef, ref)
+	}
+	if err := c.UpdateExternalRef(ctx, id, ""); err != nil {
+		t.Fatalf("UpdateExternalRef to clear: %v", err)
+	}
+	if got := mustShow(t, ctx, c, id); got.ExternalRef != "" {
+		t.Errorf("external_ref = %q after clearing", got.ExternalRef)
+	}
+}
+
+func testDependencies(t *testing.T, ctx context.Context, c beads.Client) {
+	blocked := mustCreate(t, ctx, c, "Blocked")
+	blocker := mustCreate(t, ctx, c, "Blocker")
+	other := mustCreate(t, ctx, c, "Other")
+
+	if err := c.AddDependency(ctx, blocked, blocker, "blocks"); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+	// An empty type means blocks.
+	if err := c.AddDependency(ctx, blocked, other, ""); err != nil {
+		t.Fatalf("AddDependency with default type: %v", err)
+	}
+
+	issues := mustExport(t, ctx, c)
+	assertDependency(t, issues[blocked], blocker, "blocks")
+	assertDependency(t, issues[blocked], other, "blocks")
+	assertDependent(t, issues[blocker], blocked, "blocks")
+	assertDependent(t, issues[other], blocked, "blocks")
+	if len(issues[blocker].Dependencies) != 0 {
+		t.Errorf("dependencies are directional, but %s depends on %+v", blocker, issues[blocker].Dependencies)
+	}
+
+	if err := c.RemoveDependency(ctx, blocked, blocker, "blocks"); err != nil {
+		t.Fatalf("RemoveDependency: %v", err)
+	}
+	issues = mustExport(t, ctx, c)
+	if hasDependency(issues[blocked], blocker) {
+		t.Errorf("dependency on %s still present after removal: %+v", blocker, issues[blocked].Dependencies)
+	}
+	if len(issues[blocker].Dependents) != 0 {
+		t.Errorf("dependents of %s after removal = %+v", blocker, issues[blocker].Dependents)
+	}
+	assertDependency(t, issues[blocked], other, "blocks")
+}
+
+func testParentChild(t *testing.T, ctx context.Context, c beads.Client) {
+	epic, err := c.CreateFull(ctx, "Epic", "epic", 1, nil, "", "", "")
+	if err != nil {
+		t.Fatalf("CreateFull epic: %v", err)
+	}
+	child, err := c.CreateFull(ctx, "Child", "task", 2, nil, "", "", epic.ID)
+	if err != nil {
+		t.Fatalf("CreateFull child: %v", err)
+	}
+
+	issues := mustExport(t, ctx, c)
+	assertDependency(t, issues[child.ID], epic.ID, "parent-child")
+	assertDependent(t, issues[epic.ID], child.ID, "parent-child")
+}
+
+func testComments(t *testing.T, ctx context.Context, c beads.Client) {
+	id := mustCreate(t, ctx, c, "Discussed")
+
+	empty, err := c.Comments(ctx, id)
+	if err != nil {
+		t.Fatalf("Comments without any: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Fatalf("new issue has comments %+v", empty)
+	}
+
+	texts := []string{"first", "second", "third"}
+	for _, text := range texts {
+		if err := c.AddComment(ctx, id, text); err != nil {
+			t.Fatalf("AddComment %q: %v", text, err)
+		}
+	}
+
+	comments, err := c.Comments(ctx, id)
+	if err != nil {
+		t.Fatalf("Comments: %v", err)
+	}
+	assertCommentTexts(t, comments, texts)
+	seen := make(map[int]bool)
+	for _, cmt := range comments {
+		if cmt.IssueID != id {
+			t.Errorf("comment %d has issue_id %q, want %q", cmt.ID, cmt.IssueID, id)
+		}
+		if cmt.ID <= 0 || seen[cmt.ID] {
+			t.Errorf("comment IDs must be positive and unique, got %d", cmt.ID)
+		}
+		seen[cmt.ID] = true
+		if cmt.CreatedAt == "" {
+			t.Errorf("comment %d has no created_at", cmt.ID)
+		}
+	}
+
+	assertCommentTexts(t, mustShow(t, ctx, c, id).Comments, texts)
+}
+
+func testCommentEditing(t *testing.T, ctx context.Context, c beads.Client) {
+	id := mustCreate(t, ctx, c, "Edited")
+	for _, text := range []string{"keep", "draft"} {
+		if err := c.AddComment(ctx, id, text); err != nil {
+			t.Fatalf("AddComment: %v", err)
+		}
+	}
+	comments, err := c.Comments(ctx, id)
+	if err != nil || len(comments) != 2 {
+		t.Fatalf("Comments = %+v, %v", comments, err)
+	}
+
+	skipIfNotSupported(t, c.UpdateComment(ctx, id, comments[1].ID, "final"), "UpdateComment")
+	comments, err = c.Comments(ctx, id)
+	if err != nil {
+		t.Fatalf("Comments: %v", err)
+	}
+	assertCommentTexts(t, comments, []string{"keep", "final"})
+
+	if err := c.DeleteComment(ctx, id, comments[0].ID); err != nil {
+		t.Fatalf("DeleteComment: %v", err)
+	}
+	comments, err = c.Comments(ctx, id)
+	if err != nil {
+		t.Fatalf("Comments: %v", err)
+	}
+	assertCommentTexts(t, comments, []string{"final"})
+}
+
+func testHistory(t *testing.T, ctx context.Context, c beads.Client) {
+	id := mustCreate(t, ctx, c, "Tracked")
+	if err := c.UpdateStatus(ctx, id, "in_progress"); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	events, err := c.History(ctx, id)
+	skipIfNotSupported(t, err, "History")
+	if events == nil {
+		t.Error("History returned nil; want an empty slice when nothing is recorded")
+	}
+
+	sawStatus, sawInProgress := false, false
+	for i, ev := range events {
+		if ev.IssueID != id {
+			t.Errorf("event %d is for %q, want %q", i, ev.IssueID, id)
+		}
+		if i > 0 && ev.CreatedAt < events[i-1].CreatedAt {
+			t.Errorf("events are not oldest first: %q after %q", ev.CreatedAt, events[i-1].CreatedAt)
+		}
+		if ev.Field == beads.HistoryStatus {
+			sawStatus = true
+			sawInProgress = sawInProgress || ev.NewValue == "in_progress"
+		}
+	}
+	if sawStatus && !sawInProgress {
+		t.Errorf("history records status changes but not the change to in_progress: %+v", events)
+	}
+}
+
+func testDelete(t *testing.T, ctx context.Context, c beads.Client) {
+	doomed := mustCreate(t, ctx, c, "Doomed")
+	survivor := mustCreate(t, ctx, c, "Survivor")
+	if err := c.AddComment(ctx, doomed, "going away"); err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+	if err := c.AddDependency(ctx, survivor, doomed, "blocks"); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+
+	if err := c.Delete(ctx, doomed, false); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	// Deleted issues may linger as tombstones in storage but must not be
+	// visible through any reader.
+	assertGone(t, ctx, c, doomed)
+	issues := mustExport(t, ctx, c)
+	if _, ok := issues[survivor]; !ok {
+		t.Fatalf("Delete of %s also removed %s", doomed, survivor)
+	}
+	for _, iss := range issues {
+		for _, dep := range iss.Dependents {
+			if dep.ID == doomed {
+				t.Errorf("deleted issue %s still listed as a dependent of %s", doomed, iss.ID)
+			}
+		}
+	}
+}
+
+func testDeleteCascade(t *testing.T, ctx context.Context, c beads.Client) {
+	epic, err := c.CreateFull(ctx, "Epic", "epic", 1, nil, "", "", "")
+	if err != nil {
+		t.Fatalf("CreateFull epic: %v", err)
+	}
+	child, err := c.CreateFull(ctx, "Child", "task", 2, nil, "", "", epic.ID)
+	if err != nil {
+		t.Fatalf("CreateFull child: %v", err)
+	}
+	bystander := mustCreate(t, ctx, c, "Bystander")
+
+	if err := c.Delete(ctx, epic.ID, true); err != nil {
+		t.Fatalf("Delete with cascade: %v", err)
+	}
+	assertGone(t, ctx, c, epic.ID)
+	assertGone(t, ctx, c, child.ID)
+	if _, ok := mustExport(t, ctx, c)[bystander]; !ok {
+		t.Errorf("cascading delete removed unrelated issue %s", bystander)
+	}
+}
+
+// testMissingIssueErrors checks that writes to an issue that does not exist
+// fail, and that they are not reported as unsupported: callers use
+// beads.ErrNotSupported to hide features, not to explain failures.
+func testMissingIssueErrors(t *testing.T, ctx context.Context, c beads.Client) {
+	existing := mustCreate(t, ctx, c, "Existing")
+	writes := map[string]func() error{
+		"UpdateStatus":   func() error { return c.UpdateStatus(ctx, missingID, "in_progress") },
+		"Close":          func() error { return c.Close(ctx, missingID) },
+		"Reopen":         func() error { return c.Reopen(ctx, missingID) },
+		"AddLabel":       func() error { return c.AddLabel(ctx, missingID, "x") },
+		"UpdatePriority": func() error { return c.UpdatePriority(ctx, missingID, 1) },
+		"UpdateAssignee": func() error { return c.UpdateAssignee(ctx, missingID, "bob") },
+		"UpdateFull":     func() error { return c.UpdateFull(ctx, missingID, "Title", "task", 2, nil, "", "") },
+		"AddDependency":  func() error { return c.AddDependency(ctx, missingID, existing, "blocks") },
+		"AddDependencyOnMissing": func() error {
+			return c.AddDependency(ctx, existing, missingID, "blocks")
+		},
+		"Delete":     func() error { return c.Delete(ctx, missingID, false) },
+		"AddComment": func() error { return c.AddComment(ctx, missingID, "hello") },
+	}
+	names := make([]string, 0, len(writes))
+	for name := range writes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		err := writes[name]()
+		switch {
+		case err == nil:
+			t.Errorf("%s on a missing issue succeeded", name)
+		case errors.Is(err, beads.ErrNotSupported):
+			t.Errorf("%s on a missing issue reported ErrNotSupported: %v", name, err)
+		}
+	}
+
+	if got := mustShow(t, ctx, c, existing); len(got.Dependencies) != 0 {
+		t.Errorf("failed AddDependency left %+v on %s", got.Dependencies, existing)
+	}
+}
@@ -0,0 +1,588 @@
+package beadstest
+
+import (
+	"cmp"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"abacus/beads"
+	internalbeads "abacus/internal/beads"
+
+	_ "modernc.org/sqlite" // Pure Go SQLite driver
+)
+
+// fakeBrEnv tells a re-executed test binary to act as br.
+const fakeBrEnv = "ABACUS_BEADSTEST_FAKE_BR"
+
+// fakeBrActor is recorded on events and as the default comment author.
+const fakeBrActor = "beadstest"
+
+// fakeBrSchema is the part of the br schema abacus reads and writes,
+// including the optional scheduling columns, the events table and the dirty
+// marks that drive br's JSONL export.
+const fakeBrSchema = `
+	CREATE TABLE issues (
+		id TEXT PRIMARY KEY,
+		title TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		design TEXT NOT NULL DEFAULT '',
+		acceptance_criteria TEXT NOT NULL DEFAULT '',
+		notes TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'open',
+		priority INTEGER NOT NULL DEFAULT 2,
+		issue_type TEXT NOT NULL DEFAULT 'task',
+		assignee TEXT,
+		created_by TEXT DEFAULT '',
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL,
+		closed_at TEXT,
+		close_reason TEXT DEFAULT '',
+		external_ref TEXT,
+		due_at TEXT,
+		defer_until TEXT,
+		estimated_minutes INTEGER,
+		deleted_at TEXT
+	);
+	CREATE TABLE labels (
+		issue_id TEXT NOT NULL,
+		label TEXT NOT NULL,
+		PRIMARY KEY (issue_id, label)
+	);
+	CREATE TABLE dependencies (
+		issue_id TEXT NOT NULL,
+		depends_on_id TEXT NOT NULL,
+		type TEXT NOT NULL DEFAULT 'blocks',
+		PRIMARY KEY (issue_id, depends_on_id)
+	);
+	CREATE TABLE comments (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		issue_id TEXT NOT NULL,
+		author TEXT NOT NULL,
+		text TEXT NOT NULL,
+		created_at TEXT
+	);
+	CREATE TABLE dirty_issues (
+		issue_id TEXT PRIMARY KEY,
+		marked_at TEXT NOT NULL
+	);
+	CREATE TABLE config (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	);
+	INSERT INTO config (key, value) VALUES ('issue_prefix', 'bt');
+	CREATE TABLE events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		issue_id TEXT NOT NULL,
+		event_type TEXT NOT NULL,
+		actor TEXT NOT NULL,
+		old_value TEXT,
+		new_value TEXT,
+		comment TEXT,
+		created_at TEXT NOT NULL
+	);
+`
+
+// FakeBrFactory returns a Factory for abacus's own br clients, running
+// against a fresh workspace and the fake br from FakeBr. opts are passed on
+// to the br client; they can only be built inside the abacus module.
+func FakeBrFactory(opts ...internalbeads.BrCLIOption) Factory {
+	return func(t *testing.T) beads.Client {
+		t.Helper()
+		clientOpts := append([]internalbeads.BrCLIOption{internalbeads.WithBrBinaryPath(FakeBr(t))}, opts...)
+		return internalbeads.NewBrSQLiteClient(NewBrWorkspace(t), clientOpts...)
+	}
+}
+
+// NewBrWorkspace creates an empty br workspace in a temporary directory and
+// returns the path of its database.
+func NewBrWorkspace(t *testing.T) string {
+	t.Helper()
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.Mkdir(beadsDir, 0o750); err != nil {
+		t.Fatalf("create workspace: %v", err)
+	}
+	dbPath := filepath.Join(beadsDir, "beads.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open workspace db: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	if _, err := db.Exec(fakeBrSchema); err != nil {
+		t.Fatalf("create workspace schema: %v", err)
+	}
+	return dbPath
+}
+
+// FakeBr returns the path of a br executable that implements the commands
+// abacus runs against the br SQLite schema. It re-executes the test binary,
+// so the test package's TestMain must call RunFakeBrIfRequested first.
+func FakeBr(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake br needs a POSIX shell")
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("locate test binary: %v", err)
+	}
+	// -test.run=^$ keeps a binary without RunFakeBrIfRequested from running
+	// its tests again; it fails on br's flags instead.
+	script := fmt.Sprintf("#!/bin/sh\n%s=1 exec '%s' -test.run='^$' \"$@\"\n", fakeBrEnv, strings.ReplaceAll(exe, "'", `'\''`))
+	path := filepath.Join(t.TempDir(), "br")
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil { //nolint:gosec // G306: the script must be executable
+		t.Fatalf("write fake br: %v", err)
+	}
+	return path
+}
+
+// RunFakeBrIfRequested turns the process into the fake br when it was
+// started through FakeBr, exiting once the command is done. Otherwise it
+// returns immediately. Call it at the start of TestMain.
+func RunFakeBrIfRequested() {
+	if os.Getenv(fakeBrEnv) != "1" {
+		return
+	}
+	args := os.Args[1:]
+	if len(args) > 0 && strings.HasPrefix(args[0], "-test.run=") {
+		args = args[1:]
+	}
+	if err := runFakeBr(args, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+type fakeBr struct {
+	db  *sql.DB
+	out io.Writer
+	now string
+}
+
+func runFakeBr(args []string, out io.Writer) error {
+	dbPath := filepath.Join(".beads", "beads.db")
+	if len(args) >= 2 && args[0] == "--db" {
+		dbPath, args = args[1], args[2:]
+	}
+	if len(args) == 0 {
+		return errors.New("no command given")
+	}
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	// Fixed-width timestamps keep string ordering chronological.
+	br := &fakeBr{db: db, out: out, now: time.Now().UTC().Format("2006-01-02T15:04:05.000000000Z")}
+
+	cmd, parsed := args[0], parseFakeBrArgs(args[1:])
+	if len(parsed.positional) > 0 && (cmd == "label" || cmd == "dep" || cmd == "comments") {
+		cmd += " " + parsed.positional[0]
+		parsed.positional = parsed.positional[1:]
+	}
+	switch cmd {
+	case "create":
+		return br.create(parsed)
+	case "update":
+		return br.update(parsed)
+	case "close":
+		return br.close(parsed)
+	case "reopen":
+		return br.reopen(parsed)
+	case "label add", "label remove":
+		return br.label(cmd == "label add", parsed)
+	case "dep add":
+		return br.addDependency(parsed)
+	case "dep remove":
+		return br.removeDependency(parsed)
+	case "delete":
+		return br.delete(parsed)
+	case "comments add":
+		return br.addComment(parsed)
+	default:
+		return fmt.Errorf("unsupported command %q", cmd)
+	}
+}
+
+type fakeBrArgs struct {
+	positional []string
+	flags      map[string]string
+}
+
+// fakeBrSwitches are the br flags that take no value.
+var fakeBrSwitches = map[string]bool{"json": true, "force": true, "cascade": true}
+
+func parseFakeBrArgs(args []string) fakeBrArgs {
+	parsed := fakeBrArgs{flags: make(map[string]string)}
+	for i := 0; i < len(args); i++ {
+		name, ok := strings.CutPrefix(args[i], "--")
+		if !ok {
+			parsed.positional = append(parsed.positional, args[i])
+			continue
+		}
+		if key, value, ok := strings.Cut(name, "="); ok {
+			parsed.flags[key] = value
+			continue
+		}
+		if fakeBrSwitches[name] || i+1 == len(args) {
+			parsed.flags[name] = "true"
+			continue
+		}
+		i++
+		parsed.flags[name] = args[i]
+	}
+	return parsed
+}
+
+// arg returns the nth positional argument.
+func (a fakeBrArgs) arg(n int, name string) (string, error) {
+	if n >= len(a.positional) || strings.TrimSpace(a.positional[n]) == "" {
+		return "", fmt.Errorf("missing %s", name)
+	}
+	return a.positional[n], nil
+}
+
+// status returns the status of a live issue, failing like br does for
+// unknown and deleted issues.
+func (br *fakeBr) status(id string) (string, error) {
+	var status string
+	err := br.db.QueryRow(`SELECT status FROM issues WHERE id = ? AND status != 'tombstone' AND deleted_at IS NULL`, id).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("issue not found: %s", id)
+	}
+	return status, err
+}
+
+// event records a change to an issue and marks it dirty for export.
+func (br *fakeBr) event(issueID, eventType string, oldValue, newValue, comment any) error {
+	_, err := br.db.Exec(`INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`, issueID, eventType, fakeBrActor, oldValue, newValue, comment, br.now)
+	if err != nil {
+		return err
+	}
+	return br.markDirty(issueID)
+}
+
+func (br *fakeBr) markDirty(issueID string) error {
+	_, err := br.db.Exec(`INSERT OR REPLACE INTO dirty_issues (issue_id, marked_at) VALUES (?, ?)`, issueID, br.now)
+	return err
+}
+
+func (br *fakeBr) touch(id string) error {
+	_, err := br.db.Exec(`UPDATE issues SET updated_at = ? WHERE id = ?`, br.now, id)
+	return err
+}
+
+func (br *fakeBr) setLabels(id string, csv string) error {
+	for _, label := range strings.Split(csv, ",") {
+		if label = strings.TrimSpace(label); label == "" {
+			continue
+		}
+		if _, err := br.db.Exec(`INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`, id, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (br *fakeBr) create(a fakeBrArgs) error {
+	title, err := a.arg(0, "title")
+	if err != nil {
+		return err
+	}
+	issue := beads.FullIssue{
+		Title:       title,
+		Status:      "open",
+		IssueType:   cmp.Or(a.flags["type"], "task"),
+		Priority:    2,
+		Description: a.flags["description"],
+		Assignee:    a.flags["assignee"],
+		CreatedAt:   br.now,
+		UpdatedAt:   br.now,
+		Labels:      []string{},
+	}
+	if p, ok := a.flags["priority"]; ok {
+		if issue.Priority, err = strconv.Atoi(p); err != nil {
+			return fmt.Errorf("invalid priority %q", p)
+		}
+	}
+	var count int
+	if err := br.db.QueryRow(`SELECT COUNT(*) FROM issues`).Scan(&count); err != nil {
+		return err
+	}
+	issue.ID = fmt.Sprintf("bt-%d", count+1)
+
+	_, err = br.db.Exec(`INSERT INTO issues (id, title, description, status, priority, issue_type, assignee, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		issue.ID, issue.Title, issue.Description, issue.Status, issue.Priority, issue.IssueType,
+		nullIfEmpty(issue.Assignee), fakeBrActor, br.now, br.now)
+	if err != nil {
+		return err
+	}
+	if err := br.setLabels(issue.ID, a.flags["labels"]); err != nil {
+		return err
+	}
+	if err := br.event(issue.ID, "created", nil, nil, nil); err != nil {
+		return err
+	}
+
+	if a.flags["json"] != "true" {
+		_, err := fmt.Fprintf(br.out, "Created issue %s\n", issue.ID)
+		return err
+	}
+	for _, label := range strings.Split(a.flags["labels"], ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			issue.Labels = append(issue.Labels, label)
+		}
+	}
+	return json.NewEncoder(br.out).Encode(issue)
+}
+
+// fakeBrUpdateColumns maps `br update` flags onto issue columns. Nullable
+// columns are cleared by an empty value.
+var fakeBrUpdateColumns = map[string]struct {
+	column   string
+	integer  bool
+	nullable bool
+}{
+	"title":        {column: "title"},
+	"description":  {column: "description"},
+	"type":         {column: "issue_type"},
+	"priority":     {column: "priority", integer: true},
+	"assignee":     {column: "assignee", nullable: true},
+	"due":          {column: "due_at", nullable: true},
+	"defer":        {column: "defer_until", nullable: true},
+	"estimate":     {column: "estimated_minutes", integer: true},
+	"external-ref": {column: "external_ref", nullable: true},
+}
+
+func (br *fakeBr) update(a fakeBrArgs) error {
+	id, err := a.arg(0, "issue id")
+	if err != nil {
+		return err
+	}
+	oldStatus, err := br.status(id)
+	if err != nil {
+		return err
+	}
+	for flag, value := range a.flags {
+		switch flag {
+		case "status":
+			_, err = br.db.Exec(`UPDATE issues SET status = ?, closed_at = CASE WHEN ? = 'closed' THEN ? END WHERE id = ?`,
+				value, value, br.now, id)
+			if err == nil && value != oldStatus {
+				err = br.event(id, "status_changed", oldStatus, value, nil)
+			}
+		case "set-labels":
+			if _, err = br.db.Exec(`DELETE FROM labels WHERE issue_id = ?`, id); err == nil {
+				err = br.setLabels(id, value)
+			}
+		default:
+			col, ok := fakeBrUpdateColumns[flag]
+			if !ok {
+				return fmt.Errorf("unknown flag --%s", flag)
+			}
+			var v any = value
+			if col.integer {
+				if v, err = strconv.Atoi(value); err != nil {
+					return fmt.Errorf("invalid --%s %q", flag, value)
+				}
+			} else if col.nullable {
+				v = nullIfEmpty(value)
+			}
+			_, err = br.db.Exec(`UPDATE issues SET `+col.column+` = ? WHERE id = ?`, v, id) //nolint:gosec // G202: column comes from fakeBrUpdateColumns
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := br.touch(id); err != nil {
+		return err
+	}
+	return br.markDirty(id)
+}
+
+func (br *fakeBr) close(a fakeBrArgs) error {
+	id, err := a.arg(0, "issue id")
+	if err != nil {
+		return err
+	}
+	oldStatus, err := br.status(id)
+	if err != nil {
+		return err
+	}
+	reason := a.flags["reason"]
+	if _, err := br.db.Exec(`UPDATE issues SET status = 'closed', closed_at = ?, close_reason = ?, updated_at = ? WHERE id = ?`,
+		br.now, reason, br.now, id); err != nil {
+		return err
+	}
+	return br.event(id, "closed", oldStatus, "closed", nullIfEmpty(reason))
+}
+
+func (br *fakeBr) reopen(a fakeBrArgs) error {
+	id, err := a.arg(0, "issue id")
+	if err != nil {
+		return err
+	}
+	oldStatus, err := br.status(id)
+	if err != nil {
+		return err
+	}
+	if _, err := br.db.Exec(`UPDATE issues SET status = 'open', closed_at = NULL, close_reason = '', updated_at = ? WHERE id = ?`,
+		br.now, id); err != nil {
+		return err
+	}
+	return br.event(id, "reopened", oldStatus, "open", nil)
+}
+
+func (br *fakeBr) label(add bool, a fakeBrArgs) error {
+	id, err := a.arg(0, "issue id")
+	if err != nil {
+		return err
+	}
+	label, err := a.arg(1, "label")
+	if err != nil {
+		return err
+	}
+	if _, err := br.status(id); err != nil {
+		return err
+	}
+	if add {
+		if err := br.setLabels(id, label); err != nil {
+			return err
+		}
+		return br.event(id, "label_added", nil, label, nil)
+	}
+	if _, err := br.db.Exec(`DELETE FROM labels WHERE issue_id = ? AND label = ?`, id, label); err != nil {
+		return err
+	}
+	return br.event(id, "label_removed", label, nil, nil)
+}
+
+func (br *fakeBr) addDependency(a fakeBrArgs) error {
+	from, err := a.arg(0, "issue id")
+	if err != nil {
+		return err
+	}
+	to, err := a.arg(1, "depends-on id")
+	if err != nil {
+		return err
+	}
+	for _, id := range []string{from, to} {
+		if _, err := br.status(id); err != nil {
+			return err
+		}
+	}
+	depType := cmp.Or(a.flags["type"], "blocks")
+	if _, err := br.db.Exec(`INSERT OR REPLACE INTO dependencies (issue_id, depends_on_id, type) VALUES (?, ?, ?)`,
+		from, to, depType); err != nil {
+		return err
+	}
+	return br.event(from, "dependency_added", nil, to, nil)
+}
+
+func (br *fakeBr) removeDependency(a fakeBrArgs) error {
+	from, err := a.arg(0, "issue id")
+	if err != nil {
+		return err
+	}
+	to, err := a.arg(1, "depends-on id")
+	if err != nil {
+		return err
+	}
+	if _, err := br.status(from); err != nil {
+		return err
+	}
+	if _, err := br.db.Exec(`DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?`, from, to); err != nil {
+		return err
+	}
+	return br.event(from, "dependency_removed", to, nil, nil)
+}
+
+// delete tombstones the issue and, with --cascade, everything that depends
+// on it, dropping their dependency links.
+func (br *fakeBr) delete(a fakeBrArgs) error {
+	id, err := a.arg(0, "issue id")
+	if err != nil {
+		return err
+	}
+	if _, err := br.status(id); err != nil {
+		return err
+	}
+	doomed := []string{id}
+	seen := map[string]bool{id: true}
+	for i := 0; i < len(doomed) && a.flags["cascade"] == "true"; i++ {
+		rows, err := br.db.Query(`SELECT issue_id FROM dependencies WHERE depends_on_id = ?`, doomed[i])
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var dependent string
+			if err := rows.Scan(&dependent); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			if !seen[dependent] {
+				seen[dependent] = true
+				doomed = append(doomed, dependent)
+			}
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+	for _, doomedID := range doomed {
+		if _, err := br.db.Exec(`UPDATE issues SET status = 'tombstone', deleted_at = ?, updated_at = ? WHERE id = ?`,
+			br.now, br.now, doomedID); err != nil {
+			return err
+		}
+		if _, err := br.db.Exec(`DELETE FROM dependencies WHERE issue_id = ? OR depends_on_id = ?`, doomedID, doomedID); err != nil {
+			return err
+		}
+		if err := br.markDirty(doomedID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (br *fakeBr) addComment(a fakeBrArgs) error {
+	id, err := a.arg(0, "issue id")
+	if err != nil {
+		return err
+	}
+	text, err := a.arg(1, "comment text")
+	if err != nil {
+		return err
+	}
+	if _, err := br.status(id); err != nil {
+		return err
+	}
+	if _, err := br.db.Exec(`INSERT INTO comments (issue_id, author, text, created_at) VALUES (?, ?, ?, ?)`,
+		id, cmp.Or(a.flags["author"], fakeBrActor), text, br.now); err != nil {
+		return err
+	}
+	return br.event(id, "commented", nil, nil, text)
+}
+
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+
+	"abacus/internal/beads"
+	"abacus/internal/ui"
+)
+
+// project is the beads database for the current directory and a client for
+// it, for subcommands that work on the project without starting the TUI.
+type project struct {
+	client  beads.Client
+	backend string
+	dbPath  string
+}
+
+// openProject finds the beads database for the current directory and
+// returns a client for the detected (or forced) backend.
+func openProject(backendFlag string, skipVersionCheck bool) (project, error) {
+	dbPath, _, err := ui.FindBeadsDB()
+	if err != nil {
+		return project{}, err
+	}
+	backend, err := beads.DetectBackend(beads.DetectBackendOptions{
+		CLIFlag:          strings.TrimSpace(backendFlag),
+		SkipVersionCheck: skipVersionCheck,
+	})
+	if err != nil {
+		return project{}, err
+	}
+	client, err := beads.NewClientForBackend(backend, dbPath)
+	if err != nil {
+		return project{}, err
+	}
+	return project{client: client, backend: backend, dbPath: dbPath}, nil
+}
+
+// close releases the project's client.
+func (p project) close() {
+	_ = beads.ShutdownClient(p.client)
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"abacus/internal/beads"
+	"abacus/internal/config"
+	"abacus/internal/gitinfo"
+)
+
+// hookMarker identifies hook scripts written by `abacus hook install`, so
+// reinstalling replaces them but never someone else's hook.
+const hookMarker = "# Installed by abacus hook install"
+
+// hookTimeout bounds each hook run so a stuck backend never hangs git.
+const hookTimeout = 30 * time.Second
+
+// hookNames are the git hooks `abacus hook install` writes.
+var hookNames = []string{"commit-msg", "post-commit"}
+
+func printHookUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: abacus hook <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Ties commits to beads through message trailers:")
+	fmt.Fprintln(w, "  Closes: ab-12    close the bead, with the commit hash as the close reason")
+	fmt.Fprintln(w, "  Refs: ab-12      comment on the bead with the commit hash")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	fmt.Fprintln(w, "  install [--force]      install the commit-msg and post-commit hooks in this repository")
+	fmt.Fprintln(w, "  commit-msg <file>      reject commits whose trailers reference unknown beads")
+	fmt.Fprintln(w, "  post-commit            close or comment on the beads referenced by HEAD")
+}
+
+// runHookCommand implements `abacus hook` and returns the process exit code.
+func runHookCommand(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printHookUsage(stderr)
+		return 2
+	}
+	switch args[0] {
+	case "install":
+		return runHookInstall(args[1:], stdout, stderr)
+	case "commit-msg":
+		return runCommitMsgHook(args[1:], stderr)
+	case "post-commit":
+		return runPostCommitHook(args[1:], stdout, stderr)
+	case "-h", "--help", "help":
+		printHookUsage(stdout)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "Error: unknown hook command %q\n\n", args[0])
+		printHookUsage(stderr)
+		return 2
+	}
+}
+
+// newHookFlagSet returns a flag set with the backend flags shared by the
+// hook commands.
+func newHookFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *string, *bool) {
+	fs := flag.NewFlagSet("hook "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	backend := fs.String("backend", "", "Force backend (bd, br or a plugin name) - overrides auto-detection, one-time only")
+	skipVersionCheck := fs.Bool("skip-version-check", config.GetBool(config.KeySkipVersionCheck), "Skip Beads CLI version validation")
+	return fs, backend, skipVersionCheck
+}
+
+func runCommitMsgHook(args []string, stderr io.Writer) int {
+	fs, backendFlag, skipVersionCheckFlag := newHookFlagSet("commit-msg", stderr)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "Usage: abacus hook commit-msg <message-file>")
+		return 2
+	}
+	//nolint:gosec // G304: git passes the path of the message being committed
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "abacus: read commit message: %v\n", err)
+		return 1
+	}
+	if len(gitinfo.ParseTrailers(string(data))) == 0 {
+		return 0
+	}
+
+	proj, err := openProject(*backendFlag, *skipVersionCheckFlag)
+	if err != nil {
+		// Tooling problems should not block commits; only bad references do.
+		fmt.Fprintf(stderr, "abacus: skipping bead trailer check: %v\n", err)
+		return 0
+	}
+	defer proj.close()
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+	if err := checkCommitMessage(ctx, proj.client, string(data)); err != nil {
+		fmt.Fprintf(stderr, "abacus: %v\n", err)
+		fmt.Fprintln(stderr, "abacus: fix the trailer or commit with --no-verify to skip this check")
+		return 1
+	}
+	return 0
+}
+
+func runPostCommitHook(args []string, stdout, stderr io.Writer) int {
+	fs, backendFlag, skipVersionCheckFlag := newHookFlagSet("post-commit", stderr)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+	repo, err := gitinfo.Open(ctx, ".")
+	if err != nil {
+		fmt.Fprintf(stderr, "abacus: %v\n", err)
+		return 1
+	}
+	commit, message, err := repo.HeadCommit(ctx)
+	if err != nil {
+		fmt.Fprintf(stderr, "abacus: %v\n", err)
+		return 1
+	}
+	if len(gitinfo.ParseTrailers(message)) == 0 {
+		return 0
+	}
+
+	proj, err := openProject(*backendFlag, *skipVersionCheckFlag)
+	if err != nil {
+		fmt.Fprintf(stderr, "abacus: %v\n", err)
+		return 1
+	}
+	defer proj.close()
+	if err := applyCommitTrailers(ctx, proj.client, commit, message, stdout); err != nil {
+		fmt.Fprintf(stderr, "abacus: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// checkCommitMessage returns an error naming any beads referenced by the
+// message's trailers that do not exist.
+func checkCommitMessage(ctx context.Context, reader beads.Reader, message string) error {
+	trailers := gitinfo.ParseTrailers(message)
+	if len(trailers) == 0 {
+		return nil
+	}
+	found, err := findTrailerBeads(ctx, reader, trailers)
+	if err != nil {
+		return err
+	}
+	var unknown []string
+	for _, t := range trailers {
+		if _, ok := found[t.ID]; !ok {
+			unknown = append(unknown, t.ID)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("commit message references unknown %s: %s", pluralBeads(len(unknown)), strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// applyCommitTrailers closes the beads a commit's Closes trailers name and
+// comments on the ones its Refs trailers name, printing one line per bead.
+// Unknown and already closed beads are skipped, as are beads that already
+// reference the commit; the first write error stops.
+func applyCommitTrailers(ctx context.Context, client beads.Client, commit gitinfo.Commit, message string, out io.Writer) error {
+	trailers := gitinfo.ParseTrailers(message)
+	if len(trailers) == 0 {
+		return nil
+	}
+	found, err := findTrailerBeads(ctx, client, trailers)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range trailers {
+		issue, ok := found[t.ID]
+		if !ok {
+			fmt.Fprintf(out, "abacus: skipping unknown bead %s\n", t.ID)
+			continue
+		}
+		switch t.Action {
+		case gitinfo.TrailerCloses:
+			if issue.Status == "closed" {
+				fmt.Fprintf(out, "abacus: %s is already closed\n", t.ID)
+				continue
+			}
+			reason := fmt.Sprintf("Closed by commit %s: %s", commit.Hash, commit.Subject)
+			if err := closeWithReason(ctx, client, t.ID, reason); err != nil {
+				return fmt.Errorf("close %s: %w", t.ID, err)
+			}
+			fmt.Fprintf(out, "abacus: closed %s\n", t.ID)
+		case gitinfo.TrailerRefs:
+			if hasCommitReference(issue, commit.Hash) {
+				// post-commit runs again on amend, rebase and cherry-pick.
+				fmt.Fprintf(out, "abacus: %s already references %s\n", t.ID, commit.Hash)
+				continue
+			}
+			text := fmt.Sprintf("Referenced by commit %s: %s", commit.Hash, commit.Subject)
+			if err := client.AddComment(ctx, t.ID, text); err != nil {
+				return fmt.Errorf("comment on %s: %w", t.ID, err)
+			}
+			fmt.Fprintf(out, "abacus: commented on %s\n", t.ID)
+		}
+	}
+	return nil
+}
+
+// hasCommitReference reports whether the bead already has the comment a Refs
+// trailer of the commit adds.
+func hasCommitReference(issue beads.FullIssue, hash string) bool {
+	prefix := fmt.Sprintf("Referenced by commit %s:", hash)
+	for _, c := range issue.Comments {
+		if strings.HasPrefix(c.Text, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// closeWithReason closes the bead with a reason, falling back to a plain
+// close plus a comment on backends that cannot record reasons.
+func closeWithReason(ctx context.Context, client beads.Writer, id, reason string) error {
+	err := client.CloseWithReason(ctx, id, reason)
+	if !errors.Is(err, beads.ErrNotSupported) {
+		return err
+	}
+	if err := client.Close(ctx, id); err != nil {
+		return err
+	}
+	return client.AddComment(ctx, id, reason)
+}
+
+func findTrailerBeads(ctx context.Context, reader beads.Reader, trailers []gitinfo.Trailer) (map[string]beads.FullIssue, error) {
+	ids := make([]string, 0, len(trailers))
+	for _, t := range trailers {
+		ids = append(ids, t.ID)
+	}
+	issues, err := reader.Show(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("look up beads: %w", err)
+	}
+	found := make(map[string]beads.FullIssue, len(issues))
+	for _, iss := range issues {
+		found[iss.ID] = iss
+	}
+	return found, nil
+}
+
+func runHookInstall(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("hook install", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	force := fs.Bool("force", false, "Replace existing hooks that abacus did not install")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+	repo, err := gitinfo.Open(ctx, ".")
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	dir, err := repo.HooksDir(ctx)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if err := installHooks(dir, hookCommand(), *force, stdout); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// hookCommand is how installed hooks invoke abacus: by name when it is on
+// PATH, so upgrades keep working, otherwise by this binary's path.
+func hookCommand() string {
+	if _, err := exec.LookPath("abacus"); err == nil {
+		return "abacus"
+	}
+	if exe, err := os.Executable(); err == nil {
+		return "'" + strings.ReplaceAll(exe, "'", `'\''`) + "'"
+	}
+	return "abacus"
+}
+
+// installHooks writes the hook scripts into dir. Existing hooks that abacus
+// did not write are only replaced with force.
+func installHooks(dir, command string, force bool, out io.Writer) error {
+	if !force {
+		for _, name := range hookNames {
+			path := filepath.Join(dir, name)
+			//nolint:gosec // G304: path is inside the repository's hooks directory
+			existing, err := os.ReadFile(path)
+			if err == nil && !strings.Contains(string(existing), hookMarker) {
+				return fmt.Errorf("%s already exists; re-run with --force to replace it", path)
+			}
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create hooks directory: %w", err)
+	}
+	for _, name := range hookNames {
+		args := ""
+		if name == "commit-msg" {
+			args = ` "$1"`
+		}
+		script := fmt.Sprintf("#!/bin/sh\n%s\nexec %s hook %s%s\n", hookMarker, command, name, args)
+		path := filepath.Join(dir, name)
+		//nolint:gosec // G306: git hooks must be executable
+		if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+			return fmt.Errorf("write %s hook: %w", name, err)
+		}
+		fmt.Fprintf(out, "Installed %s\n", path)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"abacus/internal/beads"
+	"abacus/internal/gitinfo"
+)
+
+func hookTestClient(issues ...beads.FullIssue) *beads.MockClient {
+	m := beads.NewMockClient()
+	m.ShowFn = func(_ context.Context, ids []string) ([]beads.FullIssue, error) {
+		var found []beads.FullIssue
+		for _, id := range ids {
+			for _, iss := range issues {
+				if iss.ID == id {
+					found = append(found, iss)
+				}
+			}
+		}
+		return found, nil
+	}
+	return m
+}
+
+func TestCheckCommitMessage_RejectsUnknownBeads(t *testing.T) {
+	m := hookTestClient(beads.FullIssue{ID: "ab-1", Status: "open"})
+
+	if err := checkCommitMessage(context.Background(), m, "Fix crash\n\nCloses: ab-1\n"); err != nil {
+		t.Fatalf("expected known bead to pass, got %v", err)
+	}
+	err := checkCommitMessage(context.Background(), m, "Fix crash\n\nCloses: ab-1, ab-9\nRefs: ab-8\n")
+	if err == nil || !strings.Contains(err.Error(), "ab-9, ab-8") {
+		t.Fatalf("expected unknown beads to be named, got %v", err)
+	}
+}
+
+func TestApplyCommitTrailers(t *testing.T) {
+	m := hookTestClient(
+		beads.FullIssue{ID: "ab-1", Status: "open"},
+		beads.FullIssue{ID: "ab-2", Status: "closed"},
+		beads.FullIssue{ID: "ab-3", Status: "open"},
+	)
+	commit := gitinfo.Commit{Hash: "abc1234", Subject: "Fix crash"}
+	message := "Fix crash\n\nCloses: ab-1 ab-2\nRefs: ab-3, ab-9\n"
+
+	var out bytes.Buffer
+	if err := applyCommitTrailers(context.Background(), m, commit, message, &out); err != nil {
+		t.Fatalf("applyCommitTrailers: %v", err)
+	}
+	if m.CloseWithReasonCallCount != 1 || m.CloseWithReasonCallArgs[0][0] != "ab-1" {
+		t.Fatalf("expected only ab-1 to be closed, got %v", m.CloseWithReasonCallArgs)
+	}
+	if reason := m.CloseWithReasonCallArgs[0][1]; reason != "Closed by commit abc1234: Fix crash" {
+		t.Errorf("unexpected close reason %q", reason)
+	}
+	if m.AddCommentCallCount != 1 || m.AddCommentCallArgs[0][0] != "ab-3" {
+		t.Fatalf("expected a comment on ab-3, got %v", m.AddCommentCallArgs)
+	}
+	for _, want := range []string{"closed ab-1", "ab-2 is already closed", "commented on ab-3", "skipping unknown bead ab-9"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected output to contain %q, got %q", want, out.String())
+		}
+	}
+}
+
+func TestApplyCommitTrailers_FallsBackWithoutCloseReasons(t *testing.T) {
+	m := hookTestClient(beads.FullIssue{ID: "ab-1", Status: "open"})
+	m.CloseWithReasonFn = func(context.Context, string, string) error { return beads.ErrNotSupported }
+
+	commit := gitinfo.Commit{Hash: "abc1234", Subject: "Fix crash"}
+	if err := applyCommitTrailers(context.Background(), m, commit, "Fix crash\n\nCloses: ab-1\n", &bytes.Buffer{}); err != nil {
+		t.Fatalf("applyCommitTrailers: %v", err)
+	}
+	if m.CloseCallCount != 1 {
+		t.Errorf("expected fallback close, got %d", m.CloseCallCount)
+	}
+	if m.AddCommentCallCount != 1 || m.AddCommentCallArgs[0][1] != "Closed by commit abc1234: Fix crash" {
+		t.Errorf("expected close reason recorded as a comment, got %v", m.AddCommentCallArgs)
+	}
+}
+
+func TestApplyCommitTrailers_SkipsExistingReference(t *testing.T) {
+	m := hookTestClient(beads.FullIssue{ID: "ab-3", Status: "open", Comments: []beads.Comment{
+		{Text: "Referenced by commit abc1234: Fix crash"},
+	}})
+
+	commit := gitinfo.Commit{Hash: "abc1234", Subject: "Fix crash (amended)"}
+	var out bytes.Buffer
+	if err := applyCommitTrailers(context.Background(), m, commit, "Fix crash\n\nRefs: ab-3\n", &out); err != nil {
+		t.Fatalf("applyCommitTrailers: %v", err)
+	}
+	if m.AddCommentCallCount != 0 {
+		t.Errorf("expected no second comment for the same commit, got %v", m.AddCommentCallArgs)
+	}
+	if !strings.Contains(out.String(), "ab-3 already references abc1234") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
+
+func TestCheckCommitMessage_IgnoresNonBeadReferences(t *testing.T) {
+	m := hookTestClient(beads.FullIssue{ID: "ab-1", Status: "open"})
+	message := "Fix crash\n\nThis refs: the old parser.\n\nCloses: #12\nRefs: JIRA-123, ab-1\n"
+	if err := checkCommitMessage(context.Background(), m, message); err != nil {
+		t.Fatalf("expected non-bead references to be ignored, got %v", err)
+	}
+}
+
+func TestInstallHooks(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+	if err := installHooks(dir, "abacus", false, &bytes.Buffer{}); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "commit-msg"))
+	if err != nil {
+		t.Fatalf("read hook: %v", err)
+	}
+	if !strings.Contains(string(data), `exec abacus hook commit-msg "$1"`) {
+		t.Errorf("unexpected commit-msg hook:\n%s", data)
+	}
+	if info, err := os.Stat(filepath.Join(dir, "post-commit")); err != nil || info.Mode()&0o100 == 0 {
+		t.Errorf("expected executable post-commit hook, err=%v", err)
+	}
+
+	// Reinstalling over our own hooks is fine; someone else's needs --force.
+	if err := installHooks(dir, "abacus", false, &bytes.Buffer{}); err != nil {
+		t.Fatalf("reinstall: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "post-commit"), []byte("#!/bin/sh\nmake lint\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := installHooks(dir, "abacus", false, &bytes.Buffer{}); err == nil {
+		t.Fatal("expected foreign hook to block install")
+	}
+	if err := installHooks(dir, "abacus", true, &bytes.Buffer{}); err != nil {
+		t.Fatalf("forced install: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"abacus/internal/beads"
+	"abacus/internal/config"
+	"abacus/internal/importer"
+)
+
+// importOptions holds the parsed `abacus import` flags.
+type importOptions struct {
+	format      string
+	jiraBaseURL string
+	dryRun      bool
+}
+
+// runImportCommand implements `abacus import [flags] <export-file>` and
+// returns the process exit code.
+func runImportCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	formatFlag := fs.String("format", "", "Export format: github, gitlab or jira (default: detect from the file)")
+	jiraURLFlag := fs.String("jira-url", "", "Jira site URL used to build issue links, e.g. https://acme.atlassian.net")
+	dryRunFlag := fs.Bool("dry-run", false, "Show what would be created without writing anything")
+	backendFlag := fs.String("backend", "", "Force backend (bd, br or a plugin name) - overrides auto-detection, one-time only")
+	skipVersionCheckFlag := fs.Bool("skip-version-check", config.GetBool(config.KeySkipVersionCheck), "Skip Beads CLI version validation")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: abacus import [flags] <export-file>")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Creates beads from a GitHub issues JSON (gh issue list --json), GitLab CSV or Jira CSV export.")
+		fmt.Fprintln(stderr, "Issues already imported (matched by external ref) are skipped.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	proj, err := openProject(*backendFlag, *skipVersionCheckFlag)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer proj.close()
+	if proj.backend == beads.BackendBd && !*dryRunFlag {
+		fmt.Fprintln(stderr, "Error: import requires the br backend; bd cannot store external refs, so re-imports could not skip existing beads")
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	opts := importOptions{
+		format:      *formatFlag,
+		jiraBaseURL: *jiraURLFlag,
+		dryRun:      *dryRunFlag,
+	}
+	if _, err := importExportFile(ctx, proj.client, fs.Arg(0), opts, stdout); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// importExportFile parses the export at path and imports it, printing one
+// line per record and a summary to out.
+func importExportFile(ctx context.Context, client beads.Client, path string, opts importOptions, out io.Writer) (importer.Result, error) {
+	//nolint:gosec // G304: The export path is supplied by the user on the command line
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return importer.Result{}, fmt.Errorf("read export: %w", err)
+	}
+	var format importer.Format
+	if strings.TrimSpace(opts.format) != "" {
+		format, err = importer.ParseFormat(opts.format)
+	} else {
+		format, err = importer.DetectFormat(path, data)
+	}
+	if err != nil {
+		return importer.Result{}, err
+	}
+	records, err := importer.Parse(format, data, importer.ParseOptions{JiraBaseURL: opts.jiraBaseURL})
+	if err != nil {
+		return importer.Result{}, err
+	}
+	fmt.Fprintf(out, "Importing %d %s issues from %s\n", len(records), format, path)
+
+	result, err := importer.Import(ctx, client, records, importer.Options{
+		DryRun:   opts.dryRun,
+		Progress: func(e importer.Entry) { printImportEntry(out, e, opts.dryRun) },
+	})
+	created, existing := result.Count(importer.ActionCreated), result.Count(importer.ActionExists)
+	verb := "Created"
+	if opts.dryRun {
+		verb = "Would create"
+	}
+	fmt.Fprintf(out, "%s %d %s, %d already imported\n", verb, created, pluralBeads(created), existing)
+	return result, err
+}
+
+func printImportEntry(out io.Writer, e importer.Entry, dryRun bool) {
+	switch e.Action {
+	case importer.ActionExists:
+		fmt.Fprintf(out, "  exists   %-10s %s\n", e.ID, e.Record.Title)
+	case importer.ActionCreated:
+		id := e.ID
+		if dryRun {
+			id = "(new)"
+		}
+		line := fmt.Sprintf("  created  %-10s %s", id, e.Record.Title)
+		if e.Orphaned {
+			line += fmt.Sprintf(" (parent %s not found, created at root)", e.Record.ParentRef)
+		}
+		fmt.Fprintln(out, line)
+	}
+}
+
+func pluralBeads(n int) string {
+	if n == 1 {
+		return "bead"
+	}
+	return "beads"
+}
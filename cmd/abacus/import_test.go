@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"abacus/internal/beads"
+)
+
+func TestImportExportFile_ReimportSkipsExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.json")
+	export := `[{"title": "Crash on start", "state": "OPEN", "url": "https://github.com/acme/app/issues/7", "labels": [{"name": "bug"}]}]`
+	if err := os.WriteFile(path, []byte(export), 0o600); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+
+	var stored []beads.FullIssue
+	m := beads.NewMockClient()
+	m.ExportFn = func(context.Context) ([]beads.FullIssue, error) { return stored, nil }
+	m.CreateFullFn = func(_ context.Context, title, issueType string, priority int, labels []string, assignee, description, parentID string) (beads.FullIssue, error) {
+		return beads.FullIssue{ID: "ab-1", Title: title}, nil
+	}
+	m.UpdateExternalRefFn = func(_ context.Context, id, ref string) error {
+		stored = append(stored, beads.FullIssue{ID: id, ExternalRef: ref})
+		return nil
+	}
+
+	var out bytes.Buffer
+	if _, err := importExportFile(context.Background(), m, path, importOptions{}, &out); err != nil {
+		t.Fatalf("first import: %v", err)
+	}
+	if !strings.Contains(out.String(), "Created 1 bead, 0 already imported") {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+
+	out.Reset()
+	if _, err := importExportFile(context.Background(), m, path, importOptions{}, &out); err != nil {
+		t.Fatalf("second import: %v", err)
+	}
+	if m.CreateFullCallCount != 1 {
+		t.Errorf("expected re-import to create nothing, got %d creates", m.CreateFullCallCount)
+	}
+	if !strings.Contains(out.String(), "exists   ab-1") || !strings.Contains(out.String(), "Created 0 beads, 1 already imported") {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+}
+
+func TestImportExportFile_RejectsUnknownFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.csv")
+	if err := os.WriteFile(path, []byte("a,b\n1,2\n"), 0o600); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+	if _, err := importExportFile(context.Background(), beads.NewMockClient(), path, importOptions{format: "trello"}, &bytes.Buffer{}); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
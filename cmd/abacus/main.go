@@ -30,6 +30,20 @@ func main() {
 		theme.SetTheme(themeName)
 	}
 
+	// Subcommands take their own flags, so dispatch before the TUI flags are parsed.
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import":
+			os.Exit(runImportCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "hook":
+			os.Exit(runHookCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "serve":
+			os.Exit(runServeCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "mcp":
+			os.Exit(runMCPCommand(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+		}
+	}
+
 	autoRefreshSecondsDefault := config.GetInt(config.KeyAutoRefreshSeconds)
 	if autoRefreshSecondsDefault < 0 {
 		autoRefreshSecondsDefault = 0
@@ -44,7 +58,7 @@ func main() {
 	skipVersionCheckFlag := flag.Bool("skip-version-check", skipVersionCheckDefault, "Skip Beads CLI version validation (or set AB_SKIP_VERSION_CHECK=true)")
 	skipUpdateCheckFlag := flag.Bool("skip-update-check", skipUpdateCheckDefault, "Skip checking for updates at startup (or set AB_SKIP_UPDATE_CHECK=true)")
 	debugFlag := flag.Bool("debug", config.GetBool(config.KeyDebug), "Enable debug logging to ~/.abacus/debug.log")
-	backendFlag := flag.String("backend", "", "Force backend (bd or br) - overrides auto-detection, one-time only")
+	backendFlag := flag.String("backend", "", "Force backend (bd, br or a plugin name) - overrides auto-detection, one-time only")
 	flag.Parse()
 
 	if *versionFlag {
@@ -119,7 +133,7 @@ func main() {
 
 	// Pass the existing startup display to runWithRuntime
 	if err := runWithRuntime(runtime, ui.NewApp, func(app *ui.App) programRunner {
-		return tea.NewProgram(app, tea.WithAltScreen())
+		return tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	}, func() startupAnimator {
 		return startup
 	}); err != nil {
@@ -147,6 +161,9 @@ func runProgram(cfg ui.Config, builder func(ui.Config) (*ui.App, error), factory
 		}
 		return fmt.Errorf("initialize UI: %w", err)
 	}
+	defer func() {
+		_ = app.Shutdown()
+	}()
 	if factory == nil {
 		return fmt.Errorf("program factory is nil")
 	}
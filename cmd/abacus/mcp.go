@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"abacus/internal/api"
+	"abacus/internal/config"
+	"abacus/internal/mcp"
+)
+
+// runMCPCommand implements `abacus mcp`, serving the Model Context Protocol
+// on stdin and stdout, and returns the process exit code. stdout carries
+// protocol messages only; everything else goes to stderr.
+func runMCPCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("mcp", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	backendFlag := fs.String("backend", "", "Force backend (bd, br or a plugin name) - overrides auto-detection, one-time only")
+	skipVersionCheckFlag := fs.Bool("skip-version-check", config.GetBool(config.KeySkipVersionCheck), "Skip Beads CLI version validation")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: abacus mcp [flags]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Serves the project's beads to coding agents over the Model Context Protocol on stdio.")
+		fmt.Fprintln(stderr, "Register it with your agent as a stdio server running `abacus mcp` in the project directory.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	proj, err := openProject(*backendFlag, *skipVersionCheckFlag)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer proj.close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	server := mcp.NewServer(api.NewService(proj.client), Version)
+	if err := server.Serve(ctx, stdin, stdout); err != nil && ctx.Err() == nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"abacus/internal/api"
+	"abacus/internal/config"
+)
+
+// defaultServeAddr keeps the API on loopback unless asked otherwise.
+const defaultServeAddr = "127.0.0.1:7745"
+
+// serveShutdownTimeout bounds how long open requests may finish after an
+// interrupt; change streams are cut off when it expires.
+const serveShutdownTimeout = 5 * time.Second
+
+// runServeCommand implements `abacus serve` and returns the process exit code.
+func runServeCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addrFlag := fs.String("addr", defaultServeAddr, "Address to listen on")
+	pollFlag := fs.Duration("poll", 2*time.Second, "How often to check the database for changes")
+	backendFlag := fs.String("backend", "", "Force backend (bd, br or a plugin name) - overrides auto-detection, one-time only")
+	skipVersionCheckFlag := fs.Bool("skip-version-check", config.GetBool(config.KeySkipVersionCheck), "Skip Beads CLI version validation")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: abacus serve [flags]")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Serves the project's beads as a JSON API under /api, with change events at /api/events.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 || *pollFlag <= 0 {
+		fs.Usage()
+		return 2
+	}
+
+	host, _, err := net.SplitHostPort(*addrFlag)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: invalid --addr: %v\n", err)
+		return 2
+	}
+	proj, err := openProject(*backendFlag, *skipVersionCheckFlag)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer proj.close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher := api.NewWatcher(proj.dbPath, *pollFlag)
+	go watcher.Run(ctx)
+
+	handler := api.NewHandler(api.NewService(proj.client), watcher)
+	if isLoopbackAddr(host) {
+		handler = api.LocalOnly(handler)
+	} else {
+		fmt.Fprintf(stderr, "Warning: %s is reachable from other machines and the API has no authentication\n", *addrFlag)
+	}
+
+	listener, err := net.Listen("tcp", *addrFlag)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	fmt.Fprintf(stdout, "Serving %s (%s) on http://%s/api\n", proj.dbPath, proj.backend, listener.Addr())
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Serve(listener) }()
+	select {
+	case err := <-errCh:
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// isLoopbackAddr reports whether a listen host only accepts local
+// connections. An empty host listens on every interface.
+func isLoopbackAddr(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,312 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"abacus/internal/beads"
+	appErrors "abacus/internal/errors"
+)
+
+// maxRequestBody bounds JSON request bodies.
+const maxRequestBody = 1 << 20
+
+// eventKeepAlive is how often the change stream sends a comment so proxies
+// and clients do not time out an idle connection.
+const eventKeepAlive = 30 * time.Second
+
+// NewHandler returns the HTTP/JSON API for svc. Change notifications are
+// streamed from watcher at /api/events.
+//
+// Requests with a body must be JSON, which browsers cannot send cross-origin
+// without a preflight the API never approves, so web pages cannot write
+// through it. Wrap the handler in LocalOnly when serving on loopback.
+func NewHandler(svc *Service, watcher *Watcher) http.Handler {
+	h := &handler{svc: svc, watcher: watcher}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/issues", h.listIssues)
+	mux.HandleFunc("POST /api/issues", h.createIssue)
+	mux.HandleFunc("GET /api/issues/{id}", h.getIssue)
+	mux.HandleFunc("PATCH /api/issues/{id}", h.updateIssue)
+	mux.HandleFunc("DELETE /api/issues/{id}", h.deleteIssue)
+	mux.HandleFunc("POST /api/issues/{id}/close", h.closeIssue)
+	mux.HandleFunc("GET /api/issues/{id}/comments", h.listComments)
+	mux.HandleFunc("POST /api/issues/{id}/comments", h.addComment)
+	mux.HandleFunc("GET /api/issues/{id}/history", h.history)
+	mux.HandleFunc("GET /api/issues/{id}/blockers", h.blockers)
+	mux.HandleFunc("POST /api/issues/{id}/labels", h.addLabel)
+	mux.HandleFunc("DELETE /api/issues/{id}/labels/{label}", h.removeLabel)
+	mux.HandleFunc("POST /api/issues/{id}/dependencies", h.addDependency)
+	mux.HandleFunc("DELETE /api/issues/{id}/dependencies/{target}", h.removeDependency)
+	mux.HandleFunc("GET /api/tree", h.tree)
+	mux.HandleFunc("GET /api/ready", h.ready)
+	mux.HandleFunc("GET /api/events", h.events)
+	return mux
+}
+
+type handler struct {
+	svc     *Service
+	watcher *Watcher
+}
+
+func (h *handler) listIssues(w http.ResponseWriter, r *http.Request) {
+	issues, err := h.svc.Issues(r.Context())
+	respond(w, http.StatusOK, issues, err)
+}
+
+func (h *handler) getIssue(w http.ResponseWriter, r *http.Request) {
+	issue, err := h.svc.Issue(r.Context(), r.PathValue("id"))
+	respond(w, http.StatusOK, issue, err)
+}
+
+func (h *handler) createIssue(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	issue, err := h.svc.Create(r.Context(), req)
+	respond(w, http.StatusCreated, issue, err)
+}
+
+// updateRequest holds the fields PATCH can change; absent fields are kept.
+type updateRequest struct {
+	Status   *string `json:"status"`
+	Priority *int    `json:"priority"`
+	Assignee *string `json:"assignee"`
+}
+
+func (h *handler) updateIssue(w http.ResponseWriter, r *http.Request) {
+	var req updateRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	ctx, id := r.Context(), r.PathValue("id")
+	var err error
+	if req.Status != nil {
+		err = h.svc.SetStatus(ctx, id, *req.Status)
+	}
+	if err == nil && req.Priority != nil {
+		err = h.svc.SetPriority(ctx, id, *req.Priority)
+	}
+	if err == nil && req.Assignee != nil {
+		err = h.svc.SetAssignee(ctx, id, *req.Assignee)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	issue, err := h.svc.Issue(ctx, id)
+	respond(w, http.StatusOK, issue, err)
+}
+
+func (h *handler) deleteIssue(w http.ResponseWriter, r *http.Request) {
+	cascade, _ := strconv.ParseBool(r.URL.Query().Get("cascade"))
+	respondNoContent(w, h.svc.Delete(r.Context(), r.PathValue("id"), cascade))
+}
+
+func (h *handler) closeIssue(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if r.ContentLength != 0 && !decode(w, r, &req) {
+		return
+	}
+	respondNoContent(w, h.svc.CloseIssue(r.Context(), r.PathValue("id"), req.Reason))
+}
+
+func (h *handler) listComments(w http.ResponseWriter, r *http.Request) {
+	comments, err := h.svc.Comments(r.Context(), r.PathValue("id"))
+	respond(w, http.StatusOK, comments, err)
+}
+
+func (h *handler) addComment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Text string `json:"text"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	respondNoContent(w, h.svc.AddComment(r.Context(), r.PathValue("id"), req.Text))
+}
+
+func (h *handler) history(w http.ResponseWriter, r *http.Request) {
+	events, err := h.svc.History(r.Context(), r.PathValue("id"))
+	respond(w, http.StatusOK, events, err)
+}
+
+func (h *handler) blockers(w http.ResponseWriter, r *http.Request) {
+	blockers, err := h.svc.Blockers(r.Context(), r.PathValue("id"))
+	respond(w, http.StatusOK, blockers, err)
+}
+
+func (h *handler) addLabel(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Label string `json:"label"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	respondNoContent(w, h.svc.AddLabel(r.Context(), r.PathValue("id"), req.Label))
+}
+
+func (h *handler) removeLabel(w http.ResponseWriter, r *http.Request) {
+	respondNoContent(w, h.svc.RemoveLabel(r.Context(), r.PathValue("id"), r.PathValue("label")))
+}
+
+func (h *handler) addDependency(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TargetID string `json:"target_id"`
+		Type     string `json:"type"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	respondNoContent(w, h.svc.AddDependency(r.Context(), r.PathValue("id"), req.TargetID, req.Type))
+}
+
+func (h *handler) removeDependency(w http.ResponseWriter, r *http.Request) {
+	err := h.svc.RemoveDependency(r.Context(), r.PathValue("id"), r.PathValue("target"), r.URL.Query().Get("type"))
+	respondNoContent(w, err)
+}
+
+func (h *handler) tree(w http.ResponseWriter, r *http.Request) {
+	tree, err := h.svc.Tree(r.Context(), r.URL.Query().Get("root"))
+	respond(w, http.StatusOK, tree, err)
+}
+
+func (h *handler) ready(w http.ResponseWriter, r *http.Request) {
+	ready, err := h.svc.Ready(r.Context())
+	respond(w, http.StatusOK, ready, err)
+}
+
+// events streams a "change" server-sent event each time the database is
+// written. Clients re-fetch what they display when one arrives.
+func (h *handler) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, errors.New("streaming not supported"))
+		return
+	}
+	changes, unsubscribe := h.watcher.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, ": connected\n\n")
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			_, _ = io.WriteString(w, ": keep-alive\n\n")
+		case change := <-changes:
+			data, _ := json.Marshal(change)
+			_, _ = fmt.Fprintf(w, "event: change\ndata: %s\n\n", data)
+		}
+		flusher.Flush()
+	}
+}
+
+// decode reads a JSON request body into v, writing a 400 response and
+// returning false when it cannot.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	mediaType, _, _ := strings.Cut(r.Header.Get("Content-Type"), ";")
+	if strings.TrimSpace(mediaType) != "application/json" {
+		writeJSON(w, http.StatusUnsupportedMediaType, errorBody{Error: "request body must be application/json"})
+		return false
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{Error: "invalid request body: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+func respond(w http.ResponseWriter, status int, v any, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, v)
+}
+
+func respondNoContent(w http.ResponseWriter, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError maps service errors onto HTTP statuses: unknown beads are 404,
+// invalid input 400, disallowed status changes 409 and operations the
+// backend lacks 501.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	code := appErrors.CodeOf(err)
+	switch {
+	case errors.Is(err, beads.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, beads.ErrNotSupported):
+		status = http.StatusNotImplemented
+	case code == appErrors.CodeInvalidTransition:
+		status = http.StatusConflict
+	case code == appErrors.CodeInvalidStatus, code == appErrors.CodeInvalidPriority, code == appErrors.CodeInvalidIssueData:
+		status = http.StatusBadRequest
+	}
+	body := errorBody{Error: err.Error()}
+	if code != appErrors.CodeUnknown {
+		body.Code = string(code)
+	}
+	writeJSON(w, status, body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// LocalOnly rejects requests whose Host header is not a loopback address,
+// which keeps DNS-rebound web pages from reading a server bound to localhost.
+func LocalOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if !isLoopbackHost(host) {
+			writeJSON(w, http.StatusForbidden, errorBody{Error: "requests must address localhost"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isLoopbackHost(host string) bool {
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"abacus/internal/beads"
+)
+
+func newTestServer(t *testing.T, client beads.Client, watcher *Watcher) *httptest.Server {
+	t.Helper()
+	if watcher == nil {
+		watcher = NewWatcher(filepath.Join(t.TempDir(), "beads.db"), time.Hour)
+	}
+	srv := httptest.NewServer(LocalOnly(NewHandler(NewService(client), watcher)))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func doJSON(t *testing.T, method, url, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestHandlerReads(t *testing.T) {
+	srv := newTestServer(t, fixtureClient(), nil)
+
+	resp := doJSON(t, http.MethodGet, srv.URL+"/api/issues/ab-2", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET issue status = %d", resp.StatusCode)
+	}
+	var issue beads.FullIssue
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil || issue.Title != "First" {
+		t.Fatalf("unexpected issue %+v (%v)", issue, err)
+	}
+
+	resp = doJSON(t, http.MethodGet, srv.URL+"/api/ready", "")
+	var ready []IssueSummary
+	if err := json.NewDecoder(resp.Body).Decode(&ready); err != nil || len(ready) != 1 {
+		t.Fatalf("unexpected ready list %+v (%v)", ready, err)
+	}
+
+	resp = doJSON(t, http.MethodGet, srv.URL+"/api/issues/ab-99", "")
+	var body errorBody
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	if resp.StatusCode != http.StatusNotFound || body.Error == "" {
+		t.Fatalf("expected 404 with error body, got %d %+v", resp.StatusCode, body)
+	}
+}
+
+func TestHandlerWrites(t *testing.T) {
+	mock := fixtureClient()
+	srv := newTestServer(t, mock, nil)
+
+	resp := doJSON(t, http.MethodPatch, srv.URL+"/api/issues/ab-1", `{"priority":0,"assignee":"sam"}`)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("PATCH status = %d", resp.StatusCode)
+	}
+	if mock.UpdatePriorityCallCount != 1 || mock.UpdateAssigneeCallCount != 1 || mock.UpdateStatusCallCount != 0 {
+		t.Fatalf("unexpected calls: priority=%d assignee=%d status=%d",
+			mock.UpdatePriorityCallCount, mock.UpdateAssigneeCallCount, mock.UpdateStatusCallCount)
+	}
+
+	resp = doJSON(t, http.MethodPatch, srv.URL+"/api/issues/ab-1", `{"priority":9}`)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("invalid priority status = %d, want 400", resp.StatusCode)
+	}
+
+	resp = doJSON(t, http.MethodPost, srv.URL+"/api/issues/ab-2/close", `{"reason":"shipped"}`)
+	if resp.StatusCode != http.StatusNoContent || mock.CloseWithReasonCallCount != 1 {
+		t.Fatalf("close status = %d, calls = %d", resp.StatusCode, mock.CloseWithReasonCallCount)
+	}
+
+	resp = doJSON(t, http.MethodPost, srv.URL+"/api/issues/ab-1/dependencies", `{"target_id":"ab-2","type":"sideways"}`)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unknown dependency type status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestHandlerErrorStatuses(t *testing.T) {
+	mock := fixtureClient()
+	mock.AddCommentFn = func(context.Context, string, string) error { return beads.ErrNotSupported }
+	srv := newTestServer(t, mock, nil)
+
+	resp := doJSON(t, http.MethodPost, srv.URL+"/api/issues/ab-1/comments", `{"text":"hi"}`)
+	if resp.StatusCode != http.StatusNotImplemented {
+		t.Fatalf("unsupported write status = %d, want 501", resp.StatusCode)
+	}
+
+	// ab-4 is closed; closed beads cannot go straight to in_progress.
+	resp = doJSON(t, http.MethodPatch, srv.URL+"/api/issues/ab-4", `{"status":"in_progress"}`)
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("invalid transition status = %d, want 409", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/api/issues", strings.NewReader(`{"title":"x"}`))
+	req.Header.Set("Content-Type", "text/plain")
+	plain, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	_ = plain.Body.Close()
+	if plain.StatusCode != http.StatusUnsupportedMediaType || mock.CreateFullCallCount != 0 {
+		t.Fatalf("non-JSON body status = %d, creates = %d", plain.StatusCode, mock.CreateFullCallCount)
+	}
+}
+
+func TestLocalOnlyRejectsForeignHost(t *testing.T) {
+	srv := newTestServer(t, fixtureClient(), nil)
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/issues", nil)
+	req.Host = "attacker.example:7745"
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("foreign host status = %d, want 403", resp.StatusCode)
+	}
+}
+
+func TestEventsStreamChanges(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "beads.db")
+	if err := os.WriteFile(dbPath, []byte("v1"), 0o600); err != nil {
+		t.Fatalf("write db: %v", err)
+	}
+	old := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(dbPath, old, old); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	watcher := NewWatcher(dbPath, time.Hour)
+	srv := newTestServer(t, fixtureClient(), watcher)
+
+	resp := doJSON(t, http.MethodGet, srv.URL+"/api/events", "")
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type = %q", ct)
+	}
+	lines := bufio.NewScanner(resp.Body)
+	if !lines.Scan() || lines.Text() != ": connected" {
+		t.Fatalf("expected connected comment, got %q", lines.Text())
+	}
+
+	if err := os.Chtimes(dbPath, time.Now(), time.Now()); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	watcher.Poll()
+
+	var got []string
+	for lines.Scan() && len(got) < 3 {
+		got = append(got, lines.Text())
+	}
+	if len(got) < 3 || got[1] != "event: change" || !strings.HasPrefix(got[2], "data: {\"mod_time\":") {
+		t.Fatalf("unexpected event lines %q", got)
+	}
+}
@@ -0,0 +1,454 @@
+// Package api exposes beads and their dependency graph to other programs.
+//
+// Service holds the queries and validated mutations shared by the external
+// interfaces (`abacus serve` and `abacus mcp`); it reads through
+// beads.Reader, builds the forest with graph.Builder and writes through
+// beads.Writer, so callers never touch the backend schema directly.
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"abacus/internal/beads"
+	"abacus/internal/domain"
+	appErrors "abacus/internal/errors"
+	"abacus/internal/graph"
+)
+
+// IssueSummary is the compact form of a bead used in lists and trees.
+type IssueSummary struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	IssueType string `json:"issue_type"`
+	Priority  int    `json:"priority"`
+	Assignee  string `json:"assignee,omitempty"`
+	Blocked   bool   `json:"blocked"`
+	Ready     bool   `json:"ready"`
+}
+
+// Rollup counts the distinct descendants of a bead by state.
+type Rollup struct {
+	Total      int `json:"total"`
+	Closed     int `json:"closed"`
+	InProgress int `json:"in_progress"`
+	Blocked    int `json:"blocked"`
+	Ready      int `json:"ready"`
+}
+
+// TreeNode is a bead with its children. Rollup is set for beads that have
+// children.
+type TreeNode struct {
+	IssueSummary
+	Rollup   *Rollup    `json:"rollup,omitempty"`
+	Children []TreeNode `json:"children,omitempty"`
+}
+
+// Blockers lists what a bead waits on (open blockers only) and what waits on
+// it.
+type Blockers struct {
+	IssueID   string         `json:"issue_id"`
+	Blocked   bool           `json:"blocked"`
+	BlockedBy []IssueSummary `json:"blocked_by"`
+	Blocks    []IssueSummary `json:"blocks"`
+}
+
+// CreateRequest describes a new bead.
+type CreateRequest struct {
+	Title       string   `json:"title"`
+	IssueType   string   `json:"issue_type"`
+	Priority    *int     `json:"priority"`
+	Labels      []string `json:"labels"`
+	Assignee    string   `json:"assignee"`
+	Description string   `json:"description"`
+	ParentID    string   `json:"parent_id"`
+}
+
+// defaultPriority is used when a CreateRequest does not set one, matching
+// the create modal.
+const defaultPriority = 2
+
+// Service answers queries and applies changes against a beads client.
+type Service struct {
+	client beads.Client
+}
+
+// NewService returns a Service backed by client.
+func NewService(client beads.Client) *Service {
+	return &Service{client: client}
+}
+
+// Issues returns every bead with its labels, dependencies and comments.
+func (s *Service) Issues(ctx context.Context) ([]beads.FullIssue, error) {
+	return s.client.Export(ctx)
+}
+
+// Issue returns one bead, or an error matching beads.ErrNotFound.
+func (s *Service) Issue(ctx context.Context, id string) (beads.FullIssue, error) {
+	issues, err := s.client.Show(ctx, []string{id})
+	if err != nil {
+		return beads.FullIssue{}, err
+	}
+	for _, iss := range issues {
+		if iss.ID == id {
+			return iss, nil
+		}
+	}
+	return beads.FullIssue{}, fmt.Errorf("%s: %w", id, beads.ErrNotFound)
+}
+
+// Comments returns a bead's comments, oldest first.
+func (s *Service) Comments(ctx context.Context, id string) ([]beads.Comment, error) {
+	if _, err := s.Issue(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.client.Comments(ctx, id)
+}
+
+// History returns a bead's recorded changes, oldest first.
+func (s *Service) History(ctx context.Context, id string) ([]beads.HistoryEvent, error) {
+	if _, err := s.Issue(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.client.History(ctx, id)
+}
+
+// forest builds the dependency forest from a fresh export.
+func (s *Service) forest(ctx context.Context) ([]*graph.Node, error) {
+	issues, err := s.client.Export(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graph.NewBuilder().Build(issues)
+}
+
+// Tree returns the whole forest, or the subtree under rootID when it is set.
+func (s *Service) Tree(ctx context.Context, rootID string) ([]TreeNode, error) {
+	roots, err := s.forest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rootID != "" {
+		node := findNode(roots, rootID)
+		if node == nil {
+			return nil, fmt.Errorf("%s: %w", rootID, beads.ErrNotFound)
+		}
+		roots = []*graph.Node{node}
+	}
+	tree := make([]TreeNode, 0, len(roots))
+	for _, root := range roots {
+		tree = append(tree, treeNode(root))
+	}
+	return tree, nil
+}
+
+// Ready returns the open, unblocked beads, most urgent first.
+func (s *Service) Ready(ctx context.Context) ([]IssueSummary, error) {
+	roots, err := s.forest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ready []*graph.Node
+	walkUnique(roots, func(n *graph.Node) {
+		if isReady(n) {
+			ready = append(ready, n)
+		}
+	})
+	sort.SliceStable(ready, func(i, j int) bool {
+		if ready[i].Issue.Priority != ready[j].Issue.Priority {
+			return ready[i].Issue.Priority < ready[j].Issue.Priority
+		}
+		return ready[i].Issue.CreatedAt < ready[j].Issue.CreatedAt
+	})
+	return summaries(ready), nil
+}
+
+// Blockers returns the beads blocking id and the beads it blocks.
+func (s *Service) Blockers(ctx context.Context, id string) (Blockers, error) {
+	roots, err := s.forest(ctx)
+	if err != nil {
+		return Blockers{}, err
+	}
+	node := findNode(roots, id)
+	if node == nil {
+		return Blockers{}, fmt.Errorf("%s: %w", id, beads.ErrNotFound)
+	}
+	var open []*graph.Node
+	for _, b := range node.BlockedBy {
+		if b.Issue.Status != string(domain.StatusClosed) {
+			open = append(open, b)
+		}
+	}
+	return Blockers{
+		IssueID:   id,
+		Blocked:   node.IsBlocked,
+		BlockedBy: summaries(open),
+		Blocks:    summaries(node.Blocks),
+	}, nil
+}
+
+// SetStatus moves a bead to status after checking the transition is allowed.
+// Reopening a closed bead goes through Reopen so the backend clears its
+// close metadata.
+func (s *Service) SetStatus(ctx context.Context, id, status string) error {
+	target, err := domain.ParseStatus(status)
+	if err != nil {
+		return err
+	}
+	current, err := s.checkTransition(ctx, id, target)
+	if err != nil || current == target {
+		return err
+	}
+	if current == domain.StatusClosed && target == domain.StatusOpen {
+		return s.client.Reopen(ctx, id)
+	}
+	return s.client.UpdateStatus(ctx, id, string(target))
+}
+
+// CloseIssue closes a bead, recording reason when one is given.
+func (s *Service) CloseIssue(ctx context.Context, id, reason string) error {
+	if _, err := s.checkTransition(ctx, id, domain.StatusClosed); err != nil {
+		return err
+	}
+	if strings.TrimSpace(reason) == "" {
+		return s.client.Close(ctx, id)
+	}
+	return s.client.CloseWithReason(ctx, id, reason)
+}
+
+// checkTransition returns the bead's current status after checking it may
+// move to target. Statuses abacus does not know (from newer backends) may
+// move to any known status.
+func (s *Service) checkTransition(ctx context.Context, id string, target domain.Status) (domain.Status, error) {
+	iss, err := s.Issue(ctx, id)
+	if err != nil {
+		return domain.StatusUnknown, err
+	}
+	current := domain.Status(iss.Status)
+	if !current.IsKnown() {
+		return current, target.Validate()
+	}
+	return current, current.CanTransitionTo(target)
+}
+
+// SetPriority changes a bead's priority (0-4).
+func (s *Service) SetPriority(ctx context.Context, id string, priority int) error {
+	if err := domain.Priority(priority).Validate(); err != nil {
+		return err
+	}
+	if _, err := s.Issue(ctx, id); err != nil {
+		return err
+	}
+	return s.client.UpdatePriority(ctx, id, priority)
+}
+
+// SetAssignee changes a bead's assignee; "" unassigns it.
+func (s *Service) SetAssignee(ctx context.Context, id, assignee string) error {
+	if _, err := s.Issue(ctx, id); err != nil {
+		return err
+	}
+	return s.client.UpdateAssignee(ctx, id, strings.TrimSpace(assignee))
+}
+
+// Create adds a bead, under req.ParentID when it is set.
+func (s *Service) Create(ctx context.Context, req CreateRequest) (beads.FullIssue, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return beads.FullIssue{}, invalidRequest("title is required")
+	}
+	issueType := req.IssueType
+	if issueType == "" {
+		issueType = "task"
+	}
+	priority := defaultPriority
+	if req.Priority != nil {
+		priority = *req.Priority
+	}
+	if err := domain.Priority(priority).Validate(); err != nil {
+		return beads.FullIssue{}, err
+	}
+	if req.ParentID != "" {
+		if _, err := s.Issue(ctx, req.ParentID); err != nil {
+			return beads.FullIssue{}, err
+		}
+	}
+	return s.client.CreateFull(ctx, title, issueType, priority, req.Labels, req.Assignee, req.Description, req.ParentID)
+}
+
+// AddLabel adds a label to a bead.
+func (s *Service) AddLabel(ctx context.Context, id, label string) error {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return invalidRequest("label is required")
+	}
+	return s.client.AddLabel(ctx, id, label)
+}
+
+// RemoveLabel removes a label from a bead.
+func (s *Service) RemoveLabel(ctx context.Context, id, label string) error {
+	return s.client.RemoveLabel(ctx, id, label)
+}
+
+// AddComment adds a comment to a bead.
+func (s *Service) AddComment(ctx context.Context, id, text string) error {
+	if strings.TrimSpace(text) == "" {
+		return invalidRequest("comment text is required")
+	}
+	if _, err := s.Issue(ctx, id); err != nil {
+		return err
+	}
+	return s.client.AddComment(ctx, id, text)
+}
+
+// dependencyTypes are the dependency types beads can be linked with.
+var dependencyTypes = []string{"blocks", "parent-child", "related", "discovered-from"}
+
+// AddDependency records that fromID depends on toID. depType defaults to
+// "blocks".
+func (s *Service) AddDependency(ctx context.Context, fromID, toID, depType string) error {
+	if depType == "" {
+		depType = "blocks"
+	}
+	if !containsString(dependencyTypes, depType) {
+		return invalidRequest(fmt.Sprintf("unknown dependency type %q (want one of %s)", depType, strings.Join(dependencyTypes, ", ")))
+	}
+	if fromID == toID {
+		return invalidRequest("a bead cannot depend on itself")
+	}
+	found, err := s.client.Show(ctx, []string{fromID, toID})
+	if err != nil {
+		return err
+	}
+	for _, id := range []string{fromID, toID} {
+		if !containsIssue(found, id) {
+			return fmt.Errorf("%s: %w", id, beads.ErrNotFound)
+		}
+	}
+	return s.client.AddDependency(ctx, fromID, toID, depType)
+}
+
+// RemoveDependency removes a dependency of fromID on toID.
+func (s *Service) RemoveDependency(ctx context.Context, fromID, toID, depType string) error {
+	if depType == "" {
+		depType = "blocks"
+	}
+	return s.client.RemoveDependency(ctx, fromID, toID, depType)
+}
+
+// Delete removes a bead, and its descendants when cascade is set.
+func (s *Service) Delete(ctx context.Context, id string, cascade bool) error {
+	if _, err := s.Issue(ctx, id); err != nil {
+		return err
+	}
+	return s.client.Delete(ctx, id, cascade)
+}
+
+func invalidRequest(msg string) error {
+	return appErrors.New(appErrors.CodeInvalidIssueData, msg, nil)
+}
+
+func containsString(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+func containsIssue(issues []beads.FullIssue, id string) bool {
+	for _, iss := range issues {
+		if iss.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func isReady(n *graph.Node) bool {
+	iss, err := domain.NewIssueFromFull(n.Issue, n.IsBlocked)
+	return err == nil && iss.IsReady()
+}
+
+func summary(n *graph.Node) IssueSummary {
+	return IssueSummary{
+		ID:        n.Issue.ID,
+		Title:     n.Issue.Title,
+		Status:    n.Issue.Status,
+		IssueType: n.Issue.IssueType,
+		Priority:  n.Issue.Priority,
+		Assignee:  n.Issue.Assignee,
+		Blocked:   n.IsBlocked,
+		Ready:     isReady(n),
+	}
+}
+
+func summaries(nodes []*graph.Node) []IssueSummary {
+	out := make([]IssueSummary, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, summary(n))
+	}
+	return out
+}
+
+func treeNode(n *graph.Node) TreeNode {
+	tn := TreeNode{IssueSummary: summary(n)}
+	if len(n.Children) == 0 {
+		return tn
+	}
+	tn.Children = make([]TreeNode, 0, len(n.Children))
+	for _, child := range n.Children {
+		tn.Children = append(tn.Children, treeNode(child))
+	}
+	tn.Rollup = rollup(n)
+	return tn
+}
+
+// rollup counts n's descendants, each once even when it has several parents.
+func rollup(n *graph.Node) *Rollup {
+	r := &Rollup{}
+	walkUnique(n.Children, func(d *graph.Node) {
+		r.Total++
+		switch {
+		case d.Issue.Status == string(domain.StatusClosed):
+			r.Closed++
+		case d.Issue.Status == string(domain.StatusInProgress):
+			r.InProgress++
+		case d.IsBlocked || d.Issue.Status == string(domain.StatusBlocked):
+			r.Blocked++
+		case isReady(d):
+			r.Ready++
+		}
+	})
+	return r
+}
+
+// walkUnique visits every node reachable through Children once.
+func walkUnique(nodes []*graph.Node, visit func(*graph.Node)) {
+	seen := make(map[string]bool)
+	var walk func([]*graph.Node)
+	walk = func(nodes []*graph.Node) {
+		for _, n := range nodes {
+			if seen[n.Issue.ID] {
+				continue
+			}
+			seen[n.Issue.ID] = true
+			visit(n)
+			walk(n.Children)
+		}
+	}
+	walk(nodes)
+}
+
+func findNode(roots []*graph.Node, id string) *graph.Node {
+	var found *graph.Node
+	walkUnique(roots, func(n *graph.Node) {
+		if found == nil && n.Issue.ID == id {
+			found = n
+		}
+	})
+	return found
+}
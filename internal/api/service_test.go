@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"abacus/internal/beads"
+	appErrors "abacus/internal/errors"
+)
+
+// fixtureClient serves an epic with two tasks, one blocking the other, plus
+// an unrelated closed bead.
+func fixtureClient() *beads.MockClient {
+	issues := []beads.FullIssue{
+		{ID: "ab-1", Title: "Epic", Status: "open", IssueType: "epic", Priority: 1, CreatedAt: "2025-01-01T00:00:00Z"},
+		{ID: "ab-2", Title: "First", Status: "in_progress", IssueType: "task", Priority: 2, CreatedAt: "2025-01-02T00:00:00Z",
+			Dependencies: []beads.Dependency{{TargetID: "ab-1", Type: "parent-child"}}},
+		{ID: "ab-3", Title: "Second", Status: "open", IssueType: "task", Priority: 0, CreatedAt: "2025-01-03T00:00:00Z",
+			Dependencies: []beads.Dependency{{TargetID: "ab-1", Type: "parent-child"}, {TargetID: "ab-2", Type: "blocks"}}},
+		{ID: "ab-4", Title: "Done", Status: "closed", IssueType: "task", Priority: 3, CreatedAt: "2025-01-04T00:00:00Z"},
+	}
+	mock := beads.NewMockClient()
+	mock.ExportFn = func(context.Context) ([]beads.FullIssue, error) { return issues, nil }
+	mock.ShowFn = func(_ context.Context, ids []string) ([]beads.FullIssue, error) {
+		var out []beads.FullIssue
+		for _, iss := range issues {
+			for _, id := range ids {
+				if iss.ID == id {
+					out = append(out, iss)
+				}
+			}
+		}
+		return out, nil
+	}
+	return mock
+}
+
+func TestServiceTreeRollup(t *testing.T) {
+	svc := NewService(fixtureClient())
+	tree, err := svc.Tree(context.Background(), "ab-1")
+	if err != nil {
+		t.Fatalf("Tree: %v", err)
+	}
+	if len(tree) != 1 || len(tree[0].Children) != 2 {
+		t.Fatalf("expected epic with two children, got %+v", tree)
+	}
+	got := *tree[0].Rollup
+	want := Rollup{Total: 2, InProgress: 1, Blocked: 1}
+	if got != want {
+		t.Fatalf("rollup = %+v, want %+v", got, want)
+	}
+
+	if _, err := svc.Tree(context.Background(), "ab-99"); !errors.Is(err, beads.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown root, got %v", err)
+	}
+}
+
+func TestServiceReadyAndBlockers(t *testing.T) {
+	svc := NewService(fixtureClient())
+	ready, err := svc.Ready(context.Background())
+	if err != nil {
+		t.Fatalf("Ready: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != "ab-1" {
+		t.Fatalf("expected only the epic to be ready, got %+v", ready)
+	}
+
+	blockers, err := svc.Blockers(context.Background(), "ab-3")
+	if err != nil {
+		t.Fatalf("Blockers: %v", err)
+	}
+	if !blockers.Blocked || len(blockers.BlockedBy) != 1 || blockers.BlockedBy[0].ID != "ab-2" {
+		t.Fatalf("unexpected blockers: %+v", blockers)
+	}
+}
+
+func TestServiceSetStatus(t *testing.T) {
+	mock := fixtureClient()
+	svc := NewService(mock)
+	ctx := context.Background()
+
+	if err := svc.SetStatus(ctx, "ab-4", "open"); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if mock.ReopenCallCount != 1 || mock.UpdateStatusCallCount != 0 {
+		t.Fatalf("expected closed->open to reopen, got reopen=%d update=%d", mock.ReopenCallCount, mock.UpdateStatusCallCount)
+	}
+
+	if err := svc.SetStatus(ctx, "ab-1", "bogus"); !appErrors.IsCode(err, appErrors.CodeInvalidStatus) {
+		t.Fatalf("expected invalid status error, got %v", err)
+	}
+	if err := svc.SetStatus(ctx, "ab-99", "closed"); !errors.Is(err, beads.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestServiceCreateDefaults(t *testing.T) {
+	mock := fixtureClient()
+	var gotType string
+	var gotPriority int
+	var gotParent string
+	mock.CreateFullFn = func(_ context.Context, title, issueType string, priority int, _ []string, _, _, parentID string) (beads.FullIssue, error) {
+		gotType, gotPriority, gotParent = issueType, priority, parentID
+		return beads.FullIssue{ID: "ab-5", Title: title}, nil
+	}
+	svc := NewService(mock)
+
+	if _, err := svc.Create(context.Background(), CreateRequest{Title: "  "}); !appErrors.IsCode(err, appErrors.CodeInvalidIssueData) {
+		t.Fatalf("expected blank title to be rejected, got %v", err)
+	}
+	issue, err := svc.Create(context.Background(), CreateRequest{Title: "New", ParentID: "ab-1"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if issue.ID != "ab-5" || gotType != "task" || gotPriority != 2 || gotParent != "ab-1" {
+		t.Fatalf("unexpected create: id=%s type=%s priority=%d parent=%s", issue.ID, gotType, gotPriority, gotParent)
+	}
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"abacus/internal/beads"
+)
+
+// Change reports that the database was written.
+type Change struct {
+	ModTime time.Time `json:"mod_time"`
+}
+
+// Watcher polls the database modification time, as the TUI's auto-refresh
+// does, and notifies subscribers when it advances.
+type Watcher struct {
+	dbPath   string
+	interval time.Duration
+
+	mu   sync.Mutex
+	last time.Time
+	subs map[chan Change]struct{}
+}
+
+// NewWatcher returns a Watcher for dbPath that polls every interval.
+func NewWatcher(dbPath string, interval time.Duration) *Watcher {
+	w := &Watcher{dbPath: dbPath, interval: interval, subs: make(map[chan Change]struct{})}
+	if modTime, err := beads.LatestModTime(dbPath); err == nil {
+		w.last = modTime
+	}
+	return w
+}
+
+// Run polls until ctx is done. Errors (for example while the database is
+// being replaced) are retried on the next poll.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Poll()
+		}
+	}
+}
+
+// Poll checks the database once and notifies subscribers if it changed.
+func (w *Watcher) Poll() {
+	modTime, err := beads.LatestModTime(w.dbPath)
+	if err != nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !modTime.After(w.last) {
+		return
+	}
+	w.last = modTime
+	for ch := range w.subs {
+		// Subscribers only need to know something changed, so a pending
+		// notification is as good as a new one.
+		select {
+		case ch <- Change{ModTime: modTime}:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel of changes and a function that unsubscribes.
+func (w *Watcher) Subscribe() (<-chan Change, func()) {
+	ch := make(chan Change, 1)
+	w.mu.Lock()
+	w.subs[ch] = struct{}{}
+	w.mu.Unlock()
+	return ch, func() {
+		w.mu.Lock()
+		delete(w.subs, ch)
+		w.mu.Unlock()
+	}
+}
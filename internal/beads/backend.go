@@ -9,6 +9,7 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/huh"
@@ -66,6 +67,12 @@ var (
 
 	// promptSwitchBackendFunc is used for interactive switch confirmation.
 	promptSwitchBackendFunc = promptSwitchBackend
+
+	// pluginExistsFunc is used to check if a backend plugin is on PATH.
+	pluginExistsFunc = pluginExists
+
+	// findPluginBackendsFunc is used to list the backend plugins on PATH.
+	findPluginBackendsFunc = findPluginBackends
 )
 
 // DetectBackendOptions configures DetectBackend behavior.
@@ -80,8 +87,8 @@ type DetectBackendOptions struct {
 	SkipVersionCheck bool
 }
 
-// DetectBackend determines which backend (bd or br) to use.
-// Returns the backend name ("bd" or "br") or an error if detection fails.
+// DetectBackend determines which backend (bd, br or a plugin) to use.
+// Returns the backend name or an error if detection fails.
 //
 // Version checks create their own timeouts internally - user prompts do not
 // consume version check time, so users can take as long as needed to respond.
@@ -89,12 +96,17 @@ type DetectBackendOptions struct {
 // Priority order (regardless of SkipVersionCheck):
 //  1. CLI flag (--backend)
 //  2. Stored preference (.abacus/config.yaml beads.backend)
-//  3. Auto-detection (which backend exists on PATH)
+//  3. Auto-detection (which backend exists on PATH; a plugin only when
+//     neither bd nor br does and exactly one plugin is installed)
 func DetectBackend(opts DetectBackendOptions) (string, error) {
 	// 0. CLI flag override (highest priority, one-time, no save)
 	if opts.CLIFlag != "" {
 		if opts.CLIFlag != BackendBd && opts.CLIFlag != BackendBr {
-			return "", fmt.Errorf("invalid --backend value: %q (must be 'bd' or 'br')", opts.CLIFlag)
+			// Any other name selects a plugin; plugins check their own versions.
+			if pluginExistsFunc(opts.CLIFlag) {
+				return opts.CLIFlag, nil
+			}
+			return "", fmt.Errorf("invalid --backend value: %q (must be 'bd', 'br' or a plugin installed on PATH as %s<name>)", opts.CLIFlag, PluginPrefix)
 		}
 		if !commandExistsFunc(opts.CLIFlag) {
 			return "", fmt.Errorf("--backend %s specified but %s not found in PATH", opts.CLIFlag, opts.CLIFlag)
@@ -113,7 +125,10 @@ func DetectBackend(opts DetectBackendOptions) (string, error) {
 	if storedPref != "" {
 		// Validate stored preference is a known backend (matches CLI flag validation)
 		if storedPref != BackendBd && storedPref != BackendBr {
-			return "", fmt.Errorf("invalid beads.backend value in config: %q (must be %q or %q)", storedPref, BackendBd, BackendBr)
+			if pluginExistsFunc(storedPref) {
+				return storedPref, nil
+			}
+			return "", fmt.Errorf("invalid beads.backend value in config: %q (must be %q, %q or a plugin installed on PATH as %s<name>)", storedPref, BackendBd, BackendBr, PluginPrefix)
 		}
 		// Verify the stored preference is still valid (binary exists)
 		if commandExistsFunc(storedPref) {
@@ -159,7 +174,19 @@ func DetectBackend(opts DetectBackendOptions) (string, error) {
 	var userPrompted bool
 	switch {
 	case !brExists && !bdExists:
-		return "", ErrNoBackendAvailable
+		// Fall back to a plugin when exactly one is installed.
+		plugins := findPluginBackendsFunc()
+		switch len(plugins) {
+		case 0:
+			return "", ErrNoBackendAvailable
+		case 1:
+			if err := configSaveBackendFunc(plugins[0]); err != nil {
+				log.Printf("warning: could not save backend preference: %v", err)
+			}
+			return plugins[0], nil
+		default:
+			return "", fmt.Errorf("neither bd nor br found in PATH and several backend plugins are installed (%s); use --backend or set beads.backend in .abacus/config.yaml", strings.Join(plugins, ", "))
+		}
 	case brExists && !bdExists:
 		choice = BackendBr
 	case bdExists && !brExists:
@@ -359,19 +386,27 @@ func isInteractiveTTY() bool {
 }
 
 // NewClientForBackend creates the appropriate Client based on backend string.
-// backend must be "bd" or "br". dbPath is the path to the SQLite database.
+// backend must be "bd", "br" or the name of a backend plugin on PATH.
+// dbPath is the path to the SQLite database.
 // Returns an error for unknown backends or empty dbPath.
+// For br, the configured identity name is used as the comment author, and
+// beads.directWrites enables the direct SQLite write path.
 func NewClientForBackend(backend, dbPath string) (Client, error) {
 	if dbPath == "" {
 		return nil, fmt.Errorf("dbPath is required")
 	}
-	switch backend {
-	case BackendBd:
+	switch {
+	case backend == BackendBd:
 		return NewBdSQLiteClient(dbPath), nil
-	case BackendBr:
-		return NewBrSQLiteClient(dbPath), nil
+	case backend == BackendBr:
+		return NewBrSQLiteClient(dbPath,
+			WithBrAuthor(config.GetString(config.KeyIdentityName)),
+			WithBrDirectWrites(config.GetBool(config.KeyBeadsDirectWrites)),
+		), nil
+	case pluginExistsFunc(backend):
+		return NewPluginClient(backend, dbPath), nil
 	default:
-		return nil, fmt.Errorf("unknown backend: %q (must be %q or %q)", backend, BackendBd, BackendBr)
+		return nil, fmt.Errorf("unknown backend: %q (must be %q, %q or a plugin installed on PATH as %s<name>)", backend, BackendBd, BackendBr, PluginPrefix)
 	}
 }
 
@@ -2,6 +2,7 @@ package beads
 
 import (
 	"errors"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -83,6 +84,12 @@ func saveAndRestoreHooks(t *testing.T) func() {
 	origConfigSaveBackend := configSaveBackendFunc
 	origPromptUserForBackend := promptUserForBackendFunc
 	origPromptSwitchBackend := promptSwitchBackendFunc
+	origPluginExists := pluginExistsFunc
+	origFindPluginBackends := findPluginBackendsFunc
+
+	// Keep tests independent of any plugins installed on this machine.
+	pluginExistsFunc = func(string) bool { return false }
+	findPluginBackendsFunc = func() []string { return nil }
 
 	return func() {
 		commandExistsFunc = origCommandExists
@@ -92,6 +99,8 @@ func saveAndRestoreHooks(t *testing.T) func() {
 		configSaveBackendFunc = origConfigSaveBackend
 		promptUserForBackendFunc = origPromptUserForBackend
 		promptSwitchBackendFunc = origPromptSwitchBackend
+		pluginExistsFunc = origPluginExists
+		findPluginBackendsFunc = origFindPluginBackends
 	}
 }
 
@@ -1283,3 +1292,114 @@ func TestDetectBackend_SkipVersionCheck_StillChecksBinaryExists(t *testing.T) {
 		t.Errorf("error message should mention PATH, got: %v", err)
 	}
 }
+
+// =============================================================================
+// Backend Plugin Tests
+// =============================================================================
+
+// TestDetectBackend_CLIFlagPlugin tests selecting an installed plugin with --backend.
+func TestDetectBackend_CLIFlagPlugin(t *testing.T) {
+	restore := saveAndRestoreHooks(t)
+	defer restore()
+
+	pluginExistsFunc = func(name string) bool { return name == "jira" }
+	checkBackendVersionFunc = func(backend string) error {
+		t.Errorf("plugins should not be version checked, got check for %q", backend)
+		return nil
+	}
+
+	got, err := DetectBackend(DetectBackendOptions{CLIFlag: "jira"})
+	if err != nil {
+		t.Fatalf("DetectBackend() error = %v, want nil", err)
+	}
+	if got != "jira" {
+		t.Errorf("DetectBackend() = %q, want %q", got, "jira")
+	}
+}
+
+// TestDetectBackend_StoredPreferencePlugin tests a plugin saved in project config.
+func TestDetectBackend_StoredPreferencePlugin(t *testing.T) {
+	restore := saveAndRestoreHooks(t)
+	defer restore()
+
+	pluginExistsFunc = func(name string) bool { return name == "fork" }
+	configGetProjectStringFunc = func(key string) string {
+		if key == config.KeyBeadsBackend {
+			return "fork"
+		}
+		return ""
+	}
+
+	got, err := DetectBackend(DetectBackendOptions{})
+	if err != nil {
+		t.Fatalf("DetectBackend() error = %v, want nil", err)
+	}
+	if got != "fork" {
+		t.Errorf("DetectBackend() = %q, want %q", got, "fork")
+	}
+}
+
+// TestDetectBackend_SinglePluginFallback tests auto-detecting the only
+// installed plugin when neither bd nor br is on PATH.
+func TestDetectBackend_SinglePluginFallback(t *testing.T) {
+	restore := saveAndRestoreHooks(t)
+	defer restore()
+
+	commandExistsFunc = func(_ string) bool { return false }
+	configGetProjectStringFunc = func(_ string) string { return "" }
+	findPluginBackendsFunc = func() []string { return []string{"fork"} }
+	var saved string
+	configSaveBackendFunc = func(backend string) error {
+		saved = backend
+		return nil
+	}
+
+	got, err := DetectBackend(DetectBackendOptions{})
+	if err != nil {
+		t.Fatalf("DetectBackend() error = %v, want nil", err)
+	}
+	if got != "fork" || saved != "fork" {
+		t.Errorf("DetectBackend() = %q (saved %q), want %q", got, saved, "fork")
+	}
+}
+
+// TestDetectBackend_SeveralPlugins tests that several plugins need an explicit choice.
+func TestDetectBackend_SeveralPlugins(t *testing.T) {
+	restore := saveAndRestoreHooks(t)
+	defer restore()
+
+	commandExistsFunc = func(_ string) bool { return false }
+	configGetProjectStringFunc = func(_ string) string { return "" }
+	findPluginBackendsFunc = func() []string { return []string{"fork", "jira"} }
+
+	_, err := DetectBackend(DetectBackendOptions{})
+	if err == nil || !strings.Contains(err.Error(), "fork, jira") {
+		t.Fatalf("DetectBackend() error = %v, want error listing the plugins", err)
+	}
+}
+
+// TestNewClientForBackend_Plugin tests creating a client for an installed plugin.
+func TestNewClientForBackend_Plugin(t *testing.T) {
+	restore := saveAndRestoreHooks(t)
+	defer restore()
+
+	pluginExistsFunc = func(name string) bool { return name == "fork" }
+
+	client, err := NewClientForBackend("fork", "/tmp/test.db")
+	if err != nil {
+		t.Fatalf("NewClientForBackend(fork) error = %v", err)
+	}
+	if _, ok := client.(*pluginClient); !ok {
+		t.Errorf("NewClientForBackend(fork) returned %T, want *pluginClient", client)
+	}
+}
+
+func TestCanEditComments(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), ".beads", "beads.db")
+	if CanEditComments(NewBrSQLiteClient(dbPath)) || CanEditComments(NewBdSQLiteClient(dbPath)) {
+		t.Error("expected bd and br to report that comments cannot be edited")
+	}
+	if !CanEditComments(NewMockClient()) {
+		t.Error("expected clients without the method to be assumed capable")
+	}
+}
@@ -0,0 +1,240 @@
+package beads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Batch collects Writer operations that should succeed or fail together.
+//
+// Backends have no cross-command transactions, so Apply runs the operations
+// in order and, when one fails, undoes the ones already applied in reverse
+// order. Each operation records its own inverse when it is added, which is
+// why the builder methods take the values being replaced. Apply only uses
+// Writer methods, so a Batch works with every client, including the CLI
+// clients and MockClient.
+type Batch struct {
+	ops []batchOp
+}
+
+// batchOp is one step of a Batch. do returns the issue ID it touched, which
+// for creates is only known once the issue exists.
+type batchOp struct {
+	desc string
+	do   func(ctx context.Context, w Writer) (string, error)
+	undo func(ctx context.Context, w Writer, issueID string) error
+}
+
+// OpResult reports what happened to one operation of a Batch.
+type OpResult struct {
+	Desc    string // e.g. "add label ui to ab-1"
+	IssueID string // the issue touched; for creates, the new issue's ID
+	Applied bool   // the operation succeeded
+	Err     error  // why the operation failed, nil if it was not attempted
+	Undone  bool   // the operation was applied and then reversed
+	UndoErr error  // why reversing it failed
+}
+
+// BatchResult is the outcome of Batch.Apply, one OpResult per operation in
+// the order they were added.
+type BatchResult struct {
+	Ops []OpResult
+	Err error // nil when every operation was applied
+}
+
+// Failed returns the first operation that failed, if any.
+func (r BatchResult) Failed() (OpResult, bool) {
+	for _, op := range r.Ops {
+		if op.Err != nil {
+			return op, true
+		}
+	}
+	return OpResult{}, false
+}
+
+// NewBatch returns an empty Batch.
+func NewBatch() *Batch {
+	return &Batch{}
+}
+
+// Len returns the number of operations in the batch.
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+func (b *Batch) add(desc string, do func(context.Context, Writer) (string, error), undo func(context.Context, Writer, string) error) *Batch {
+	b.ops = append(b.ops, batchOp{desc: desc, do: do, undo: undo})
+	return b
+}
+
+// SetStatus changes an issue's status from from to to.
+func (b *Batch) SetStatus(issueID, from, to string) *Batch {
+	return b.add(fmt.Sprintf("set status of %s to %s", issueID, to),
+		func(ctx context.Context, w Writer) (string, error) {
+			return issueID, w.UpdateStatus(ctx, issueID, to)
+		},
+		func(ctx context.Context, w Writer, _ string) error {
+			return w.UpdateStatus(ctx, issueID, from)
+		})
+}
+
+// SetPriority changes an issue's priority from from to to.
+func (b *Batch) SetPriority(issueID string, from, to int) *Batch {
+	return b.add(fmt.Sprintf("set priority of %s to P%d", issueID, to),
+		func(ctx context.Context, w Writer) (string, error) {
+			return issueID, w.UpdatePriority(ctx, issueID, to)
+		},
+		func(ctx context.Context, w Writer, _ string) error {
+			return w.UpdatePriority(ctx, issueID, from)
+		})
+}
+
+// SetAssignee changes an issue's assignee from from to to. Empty means unassigned.
+func (b *Batch) SetAssignee(issueID, from, to string) *Batch {
+	return b.add(fmt.Sprintf("assign %s to %q", issueID, to),
+		func(ctx context.Context, w Writer) (string, error) {
+			return issueID, w.UpdateAssignee(ctx, issueID, to)
+		},
+		func(ctx context.Context, w Writer, _ string) error {
+			return w.UpdateAssignee(ctx, issueID, from)
+		})
+}
+
+// AddLabel adds a label the issue does not carry yet.
+func (b *Batch) AddLabel(issueID, label string) *Batch {
+	return b.add(fmt.Sprintf("add label %s to %s", label, issueID),
+		func(ctx context.Context, w Writer) (string, error) {
+			return issueID, w.AddLabel(ctx, issueID, label)
+		},
+		func(ctx context.Context, w Writer, _ string) error {
+			return w.RemoveLabel(ctx, issueID, label)
+		})
+}
+
+// RemoveLabel removes a label the issue carries.
+func (b *Batch) RemoveLabel(issueID, label string) *Batch {
+	return b.add(fmt.Sprintf("remove label %s from %s", label, issueID),
+		func(ctx context.Context, w Writer) (string, error) {
+			return issueID, w.RemoveLabel(ctx, issueID, label)
+		},
+		func(ctx context.Context, w Writer, _ string) error {
+			return w.AddLabel(ctx, issueID, label)
+		})
+}
+
+// AddDependency adds a dependency that does not exist yet.
+func (b *Batch) AddDependency(fromID, toID, depType string) *Batch {
+	return b.add(fmt.Sprintf("add %s dependency %s → %s", depType, fromID, toID),
+		func(ctx context.Context, w Writer) (string, error) {
+			return fromID, w.AddDependency(ctx, fromID, toID, depType)
+		},
+		func(ctx context.Context, w Writer, _ string) error {
+			return w.RemoveDependency(ctx, fromID, toID, depType)
+		})
+}
+
+// RemoveDependency removes an existing dependency.
+func (b *Batch) RemoveDependency(fromID, toID, depType string) *Batch {
+	return b.add(fmt.Sprintf("remove %s dependency %s → %s", depType, fromID, toID),
+		func(ctx context.Context, w Writer) (string, error) {
+			return fromID, w.RemoveDependency(ctx, fromID, toID, depType)
+		},
+		func(ctx context.Context, w Writer, _ string) error {
+			return w.AddDependency(ctx, fromID, toID, depType)
+		})
+}
+
+// SetParent moves an issue from parent from to parent to. Either may be
+// empty for a top-level issue; nothing is added when they are equal. The old
+// link is removed first because backends may allow only one parent.
+func (b *Batch) SetParent(issueID, from, to string) *Batch {
+	if from == to {
+		return b
+	}
+	if from != "" {
+		b.RemoveDependency(issueID, from, "parent-child")
+	}
+	if to != "" {
+		b.AddDependency(issueID, to, "parent-child")
+	}
+	return b
+}
+
+// UpdateFull rewrites an issue's editable fields. before is the issue as
+// currently stored; its values are restored if a later operation fails.
+func (b *Batch) UpdateFull(before FullIssue, title, issueType string, priority int, labels []string, assignee, description string) *Batch {
+	issueID := before.ID
+	labels = slices.Clone(labels)
+	prevLabels := slices.Clone(before.Labels)
+	return b.add(fmt.Sprintf("update %s", issueID),
+		func(ctx context.Context, w Writer) (string, error) {
+			return issueID, w.UpdateFull(ctx, issueID, title, issueType, priority, labels, assignee, description)
+		},
+		func(ctx context.Context, w Writer, _ string) error {
+			return w.UpdateFull(ctx, issueID, before.Title, before.IssueType, before.Priority, prevLabels, before.Assignee, before.Description)
+		})
+}
+
+// UpdateSchedule changes an issue's due date, defer date and estimate.
+// before is the issue as currently stored.
+func (b *Batch) UpdateSchedule(before FullIssue, due, deferUntil string, estimateMinutes int) *Batch {
+	issueID := before.ID
+	return b.add(fmt.Sprintf("update schedule of %s", issueID),
+		func(ctx context.Context, w Writer) (string, error) {
+			return issueID, w.UpdateSchedule(ctx, issueID, due, deferUntil, estimateMinutes)
+		},
+		func(ctx context.Context, w Writer, _ string) error {
+			return w.UpdateSchedule(ctx, issueID, before.DueAt, before.DeferUntil, before.EstimatedMinutes)
+		})
+}
+
+// Create creates an issue, optionally under parentID. Its ID is reported in
+// the operation's OpResult; the issue is deleted if a later operation fails.
+func (b *Batch) Create(title, issueType string, priority int, labels []string, assignee, description, parentID string) *Batch {
+	labels = slices.Clone(labels)
+	return b.add(fmt.Sprintf("create %q", title),
+		func(ctx context.Context, w Writer) (string, error) {
+			issue, err := w.CreateFull(ctx, title, issueType, priority, labels, assignee, description, parentID)
+			return issue.ID, err
+		},
+		func(ctx context.Context, w Writer, issueID string) error {
+			return w.Delete(ctx, issueID, false)
+		})
+}
+
+// Apply runs the operations in order against w. If one fails, the operations
+// applied before it are undone in reverse order and Err describes the
+// failure together with any undo that failed. Undo runs even when ctx has
+// been canceled, so cancellation cannot leave a batch half-applied.
+func (b *Batch) Apply(ctx context.Context, w Writer) BatchResult {
+	res := BatchResult{Ops: make([]OpResult, len(b.ops))}
+	for i, op := range b.ops {
+		res.Ops[i].Desc = op.desc
+	}
+
+	for i, op := range b.ops {
+		issueID, err := op.do(ctx, w)
+		res.Ops[i].IssueID = issueID
+		if err == nil {
+			res.Ops[i].Applied = true
+			continue
+		}
+
+		res.Ops[i].Err = err
+		errs := []error{fmt.Errorf("%s: %w", op.desc, err)}
+		undoCtx := context.WithoutCancel(ctx)
+		for j := i - 1; j >= 0; j-- {
+			if undoErr := b.ops[j].undo(undoCtx, w, res.Ops[j].IssueID); undoErr != nil {
+				res.Ops[j].UndoErr = undoErr
+				errs = append(errs, fmt.Errorf("undo %s: %w", b.ops[j].desc, undoErr))
+				continue
+			}
+			res.Ops[j].Undone = true
+		}
+		res.Err = errors.Join(errs...)
+		return res
+	}
+	return res
+}
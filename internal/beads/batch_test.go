@@ -0,0 +1,155 @@
+package beads
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBatch_AppliesInOrder(t *testing.T) {
+	t.Parallel()
+	mock := NewMockClient()
+	mock.CreateFullFn = func(_ context.Context, title, _ string, _ int, _ []string, _, _, parentID string) (FullIssue, error) {
+		return FullIssue{ID: "ab-new", Title: title}, nil
+	}
+
+	res := NewBatch().
+		Create("Child", "task", 2, nil, "", "", "ab-1").
+		SetStatus("ab-1", "open", "in_progress").
+		AddLabel("ab-1", "ui").
+		SetParent("ab-2", "ab-1", "ab-1").
+		Apply(context.Background(), mock)
+	if res.Err != nil {
+		t.Fatalf("Apply: %v", res.Err)
+	}
+	if len(res.Ops) != 3 {
+		t.Fatalf("expected 3 results (unchanged parent adds nothing), got %+v", res.Ops)
+	}
+	if res.Ops[0].IssueID != "ab-new" || !res.Ops[0].Applied {
+		t.Errorf("expected created ID in result, got %+v", res.Ops[0])
+	}
+	if _, failed := res.Failed(); failed {
+		t.Error("Failed reported a failure for a clean batch")
+	}
+	if mock.UpdateStatusCallCount != 1 || mock.AddLabelCallCount != 1 || mock.DeleteCallCount != 0 {
+		t.Errorf("unexpected calls: status=%d label=%d delete=%d", mock.UpdateStatusCallCount, mock.AddLabelCallCount, mock.DeleteCallCount)
+	}
+}
+
+func TestBatch_UndoesAppliedOpsOnFailure(t *testing.T) {
+	t.Parallel()
+	mock := NewMockClient()
+	mock.CreateFullFn = func(context.Context, string, string, int, []string, string, string, string) (FullIssue, error) {
+		return FullIssue{ID: "ab-new"}, nil
+	}
+	boom := errors.New("boom")
+	mock.AddDependencyFn = func(_ context.Context, _, toID, _ string) error {
+		if toID == "ab-b" {
+			return boom
+		}
+		return nil
+	}
+
+	before := FullIssue{ID: "ab-1", Title: "Old", IssueType: "bug", Priority: 3, Labels: []string{"x"}}
+	res := NewBatch().
+		Create("Child", "task", 2, nil, "", "", "").
+		UpdateFull(before, "New", "task", 1, []string{"y"}, "bob", "desc").
+		SetPriority("ab-2", 2, 0).
+		SetParent("ab-1", "ab-a", "ab-b").
+		Apply(context.Background(), mock)
+
+	if !errors.Is(res.Err, boom) {
+		t.Fatalf("expected boom, got %v", res.Err)
+	}
+	failed, ok := res.Failed()
+	if !ok || failed.Desc != "add parent-child dependency ab-1 → ab-b" {
+		t.Fatalf("unexpected failed op %+v", failed)
+	}
+	for i := 0; i < 4; i++ {
+		if !res.Ops[i].Undone || res.Ops[i].UndoErr != nil {
+			t.Errorf("op %d (%s) not undone: %+v", i, res.Ops[i].Desc, res.Ops[i])
+		}
+	}
+	if res.Ops[4].Applied || res.Ops[4].Undone {
+		t.Errorf("failed op should be neither applied nor undone: %+v", res.Ops[4])
+	}
+
+	// Undo runs newest first: re-link the old parent, restore priority, restore fields, delete the new issue.
+	if got := mock.AddDependencyCallArgs; len(got) != 2 || got[1][1] != "ab-a" {
+		t.Errorf("old parent not restored: %v", got)
+	}
+	if got := mock.UpdatePriorityCallArgs; len(got) != 2 || got[1].Priority != 2 {
+		t.Errorf("priority not restored: %+v", got)
+	}
+	if got := mock.UpdateFullCallArgs; len(got) != 2 || got[1].Title != "Old" || got[1].IssueType != "bug" || got[1].Labels[0] != "x" {
+		t.Errorf("fields not restored: %+v", got)
+	}
+	if mock.DeleteCallCount != 1 || mock.DeleteCallArgs[0].IssueID != "ab-new" {
+		t.Errorf("created issue not deleted: %+v", mock.DeleteCallArgs)
+	}
+}
+
+func TestBatch_ReportsFailedUndo(t *testing.T) {
+	t.Parallel()
+	mock := NewMockClient()
+	mock.RemoveLabelFn = func(context.Context, string, string) error {
+		return errors.New("remove failed")
+	}
+	mock.UpdateStatusFn = func(context.Context, string, string) error {
+		return errors.New("status failed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mock.AddLabelFn = func(context.Context, string, string) error {
+		cancel() // undo must still run after cancellation
+		return nil
+	}
+	res := NewBatch().AddLabel("ab-1", "ui").SetStatus("ab-1", "open", "closed").Apply(ctx, mock)
+
+	if res.Ops[0].Undone || res.Ops[0].UndoErr == nil {
+		t.Fatalf("expected undo failure to be recorded, got %+v", res.Ops[0])
+	}
+	if mock.RemoveLabelCallCount != 1 {
+		t.Errorf("expected undo to be attempted once, got %d", mock.RemoveLabelCallCount)
+	}
+	for _, want := range []string{"status failed", "undo add label ui to ab-1: remove failed"} {
+		if !strings.Contains(res.Err.Error(), want) {
+			t.Errorf("error %q missing %q", res.Err, want)
+		}
+	}
+}
+
+func TestBatch_CLIClient(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "args.log")
+	script := filepath.Join(dir, "fakebr.sh")
+	// dep add to ab-missing fails; everything else succeeds.
+	writeTestScript(t, script, "#!/bin/sh\n"+
+		"echo \"$@\" >> "+logFile+"\n"+
+		"case \"$*\" in *ab-missing*) echo 'issue not found' >&2; exit 1;; esac\n"+
+		"exit 0\n")
+	client := NewBrCLIClient(WithBrBinaryPath(script))
+
+	res := NewBatch().SetParent("ab-1", "ab-old", "ab-missing").Apply(context.Background(), client)
+	if res.Err == nil {
+		t.Fatal("expected the batch to fail")
+	}
+	if !res.Ops[0].Undone {
+		t.Fatalf("expected removal of the old parent to be undone: %+v", res.Ops)
+	}
+
+	output := cliCalls(t, logFile)
+	for _, want := range []string{
+		"dep remove ab-1 ab-old",
+		"dep add ab-1 ab-missing --type parent-child",
+		"dep add ab-1 ab-old --type parent-child",
+	} {
+		if !brContainsLine(output, want) {
+			t.Errorf("expected br call %q in:\n%s", want, output)
+		}
+	}
+}
@@ -81,6 +81,28 @@ func (c *bdCLIClient) UpdatePriority(ctx context.Context, issueID string, priori
 	return nil
 }
 
+// UpdateAssignee sets the assignee; an empty assignee clears it.
+func (c *bdCLIClient) UpdateAssignee(ctx context.Context, issueID, assignee string) error {
+	if strings.TrimSpace(issueID) == "" {
+		return fmt.Errorf("issue id is required for assignee update")
+	}
+	_, err := c.run(ctx, "update", issueID, "--assignee", strings.TrimSpace(assignee))
+	if err != nil {
+		return fmt.Errorf("run bd update: %w", err)
+	}
+	return nil
+}
+
+// UpdateSchedule is not supported: bd v0.38 has no due/defer/estimate flags.
+func (c *bdCLIClient) UpdateSchedule(ctx context.Context, issueID, due, deferUntil string, estimateMinutes int) error {
+	return fmt.Errorf("due dates, defer dates and estimates require the br backend: %w", ErrNotSupported)
+}
+
+// UpdateExternalRef is not supported: bd v0.38 has no external-ref flag.
+func (c *bdCLIClient) UpdateExternalRef(ctx context.Context, issueID, ref string) error {
+	return fmt.Errorf("bd cannot set external refs: %w", ErrNotSupported)
+}
+
 func (c *bdCLIClient) Close(ctx context.Context, issueID string) error {
 	if strings.TrimSpace(issueID) == "" {
 		return fmt.Errorf("issue id is required for close")
@@ -92,6 +114,12 @@ func (c *bdCLIClient) Close(ctx context.Context, issueID string) error {
 	return nil
 }
 
+// CloseWithReason is not supported by the bd backend; callers can fall back
+// to Close plus a comment.
+func (c *bdCLIClient) CloseWithReason(ctx context.Context, issueID, reason string) error {
+	return fmt.Errorf("bd cannot record close reasons: %w", ErrNotSupported)
+}
+
 func (c *bdCLIClient) Reopen(ctx context.Context, issueID string) error {
 	if strings.TrimSpace(issueID) == "" {
 		return fmt.Errorf("issue id is required for reopen")
@@ -354,6 +382,16 @@ func (c *bdCLIClient) AddComment(ctx context.Context, issueID, text string) erro
 	return nil
 }
 
+// UpdateComment is not supported: bd v0.38 comments are append-only.
+func (c *bdCLIClient) UpdateComment(ctx context.Context, issueID string, commentID int, text string) error {
+	return fmt.Errorf("bd cannot edit comments: %w", ErrNotSupported)
+}
+
+// DeleteComment is not supported: bd v0.38 comments are append-only.
+func (c *bdCLIClient) DeleteComment(ctx context.Context, issueID string, commentID int) error {
+	return fmt.Errorf("bd cannot delete comments: %w", ErrNotSupported)
+}
+
 func (c *bdCLIClient) run(ctx context.Context, args ...string) ([]byte, error) {
 	finalArgs := make([]string, 0, len(c.dbArgs)+len(args))
 	finalArgs = append(finalArgs, c.dbArgs...)
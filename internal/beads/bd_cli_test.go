@@ -498,6 +498,48 @@ func TestBdCLIClient_UpdatePriority(t *testing.T) {
 	}
 }
 
+func TestBdCLIClient_UpdateAssignee(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "args.log")
+	script := filepath.Join(dir, "fakebd.sh")
+
+	scriptBody := "#!/bin/sh\n" +
+		"echo \"$@\" >> " + logFile + "\n" +
+		"exit 0\n"
+	writeTestScript(t, script, scriptBody)
+
+	client := NewBdCLIClient(WithBdBinaryPath(script))
+
+	ctx := context.Background()
+	if err := client.UpdateAssignee(ctx, "ab-own", "alice"); err != nil {
+		t.Fatalf("UpdateAssignee: %v", err)
+	}
+	if err := client.UpdateAssignee(ctx, "ab-own", ""); err != nil {
+		t.Fatalf("UpdateAssignee clear: %v", err)
+	}
+	if err := client.UpdateAssignee(ctx, " ", "alice"); err == nil {
+		t.Fatal("expected error for empty issue id")
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("read args log: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 invocations, got %d: %q", len(lines), data)
+	}
+	if !strings.Contains(lines[0], "update ab-own --assignee alice") {
+		t.Errorf("expected update with assignee flag, got: %q", lines[0])
+	}
+	if !strings.HasSuffix(strings.TrimSpace(lines[1]), "update ab-own --assignee") {
+		t.Errorf("expected clearing update with empty assignee, got: %q", lines[1])
+	}
+}
+
 func TestBdCLIClient_UpdateFull_ClearAssignee(t *testing.T) {
 	t.Parallel()
 
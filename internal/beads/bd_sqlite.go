@@ -307,6 +307,12 @@ func (c *bdSQLiteClient) Comments(ctx context.Context, issueID string) ([]Commen
 	return comments, rows.Err()
 }
 
+// History is not supported for bd; the UI hides the section.
+// FROZEN: bead history is a br feature.
+func (c *bdSQLiteClient) History(context.Context, string) ([]HistoryEvent, error) {
+	return nil, fmt.Errorf("bd history: %w", ErrNotSupported)
+}
+
 // Mutating operations delegate to the Writer (CLI commands).
 func (c *bdSQLiteClient) UpdateStatus(ctx context.Context, issueID, newStatus string) error {
 	return c.writer.UpdateStatus(ctx, issueID, newStatus)
@@ -316,10 +322,26 @@ func (c *bdSQLiteClient) UpdatePriority(ctx context.Context, issueID string, pri
 	return c.writer.UpdatePriority(ctx, issueID, priority)
 }
 
+func (c *bdSQLiteClient) UpdateAssignee(ctx context.Context, issueID, assignee string) error {
+	return c.writer.UpdateAssignee(ctx, issueID, assignee)
+}
+
+func (c *bdSQLiteClient) UpdateSchedule(ctx context.Context, issueID, due, deferUntil string, estimateMinutes int) error {
+	return c.writer.UpdateSchedule(ctx, issueID, due, deferUntil, estimateMinutes)
+}
+
+func (c *bdSQLiteClient) UpdateExternalRef(ctx context.Context, issueID, ref string) error {
+	return c.writer.UpdateExternalRef(ctx, issueID, ref)
+}
+
 func (c *bdSQLiteClient) Close(ctx context.Context, issueID string) error {
 	return c.writer.Close(ctx, issueID)
 }
 
+func (c *bdSQLiteClient) CloseWithReason(ctx context.Context, issueID, reason string) error {
+	return c.writer.CloseWithReason(ctx, issueID, reason)
+}
+
 func (c *bdSQLiteClient) Reopen(ctx context.Context, issueID string) error {
 	return c.writer.Reopen(ctx, issueID)
 }
@@ -359,3 +381,16 @@ func (c *bdSQLiteClient) Delete(ctx context.Context, issueID string, cascade boo
 func (c *bdSQLiteClient) AddComment(ctx context.Context, issueID, text string) error {
 	return c.writer.AddComment(ctx, issueID, text)
 }
+
+// CanEditComments reports false: bd cannot edit or delete comments.
+func (c *bdSQLiteClient) CanEditComments() bool {
+	return false
+}
+
+func (c *bdSQLiteClient) UpdateComment(ctx context.Context, issueID string, commentID int, text string) error {
+	return c.writer.UpdateComment(ctx, issueID, commentID, text)
+}
+
+func (c *bdSQLiteClient) DeleteComment(ctx context.Context, issueID string, commentID int) error {
+	return c.writer.DeleteComment(ctx, issueID, commentID)
+}
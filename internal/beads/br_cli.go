@@ -22,6 +22,9 @@ type brCLIClient struct {
 	bin     string
 	dbArgs  []string
 	workDir string // working directory for br commands (br finds workspace by walking up from cwd)
+	author  string // comment author; empty lets br infer it
+
+	directWrites bool // NewBrSQLiteClient writes to the database itself
 }
 
 // BrCLIOption configures the br CLI client implementation.
@@ -56,6 +59,24 @@ func WithBrWorkDir(dir string) BrCLIOption {
 	}
 }
 
+// WithBrAuthor sets the author recorded on comments added through the br CLI.
+// An empty name leaves authorship to br's own inference.
+func WithBrAuthor(name string) BrCLIOption {
+	return func(c *brCLIClient) {
+		c.author = strings.TrimSpace(name)
+	}
+}
+
+// WithBrDirectWrites lets NewBrSQLiteClient apply mutations to the br
+// database in SQLite transactions instead of running br for each one. Writes
+// still go through br when the database schema is not one abacus recognizes.
+// NewBrCLIClient ignores it.
+func WithBrDirectWrites(enabled bool) BrCLIOption {
+	return func(c *brCLIClient) {
+		c.directWrites = enabled
+	}
+}
+
 // NewBrCLIClient constructs a beads_rust CLI-backed Writer implementation.
 // Use NewBrSQLiteClient for full Client functionality (reads via SQLite, writes via CLI).
 func NewBrCLIClient(opts ...BrCLIOption) Writer {
@@ -91,6 +112,52 @@ func (c *brCLIClient) UpdatePriority(ctx context.Context, issueID string, priori
 	return nil
 }
 
+// UpdateAssignee sets the assignee; an empty assignee clears it.
+func (c *brCLIClient) UpdateAssignee(ctx context.Context, issueID, assignee string) error {
+	if strings.TrimSpace(issueID) == "" {
+		return fmt.Errorf("issue id is required for assignee update")
+	}
+	_, err := c.run(ctx, "update", issueID, "--assignee", strings.TrimSpace(assignee))
+	if err != nil {
+		return fmt.Errorf("run br update: %w", err)
+	}
+	return nil
+}
+
+// UpdateSchedule sets the due date, defer date and estimate (in minutes).
+// Empty dates and a zero estimate clear the corresponding field.
+func (c *brCLIClient) UpdateSchedule(ctx context.Context, issueID, due, deferUntil string, estimateMinutes int) error {
+	if strings.TrimSpace(issueID) == "" {
+		return fmt.Errorf("issue id is required for schedule update")
+	}
+	if estimateMinutes < 0 {
+		return fmt.Errorf("estimate must not be negative")
+	}
+	args := []string{
+		"update",
+		issueID,
+		"--due", strings.TrimSpace(due),
+		"--defer", strings.TrimSpace(deferUntil),
+		"--estimate", fmt.Sprintf("%d", estimateMinutes),
+	}
+	if _, err := c.run(ctx, args...); err != nil {
+		return fmt.Errorf("run br update: %w", err)
+	}
+	return nil
+}
+
+// UpdateExternalRef links an issue to its record in an outside tracker,
+// usually the original issue URL. An empty ref clears the link.
+func (c *brCLIClient) UpdateExternalRef(ctx context.Context, issueID, ref string) error {
+	if strings.TrimSpace(issueID) == "" {
+		return fmt.Errorf("issue id is required for external ref update")
+	}
+	if _, err := c.run(ctx, "update", issueID, "--external-ref", strings.TrimSpace(ref)); err != nil {
+		return fmt.Errorf("run br update: %w", err)
+	}
+	return nil
+}
+
 func (c *brCLIClient) Close(ctx context.Context, issueID string) error {
 	if strings.TrimSpace(issueID) == "" {
 		return fmt.Errorf("issue id is required for close")
@@ -102,6 +169,17 @@ func (c *brCLIClient) Close(ctx context.Context, issueID string) error {
 	return nil
 }
 
+// CloseWithReason closes an issue and records why, e.g. the commit that fixed it.
+func (c *brCLIClient) CloseWithReason(ctx context.Context, issueID, reason string) error {
+	if strings.TrimSpace(issueID) == "" {
+		return fmt.Errorf("issue id is required for close")
+	}
+	if _, err := c.run(ctx, "close", issueID, "--reason", reason); err != nil {
+		return fmt.Errorf("run br close: %w", err)
+	}
+	return nil
+}
+
 func (c *brCLIClient) Reopen(ctx context.Context, issueID string) error {
 	if strings.TrimSpace(issueID) == "" {
 		return fmt.Errorf("issue id is required for reopen")
@@ -345,13 +423,27 @@ func (c *brCLIClient) AddComment(ctx context.Context, issueID, text string) erro
 	if strings.TrimSpace(text) == "" {
 		return fmt.Errorf("comment text is required")
 	}
-	_, err := c.run(ctx, "comments", "add", issueID, text)
+	args := []string{"comments", "add", issueID, text}
+	if c.author != "" {
+		args = append(args, "--author", c.author)
+	}
+	_, err := c.run(ctx, args...)
 	if err != nil {
 		return fmt.Errorf("run br comments add: %w", err)
 	}
 	return nil
 }
 
+// UpdateComment is not supported: the br CLI only appends comments.
+func (c *brCLIClient) UpdateComment(ctx context.Context, issueID string, commentID int, text string) error {
+	return fmt.Errorf("br cannot edit comments: %w", ErrNotSupported)
+}
+
+// DeleteComment is not supported: the br CLI only appends comments.
+func (c *brCLIClient) DeleteComment(ctx context.Context, issueID string, commentID int) error {
+	return fmt.Errorf("br cannot delete comments: %w", ErrNotSupported)
+}
+
 func (c *brCLIClient) run(ctx context.Context, args ...string) ([]byte, error) {
 	finalArgs := make([]string, 0, len(c.dbArgs)+len(args))
 	finalArgs = append(finalArgs, c.dbArgs...)
@@ -363,6 +455,10 @@ func (c *brCLIClient) run(ctx context.Context, args ...string) ([]byte, error) {
 	}
 	out, err := cmd.CombinedOutput()
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// The process was killed by the context; keep why for classification.
+			err = fmt.Errorf("%w: %w", err, ctxErr)
+		}
 		return nil, formatBrCommandError(c.bin, finalArgs, err, out)
 	}
 	return out, nil
@@ -2,11 +2,16 @@ package beads
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	appErrors "abacus/internal/errors"
 )
 
 func TestBrCLIClient_AppliesDatabasePath(t *testing.T) {
@@ -375,6 +380,48 @@ func TestBrCLIClient_UpdatePriority(t *testing.T) {
 	}
 }
 
+func TestBrCLIClient_UpdateAssignee(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "args.log")
+	script := filepath.Join(dir, "fakebr.sh")
+
+	scriptBody := "#!/bin/sh\n" +
+		"echo \"$@\" >> " + logFile + "\n" +
+		"exit 0\n"
+	writeTestScript(t, script, scriptBody)
+
+	client := NewBrCLIClient(WithBrBinaryPath(script))
+
+	ctx := context.Background()
+	if err := client.UpdateAssignee(ctx, "ab-own", "alice"); err != nil {
+		t.Fatalf("UpdateAssignee: %v", err)
+	}
+	if err := client.UpdateAssignee(ctx, "ab-own", ""); err != nil {
+		t.Fatalf("UpdateAssignee clear: %v", err)
+	}
+	if err := client.UpdateAssignee(ctx, " ", "alice"); err == nil {
+		t.Fatal("expected error for empty issue id")
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("read args log: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 invocations, got %d: %q", len(lines), data)
+	}
+	if !strings.Contains(lines[0], "update ab-own --assignee alice") {
+		t.Errorf("expected update with assignee flag, got: %q", lines[0])
+	}
+	if !strings.HasSuffix(strings.TrimSpace(lines[1]), "update ab-own --assignee") {
+		t.Errorf("expected clearing update with empty assignee, got: %q", lines[1])
+	}
+}
+
 func TestBrCLIClient_AddLabel(t *testing.T) {
 	t.Parallel()
 
@@ -619,6 +666,174 @@ func TestBrCLIClient_AddComment(t *testing.T) {
 	}
 }
 
+func TestBrCLIClient_AddCommentWithAuthor(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "args.log")
+	script := filepath.Join(dir, "fakebr.sh")
+
+	scriptBody := "#!/bin/sh\n" +
+		"echo \"$@\" >> " + logFile + "\n" +
+		"exit 0\n"
+	writeTestScript(t, script, scriptBody)
+
+	client := NewBrCLIClient(WithBrBinaryPath(script), WithBrAuthor(" alice "))
+
+	ctx := context.Background()
+	if err := client.AddComment(ctx, "ab-comment", "hello"); err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("read args log: %v", err)
+	}
+
+	args := strings.TrimSpace(string(data))
+	if !strings.HasSuffix(args, "comments add ab-comment hello --author alice") {
+		t.Errorf("expected author flag after comment text, got: %q", args)
+	}
+}
+
+func TestBrCLIClient_UpdateSchedule(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "args.log")
+	script := filepath.Join(dir, "fakebr.sh")
+
+	scriptBody := "#!/bin/sh\n" +
+		"echo \"$@\" >> " + logFile + "\n" +
+		"exit 0\n"
+	writeTestScript(t, script, scriptBody)
+
+	client := NewBrCLIClient(WithBrBinaryPath(script))
+
+	ctx := context.Background()
+	if err := client.UpdateSchedule(ctx, "ab-sched", "2025-02-01", "", 120); err != nil {
+		t.Fatalf("UpdateSchedule: %v", err)
+	}
+	if err := client.UpdateSchedule(ctx, "", "2025-02-01", "", 0); err == nil {
+		t.Fatal("expected error for empty issue id")
+	}
+	if err := client.UpdateSchedule(ctx, "ab-sched", "", "", -5); err == nil {
+		t.Fatal("expected error for negative estimate")
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("read args log: %v", err)
+	}
+
+	args := strings.TrimSpace(string(data))
+	if !strings.Contains(args, "update ab-sched --due 2025-02-01 --defer  --estimate 120") {
+		t.Errorf("expected schedule flags, got: %q", args)
+	}
+}
+
+func TestBrCLIClient_UpdateExternalRef(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "args.log")
+	script := filepath.Join(dir, "fakebr.sh")
+
+	scriptBody := "#!/bin/sh\n" +
+		"echo \"$@\" >> " + logFile + "\n" +
+		"exit 0\n"
+	writeTestScript(t, script, scriptBody)
+
+	client := NewBrCLIClient(WithBrBinaryPath(script))
+
+	ctx := context.Background()
+	if err := client.UpdateExternalRef(ctx, "ab-ext", " https://github.com/acme/app/issues/7 "); err != nil {
+		t.Fatalf("UpdateExternalRef: %v", err)
+	}
+	if err := client.UpdateExternalRef(ctx, "", "gh-7"); err == nil {
+		t.Fatal("expected error for empty issue id")
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("read args log: %v", err)
+	}
+
+	args := strings.TrimSpace(string(data))
+	if args != "update ab-ext --external-ref https://github.com/acme/app/issues/7" {
+		t.Errorf("expected external-ref flag, got: %q", args)
+	}
+}
+
+func TestCLIClients_CommentEditDeleteUnsupported(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clients := map[string]Writer{
+		"bd": NewBdCLIClient(WithBdBinaryPath("/nonexistent/bd")),
+		"br": NewBrCLIClient(WithBrBinaryPath("/nonexistent/br")),
+	}
+	for name, client := range clients {
+		if err := client.UpdateComment(ctx, "ab-1", 7, "edited"); !errors.Is(err, ErrNotSupported) {
+			t.Errorf("%s UpdateComment: expected ErrNotSupported, got %v", name, err)
+		}
+		if err := client.DeleteComment(ctx, "ab-1", 7); !errors.Is(err, ErrNotSupported) {
+			t.Errorf("%s DeleteComment: expected ErrNotSupported, got %v", name, err)
+		}
+	}
+}
+
+func TestBrCLIClient_CloseWithReason(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "args.log")
+	script := filepath.Join(dir, "fakebr.sh")
+
+	scriptBody := "#!/bin/sh\n" +
+		"echo \"$@\" >> " + logFile + "\n" +
+		"exit 0\n"
+	writeTestScript(t, script, scriptBody)
+
+	client := NewBrCLIClient(WithBrBinaryPath(script))
+
+	ctx := context.Background()
+	if err := client.CloseWithReason(ctx, "ab-fix", "Closed by commit 1a2b3c4"); err != nil {
+		t.Fatalf("CloseWithReason: %v", err)
+	}
+	if err := client.CloseWithReason(ctx, "", "reason"); err == nil {
+		t.Fatal("expected error for empty issue id")
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("read args log: %v", err)
+	}
+
+	args := strings.TrimSpace(string(data))
+	if args != "close ab-fix --reason Closed by commit 1a2b3c4" {
+		t.Errorf("expected reason flag, got: %q", args)
+	}
+}
+
+func TestBdCLIClient_UpdateExternalRefUnsupported(t *testing.T) {
+	t.Parallel()
+
+	client := NewBdCLIClient(WithBdBinaryPath("/nonexistent/bd"))
+	if err := client.UpdateExternalRef(context.Background(), "ab-1", "gh-7"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+}
+
+func TestBdCLIClient_UpdateScheduleUnsupported(t *testing.T) {
+	t.Parallel()
+
+	client := NewBdCLIClient(WithBdBinaryPath("/nonexistent/bd"))
+	if err := client.UpdateSchedule(context.Background(), "ab-1", "2025-03-01", "", 0); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+}
+
 func TestBrCLIClient_UpdateFull(t *testing.T) {
 	t.Parallel()
 
@@ -784,6 +999,78 @@ func TestBrCLIClient_ValidationErrors(t *testing.T) {
 	}
 }
 
+func TestBrCLIClient_ClassifiesRetryableFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		output    string
+		code      appErrors.Code
+		retryable bool
+	}{
+		{"locked", "Error: database is locked (SQLITE_BUSY)", appErrors.CodeBusy, true},
+		{"lock contention", "error: lock contention on .beads/beads.db, try again", appErrors.CodeBusy, true},
+		{"timed out", "Error: operation timed out waiting for daemon", appErrors.CodeTimeout, true},
+		{"validation", "Error: invalid status \"bogus\"", appErrors.CodeCLIFailed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			script := filepath.Join(t.TempDir(), "fakebr.sh")
+			writeTestScript(t, script, "#!/bin/sh\necho \""+strings.ReplaceAll(tt.output, `"`, `\"`)+"\" >&2\nexit 1\n")
+
+			err := NewBrCLIClient(WithBrBinaryPath(script)).UpdateStatus(context.Background(), "ab-1", "closed")
+			if got := appErrors.CodeOf(err); got != tt.code {
+				t.Errorf("code = %q, want %q (err: %v)", got, tt.code, err)
+			}
+			if got := IsRetryable(err); got != tt.retryable {
+				t.Errorf("IsRetryable = %v, want %v", got, tt.retryable)
+			}
+		})
+	}
+}
+
+func TestBrCLIClient_ClassifiesDeadlineAsTimeout(t *testing.T) {
+	t.Parallel()
+
+	script := filepath.Join(t.TempDir(), "fakebr.sh")
+	writeTestScript(t, script, "#!/bin/sh\nexec sleep 5\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := NewBrCLIClient(WithBrBinaryPath(script)).UpdatePriority(ctx, "ab-1", 1)
+	if !IsTimeout(err) || !IsRetryable(err) {
+		t.Fatalf("expected a retryable timeout, got %v (code %q)", err, appErrors.CodeOf(err))
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"not found", classifyCLIError("br", []string{"show"}, ErrNotFound, ""), false},
+		{"binary missing", classifyCLIError("br", []string{"list"}, exec.ErrNotFound, ""), true},
+		{"busy output", classifyCLIError("br", []string{"update"}, errors.New("exit status 1"), "database is locked"), true},
+		{"busy text in arguments only", classifyCLIError("br", []string{"comments", "add", "database is locked"}, errors.New("exit status 1"), "Error: no such issue"), false},
+		{"unclassified sqlite busy", fmt.Errorf("update status: %w", errors.New("database is locked (5) (SQLITE_BUSY)")), true},
+		{"unclassified deadline", fmt.Errorf("update status: %w", context.DeadlineExceeded), true},
+		{"unclassified other", errors.New("constraint failed"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
 // =============================================================================
 // Integration Tests - require real br binary
 // =============================================================================
@@ -10,15 +10,23 @@ import (
 	"database/sql"
 	"fmt"
 	"net/url"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	_ "modernc.org/sqlite" // Pure Go SQLite driver, WAL-friendly
 )
 
 // brSQLiteClient reads issues/comments directly from the br SQLite database in
-// read-only WAL mode. Mutating operations delegate to brCLIClient.
+// read-only WAL mode. Mutating operations delegate to brCLIClient, or to
+// brSQLiteWriter when direct writes are enabled.
+//
+// Reads share a pool of read-only connections opened on first use, with the
+// queries prepared once; Shutdown closes it. Export reads everything in one
+// transaction, so issues, labels, dependencies and comments come from the
+// same snapshot even while br is writing.
 //
 // Schema compatibility: br schema has 43 columns (superset of bd). All columns
 // abacus reads exist in both schemas. Extra columns in br are ignored.
@@ -26,6 +34,50 @@ type brSQLiteClient struct {
 	dbPath string
 	dsn    string
 	writer Writer // brCLIClient for write operations
+
+	mu    sync.Mutex
+	db    *sql.DB      // read pool, nil until first use or after Shutdown
+	file  os.FileInfo  // the database file db was opened on
+	stmts *brReadStmts // current statements, nil until first use
+}
+
+// brReadPoolSize bounds the read connections kept per client. WAL readers do
+// not block each other or br's writer, so comment loading can run in parallel.
+const brReadPoolSize = 4
+
+// brReadStmts are the read queries prepared on a pool. The issues query
+// depends on which optional columns exist, so the set is prepared for one
+// schema version and replaced when br migrates the database.
+//
+// Readers hold a reference while they use the statements. A replaced set is
+// retired and only closed once its last reader releases it, so a migration
+// or a rebuilt database file never closes statements mid-query.
+type brReadStmts struct {
+	db      *sql.DB
+	version int64
+
+	list          *sql.Stmt
+	labels        *sql.Stmt
+	dependencies  *sql.Stmt
+	comments      *sql.Stmt
+	issueComments *sql.Stmt
+	issues        *sql.Stmt
+
+	// Guarded by brSQLiteClient.mu.
+	refs    int
+	retired bool
+	closeDB bool // db was replaced too and is closed with the statements
+}
+
+func (s *brReadStmts) close() {
+	for _, stmt := range []*sql.Stmt{s.list, s.labels, s.dependencies, s.comments, s.issueComments, s.issues} {
+		if stmt != nil {
+			_ = stmt.Close()
+		}
+	}
+	if s.closeDB {
+		_ = s.db.Close()
+	}
 }
 
 // NewBrSQLiteClient constructs a client that reads via SQLite and writes via br CLI.
@@ -45,10 +97,15 @@ func NewBrSQLiteClient(dbPath string, opts ...BrCLIOption) Client {
 	if workDir := deriveWorkDirFromDBPath(trimmed); workDir != "" {
 		opts = append(opts, WithBrWorkDir(workDir))
 	}
+	cli := NewBrCLIClient(opts...)
+	writer := cli
+	if c, ok := cli.(*brCLIClient); ok && c.directWrites {
+		writer = newBrSQLiteWriter(trimmed, c)
+	}
 	return &brSQLiteClient{
 		dbPath: trimmed,
 		dsn:    dsn,
-		writer: NewBrCLIClient(opts...),
+		writer: writer,
 	}
 }
 
@@ -82,14 +139,31 @@ func deriveWorkDirFromDBPath(dbPath string) string {
 // normalises to //server/share/...) are prefixed with an extra "//" to produce
 // the four-slash form (file:////server/share/...) required by the SQLite URI spec.
 func buildSQLiteDSN(dbPath string) string {
-	slashed := filepath.ToSlash(dbPath)
-	escapedPath := (&url.URL{Path: slashed}).EscapedPath()
 	q := url.Values{}
 	q.Set("mode", "ro")
 	q.Set("_journal_mode", "WAL")
 	q.Set("_busy_timeout", "3000")
 	q.Set("_foreign_keys", "on")
 	q.Set("cache", "shared")
+	return sqliteFileURI(dbPath, q)
+}
+
+// buildSQLiteWriteDSN returns a read-write SQLite URI for the given path whose
+// transactions take the write lock up front, so concurrent br processes wait
+// for each other instead of failing mid-transaction.
+func buildSQLiteWriteDSN(dbPath string) string {
+	q := url.Values{}
+	q.Set("mode", "rw")
+	q.Set("_txlock", "immediate")
+	q.Add("_pragma", "busy_timeout(5000)")
+	q.Add("_pragma", "foreign_keys(1)")
+	return sqliteFileURI(dbPath, q)
+}
+
+// sqliteFileURI builds the "file:" URI for dbPath with the given query.
+func sqliteFileURI(dbPath string, q url.Values) string {
+	slashed := filepath.ToSlash(dbPath)
+	escapedPath := (&url.URL{Path: slashed}).EscapedPath()
 	if strings.HasPrefix(escapedPath, "//") {
 		// UNC path: prepend "//" so the total prefix is "file:////" as required.
 		return "file://" + escapedPath + "?" + q.Encode()
@@ -97,13 +171,81 @@ func buildSQLiteDSN(dbPath string) string {
 	return "file:" + escapedPath + "?" + q.Encode()
 }
 
-func (c *brSQLiteClient) openDB(ctx context.Context) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", c.dsn)
+// acquire returns the current statements with a reference the caller must
+// release, opening the pool on first use. The pool is reopened when the
+// database file has been replaced, e.g. by br rebuilding it from JSONL, since
+// open connections would keep reading the old file. With checkSchema the
+// statements are prepared again if the schema changed since they were.
+//
+// Queries run here while c.mu is held, so the caller must not hold a pooled
+// connection: Export calls acquire before starting its transaction. Readers
+// return their connections before releasing, so a query here always gets one.
+func (c *brSQLiteClient) acquire(ctx context.Context, checkSchema bool) (*brReadStmts, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	info, err := os.Stat(c.dbPath)
 	if err != nil {
+		c.closeLocked()
 		return nil, fmt.Errorf("open br sqlite db: %w", err)
 	}
-	db.SetMaxOpenConns(1)
-	db.SetMaxIdleConns(1)
+	if c.db == nil || !os.SameFile(c.file, info) {
+		c.closeLocked()
+		db, err := openBrReadPool(ctx, c.dsn)
+		if err != nil {
+			return nil, err
+		}
+		c.db, c.file = db, info
+	}
+
+	if c.stmts == nil || checkSchema {
+		version, err := brSchemaVersion(ctx, c.db)
+		if err != nil {
+			return nil, err
+		}
+		if c.stmts == nil || c.stmts.version != version {
+			stmts, err := prepareBrReadStmts(ctx, c.db, version)
+			if err != nil {
+				return nil, err
+			}
+			c.retireLocked(c.stmts, false)
+			c.stmts = stmts
+		}
+	}
+	c.stmts.refs++
+	return c.stmts, nil
+}
+
+// release drops a reference taken by acquire.
+func (c *brSQLiteClient) release(s *brReadStmts) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s.refs--
+	if s.retired && s.refs == 0 {
+		s.close()
+	}
+}
+
+// retireLocked closes s once no reader holds it, along with its pool when
+// closeDB is set.
+func (c *brSQLiteClient) retireLocked(s *brReadStmts, closeDB bool) {
+	if s == nil {
+		return
+	}
+	s.retired = true
+	s.closeDB = s.closeDB || closeDB
+	if s.refs == 0 {
+		s.close()
+	}
+}
+
+func openBrReadPool(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open br sqlite db: %w", err)
+	}
+	db.SetMaxOpenConns(brReadPoolSize)
+	db.SetMaxIdleConns(brReadPoolSize)
 	db.SetConnMaxLifetime(0)
 	if err := db.PingContext(ctx); err != nil {
 		_ = db.Close()
@@ -112,23 +254,79 @@ func (c *brSQLiteClient) openDB(ctx context.Context) (*sql.DB, error) {
 	return db, nil
 }
 
+func brSchemaVersion(ctx context.Context, db *sql.DB) (int64, error) {
+	var version int64
+	if err := db.QueryRowContext(ctx, `PRAGMA schema_version`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("read schema version: %w", err)
+	}
+	return version, nil
+}
+
+func prepareBrReadStmts(ctx context.Context, db *sql.DB, version int64) (*brReadStmts, error) {
+	cols, err := brIssueColumns(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	s := &brReadStmts{db: db, version: version}
+	for _, q := range []struct {
+		dst   **sql.Stmt
+		query string
+	}{
+		{&s.list, brListQuery},
+		{&s.labels, brLabelsQuery},
+		{&s.dependencies, brDependenciesQuery},
+		{&s.comments, brCommentsQuery},
+		{&s.issueComments, brIssueCommentsQuery},
+		{&s.issues, brIssuesQuery(cols)},
+	} {
+		stmt, err := db.PrepareContext(ctx, q.query)
+		if err != nil {
+			s.close()
+			return nil, fmt.Errorf("prepare br query: %w", err)
+		}
+		*q.dst = stmt
+	}
+	return s, nil
+}
+
+// Shutdown closes the read pool once in-flight reads finish, and the direct
+// writer's handle. A later read or write opens them again.
+func (c *brSQLiteClient) Shutdown() error {
+	c.mu.Lock()
+	c.closeLocked()
+	c.mu.Unlock()
+	if w, ok := c.writer.(*brSQLiteWriter); ok {
+		return w.Shutdown()
+	}
+	return nil
+}
+
+func (c *brSQLiteClient) closeLocked() {
+	if c.stmts != nil {
+		c.retireLocked(c.stmts, true)
+	} else if c.db != nil {
+		_ = c.db.Close()
+	}
+	c.db, c.file, c.stmts = nil, nil, nil
+}
+
 // Reader interface implementation - direct SQLite queries
 
+const brListQuery = `
+	SELECT id
+	FROM issues
+	WHERE status != 'tombstone' AND (deleted_at IS NULL)
+	ORDER BY created_at, id
+`
+
 func (c *brSQLiteClient) List(ctx context.Context) ([]LiteIssue, error) {
-	db, err := c.openDB(ctx)
+	stmts, err := c.acquire(ctx, false)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		_ = db.Close()
-	}()
+	defer c.release(stmts)
 
-	rows, err := db.QueryContext(ctx, `
-		SELECT id
-		FROM issues
-		WHERE status != 'tombstone' AND (deleted_at IS NULL)
-		ORDER BY created_at, id
-	`)
+	rows, err := stmts.list.QueryContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("query issues: %w", err)
 	}
@@ -169,26 +367,35 @@ func (c *brSQLiteClient) Show(ctx context.Context, ids []string) ([]FullIssue, e
 }
 
 func (c *brSQLiteClient) Export(ctx context.Context) ([]FullIssue, error) {
-	db, err := c.openDB(ctx)
+	stmts, err := c.acquire(ctx, true)
 	if err != nil {
 		return nil, err
 	}
+	defer c.release(stmts)
+
+	// SQLite takes the read snapshot at the transaction's first query and
+	// keeps it until the end, so a br write landing mid-export is either
+	// entirely visible or not at all.
+	tx, err := stmts.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin read transaction: %w", err)
+	}
 	defer func() {
-		_ = db.Close()
+		_ = tx.Rollback()
 	}()
 
-	issueMap, ordered, err := brLoadIssues(ctx, db)
+	issueMap, ordered, err := brLoadIssues(ctx, tx.StmtContext(ctx, stmts.issues))
 	if err != nil {
 		return nil, err
 	}
 
-	if err := brLoadLabels(ctx, db, issueMap); err != nil {
+	if err := brLoadLabels(ctx, tx.StmtContext(ctx, stmts.labels), issueMap); err != nil {
 		return nil, err
 	}
-	if err := brLoadDependencies(ctx, db, issueMap); err != nil {
+	if err := brLoadDependencies(ctx, tx.StmtContext(ctx, stmts.dependencies), issueMap); err != nil {
 		return nil, err
 	}
-	if err := brLoadComments(ctx, db, issueMap); err != nil {
+	if err := brLoadComments(ctx, tx.StmtContext(ctx, stmts.comments), issueMap); err != nil {
 		return nil, err
 	}
 
@@ -199,15 +406,58 @@ func (c *brSQLiteClient) Export(ctx context.Context) ([]FullIssue, error) {
 	return out, nil
 }
 
-func brLoadIssues(ctx context.Context, db *sql.DB) (map[string]*FullIssue, []*FullIssue, error) {
-	const query = `SELECT id, title, description, design, acceptance_criteria, notes,
+// brOptionalIssueColumns lists scheduling columns that only some br schema
+// versions have, with the expression used to read each one.
+var brOptionalIssueColumns = []struct {
+	name string
+	expr string
+	zero string
+}{
+	{name: "due_at", expr: "COALESCE(due_at, '')", zero: "''"},
+	{name: "defer_until", expr: "COALESCE(defer_until, '')", zero: "''"},
+	{name: "estimated_minutes", expr: "COALESCE(estimated_minutes, 0)", zero: "0"},
+}
+
+// brIssueColumns returns the set of column names in the issues table.
+func brIssueColumns(ctx context.Context, db *sql.DB) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM pragma_table_info('issues')`)
+	if err != nil {
+		return nil, fmt.Errorf("query issues columns: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	cols := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scan issues column: %w", err)
+		}
+		cols[name] = true
+	}
+	return cols, rows.Err()
+}
+
+// brIssuesQuery returns the issues query for a table with the given columns.
+func brIssuesQuery(cols map[string]bool) string {
+	optional := make([]string, len(brOptionalIssueColumns))
+	for i, col := range brOptionalIssueColumns {
+		optional[i] = col.zero
+		if cols[col.name] {
+			optional[i] = col.expr
+		}
+	}
+	return `SELECT id, title, description, design, acceptance_criteria, notes,
 		       status, priority, issue_type, COALESCE(assignee, ''),
 		       COALESCE(created_by, ''),
 		       created_at, updated_at, COALESCE(closed_at, ''), COALESCE(external_ref, ''),
-		       COALESCE(close_reason, '')
+		       COALESCE(close_reason, ''), ` + strings.Join(optional, ", ") + `
 		FROM issues WHERE status != 'tombstone' AND (deleted_at IS NULL) ORDER BY created_at, id`
+}
 
-	rows, err := db.QueryContext(ctx, query)
+func brLoadIssues(ctx context.Context, stmt *sql.Stmt) (map[string]*FullIssue, []*FullIssue, error) {
+	rows, err := stmt.QueryContext(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("query issues: %w", err)
 	}
@@ -236,6 +486,9 @@ func brLoadIssues(ctx context.Context, db *sql.DB) (map[string]*FullIssue, []*Fu
 			&iss.ClosedAt,
 			&iss.ExternalRef,
 			&iss.CloseReason,
+			&iss.DueAt,
+			&iss.DeferUntil,
+			&iss.EstimatedMinutes,
 		)
 		if scanErr != nil {
 			return nil, nil, fmt.Errorf("scan issue: %w", scanErr)
@@ -250,12 +503,14 @@ func brLoadIssues(ctx context.Context, db *sql.DB) (map[string]*FullIssue, []*Fu
 	return issues, ordered, rows.Err()
 }
 
-func brLoadLabels(ctx context.Context, db *sql.DB, issues map[string]*FullIssue) error {
-	rows, err := db.QueryContext(ctx, `
-		SELECT issue_id, label
-		FROM labels
-		ORDER BY issue_id, label
-	`)
+const brLabelsQuery = `
+	SELECT issue_id, label
+	FROM labels
+	ORDER BY issue_id, label
+`
+
+func brLoadLabels(ctx context.Context, stmt *sql.Stmt, issues map[string]*FullIssue) error {
+	rows, err := stmt.QueryContext(ctx)
 	if err != nil {
 		return fmt.Errorf("query labels: %w", err)
 	}
@@ -275,11 +530,13 @@ func brLoadLabels(ctx context.Context, db *sql.DB, issues map[string]*FullIssue)
 	return rows.Err()
 }
 
-func brLoadDependencies(ctx context.Context, db *sql.DB, issues map[string]*FullIssue) error {
-	rows, err := db.QueryContext(ctx, `
-		SELECT issue_id, depends_on_id, type
-		FROM dependencies
-	`)
+const brDependenciesQuery = `
+	SELECT issue_id, depends_on_id, type
+	FROM dependencies
+`
+
+func brLoadDependencies(ctx context.Context, stmt *sql.Stmt, issues map[string]*FullIssue) error {
+	rows, err := stmt.QueryContext(ctx)
 	if err != nil {
 		return fmt.Errorf("query dependencies: %w", err)
 	}
@@ -302,12 +559,22 @@ func brLoadDependencies(ctx context.Context, db *sql.DB, issues map[string]*Full
 	return rows.Err()
 }
 
-func brLoadComments(ctx context.Context, db *sql.DB, issues map[string]*FullIssue) error {
-	rows, err := db.QueryContext(ctx, `
-		SELECT id, issue_id, author, text, COALESCE(created_at, '')
-		FROM comments
-		ORDER BY created_at, id
-	`)
+const (
+	brCommentsQuery = `
+	SELECT id, issue_id, author, text, COALESCE(created_at, '')
+	FROM comments
+	ORDER BY created_at, id
+`
+	brIssueCommentsQuery = `
+	SELECT id, issue_id, author, text, COALESCE(created_at, '')
+	FROM comments
+	WHERE issue_id = ?
+	ORDER BY created_at, id
+`
+)
+
+func brLoadComments(ctx context.Context, stmt *sql.Stmt, issues map[string]*FullIssue) error {
+	rows, err := stmt.QueryContext(ctx)
 	if err != nil {
 		return fmt.Errorf("query comments: %w", err)
 	}
@@ -328,20 +595,13 @@ func brLoadComments(ctx context.Context, db *sql.DB, issues map[string]*FullIssu
 }
 
 func (c *brSQLiteClient) Comments(ctx context.Context, issueID string) ([]Comment, error) {
-	db, err := c.openDB(ctx)
+	stmts, err := c.acquire(ctx, false)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		_ = db.Close()
-	}()
+	defer c.release(stmts)
 
-	rows, err := db.QueryContext(ctx, `
-		SELECT id, issue_id, author, text, COALESCE(created_at, '')
-		FROM comments
-		WHERE issue_id = ?
-		ORDER BY created_at, id
-	`, issueID)
+	rows, err := stmts.issueComments.QueryContext(ctx, issueID)
 	if err != nil {
 		return nil, fmt.Errorf("query comments: %w", err)
 	}
@@ -363,6 +623,17 @@ func (c *brSQLiteClient) Comments(ctx context.Context, issueID string) ([]Commen
 	return comments, rows.Err()
 }
 
+// History reads the issue's events, falling back to the git history of the
+// JSONL export for databases without them.
+func (c *brSQLiteClient) History(ctx context.Context, issueID string) ([]HistoryEvent, error) {
+	stmts, err := c.acquire(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(stmts)
+	return loadHistory(ctx, stmts.db, c.dbPath, issueID)
+}
+
 func scanBrComment(rows *sql.Rows) (Comment, error) {
 	var cmt Comment
 	if err := rows.Scan(&cmt.ID, &cmt.IssueID, &cmt.Author, &cmt.Text, &cmt.CreatedAt); err != nil {
@@ -409,7 +680,7 @@ func brLooksLikeTimestamp(value string) bool {
 	return false
 }
 
-// Writer interface - delegate to brCLIClient
+// Writer interface - delegate to brCLIClient or brSQLiteWriter
 
 func (c *brSQLiteClient) UpdateStatus(ctx context.Context, issueID, newStatus string) error {
 	return c.writer.UpdateStatus(ctx, issueID, newStatus)
@@ -419,10 +690,26 @@ func (c *brSQLiteClient) UpdatePriority(ctx context.Context, issueID string, pri
 	return c.writer.UpdatePriority(ctx, issueID, priority)
 }
 
+func (c *brSQLiteClient) UpdateAssignee(ctx context.Context, issueID, assignee string) error {
+	return c.writer.UpdateAssignee(ctx, issueID, assignee)
+}
+
+func (c *brSQLiteClient) UpdateSchedule(ctx context.Context, issueID, due, deferUntil string, estimateMinutes int) error {
+	return c.writer.UpdateSchedule(ctx, issueID, due, deferUntil, estimateMinutes)
+}
+
+func (c *brSQLiteClient) UpdateExternalRef(ctx context.Context, issueID, ref string) error {
+	return c.writer.UpdateExternalRef(ctx, issueID, ref)
+}
+
 func (c *brSQLiteClient) Close(ctx context.Context, issueID string) error {
 	return c.writer.Close(ctx, issueID)
 }
 
+func (c *brSQLiteClient) CloseWithReason(ctx context.Context, issueID, reason string) error {
+	return c.writer.CloseWithReason(ctx, issueID, reason)
+}
+
 func (c *brSQLiteClient) Reopen(ctx context.Context, issueID string) error {
 	return c.writer.Reopen(ctx, issueID)
 }
@@ -462,3 +749,17 @@ func (c *brSQLiteClient) Delete(ctx context.Context, issueID string, cascade boo
 func (c *brSQLiteClient) AddComment(ctx context.Context, issueID, text string) error {
 	return c.writer.AddComment(ctx, issueID, text)
 }
+
+// CanEditComments reports false: br cannot edit or delete comments, and the
+// direct writer leaves both to br.
+func (c *brSQLiteClient) CanEditComments() bool {
+	return false
+}
+
+func (c *brSQLiteClient) UpdateComment(ctx context.Context, issueID string, commentID int, text string) error {
+	return c.writer.UpdateComment(ctx, issueID, commentID, text)
+}
+
+func (c *brSQLiteClient) DeleteComment(ctx context.Context, issueID string, commentID int) error {
+	return c.writer.DeleteComment(ctx, issueID, commentID)
+}
@@ -3,10 +3,14 @@ package beads
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	_ "modernc.org/sqlite"
 )
@@ -339,6 +343,48 @@ func TestBrSQLiteClient_Export_LoadsCreatedBy(t *testing.T) {
 	}
 }
 
+func TestBrSQLiteClient_Export_LoadsScheduleColumns(t *testing.T) {
+	t.Parallel()
+
+	dbPath := testBrDB(t)
+	seedTestData(t, dbPath)
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	for _, stmt := range []string{
+		`ALTER TABLE issues ADD COLUMN due_at TEXT`,
+		`ALTER TABLE issues ADD COLUMN defer_until TEXT`,
+		`ALTER TABLE issues ADD COLUMN estimated_minutes INTEGER`,
+		`UPDATE issues SET due_at = '2025-02-01T00:00:00Z', defer_until = '2025-01-15T00:00:00Z', estimated_minutes = 90 WHERE id = 'ab-001'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("exec %q: %v", stmt, err)
+		}
+	}
+	_ = db.Close()
+
+	client := NewBrSQLiteClient(dbPath)
+	issues, err := client.Export(context.Background())
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	for _, iss := range issues {
+		switch iss.ID {
+		case "ab-001":
+			if iss.DueAt != "2025-02-01T00:00:00Z" || iss.DeferUntil != "2025-01-15T00:00:00Z" || iss.EstimatedMinutes != 90 {
+				t.Errorf("unexpected schedule for ab-001: due=%q defer=%q estimate=%d", iss.DueAt, iss.DeferUntil, iss.EstimatedMinutes)
+			}
+		default:
+			if iss.DueAt != "" || iss.DeferUntil != "" || iss.EstimatedMinutes != 0 {
+				t.Errorf("expected empty schedule for %s, got due=%q defer=%q estimate=%d", iss.ID, iss.DueAt, iss.DeferUntil, iss.EstimatedMinutes)
+			}
+		}
+	}
+}
+
 func TestBrSQLiteClient_Export_LoadsDependencies(t *testing.T) {
 	t.Parallel()
 
@@ -508,6 +554,186 @@ func TestBrSQLiteClient_Comments_NoComments(t *testing.T) {
 	}
 }
 
+func TestBrSQLiteClient_ReusesReadPool(t *testing.T) {
+	t.Parallel()
+
+	dbPath := testBrDB(t)
+	seedTestData(t, dbPath)
+
+	client := NewBrSQLiteClient(dbPath).(*brSQLiteClient)
+	ctx := context.Background()
+
+	if _, err := client.Export(ctx); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	pool := client.db
+	if pool == nil {
+		t.Fatal("expected Export to open the read pool")
+	}
+	if _, err := client.List(ctx); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if comments, err := client.Comments(ctx, "ab-001"); err != nil || len(comments) != 2 {
+		t.Fatalf("Comments = %d, %v", len(comments), err)
+	}
+	if _, err := client.Export(ctx); err != nil {
+		t.Fatalf("second Export: %v", err)
+	}
+	if client.db != pool {
+		t.Error("expected reads to reuse one pool")
+	}
+
+	if err := ShutdownClient(client); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if client.db != nil {
+		t.Fatal("expected Shutdown to close the pool")
+	}
+	if issues, err := client.Export(ctx); err != nil || len(issues) != 3 {
+		t.Fatalf("Export after Shutdown = %d issues, %v", len(issues), err)
+	}
+	_ = client.Shutdown()
+}
+
+func TestBrSQLiteClient_ConcurrentReads(t *testing.T) {
+	t.Parallel()
+
+	dbPath := testBrDB(t)
+	seedTestData(t, dbPath)
+
+	client := NewBrSQLiteClient(dbPath).(*brSQLiteClient)
+	defer func() {
+		_ = client.Shutdown()
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// More readers than pooled connections, starting on a cold pool, with a
+	// migration halfway through so statements are replaced under them.
+	const readers = 3 * brReadPoolSize
+	run := func() {
+		var wg sync.WaitGroup
+		errs := make(chan error, 2*readers)
+		for range readers {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				issues, err := client.Export(ctx)
+				if err == nil && len(issues) != 3 {
+					err = fmt.Errorf("Export returned %d issues", len(issues))
+				}
+				errs <- err
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := client.Comments(ctx, "ab-001")
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatalf("concurrent read: %v", err)
+			}
+		}
+	}
+
+	run()
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if _, err := db.Exec(`ALTER TABLE issues ADD COLUMN due_at TEXT`); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	_ = db.Close()
+	run()
+}
+
+func TestBrSQLiteClient_Export_FollowsSchemaMigration(t *testing.T) {
+	t.Parallel()
+
+	dbPath := testBrDB(t)
+	seedTestData(t, dbPath)
+
+	client := NewBrSQLiteClient(dbPath).(*brSQLiteClient)
+	defer func() {
+		_ = client.Shutdown()
+	}()
+	ctx := context.Background()
+	if _, err := client.Export(ctx); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	// br adds the scheduling columns while abacus is running.
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	for _, stmt := range []string{
+		`ALTER TABLE issues ADD COLUMN due_at TEXT`,
+		`ALTER TABLE issues ADD COLUMN defer_until TEXT`,
+		`ALTER TABLE issues ADD COLUMN estimated_minutes INTEGER`,
+		`UPDATE issues SET due_at = '2025-02-01T00:00:00Z', estimated_minutes = 30 WHERE id = 'ab-002'`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("exec %q: %v", stmt, err)
+		}
+	}
+	_ = db.Close()
+
+	issues, err := client.Export(ctx)
+	if err != nil {
+		t.Fatalf("Export after migration: %v", err)
+	}
+	for _, iss := range issues {
+		if iss.ID == "ab-002" && (iss.DueAt != "2025-02-01T00:00:00Z" || iss.EstimatedMinutes != 30) {
+			t.Errorf("expected the new columns to be read, got due=%q estimate=%d", iss.DueAt, iss.EstimatedMinutes)
+		}
+	}
+}
+
+func TestBrSQLiteClient_ReopensReplacedDatabase(t *testing.T) {
+	t.Parallel()
+
+	dbPath := testBrDB(t)
+	seedTestData(t, dbPath)
+
+	client := NewBrSQLiteClient(dbPath).(*brSQLiteClient)
+	defer func() {
+		_ = client.Shutdown()
+	}()
+	ctx := context.Background()
+	if issues, err := client.Export(ctx); err != nil || len(issues) != 3 {
+		t.Fatalf("Export = %d issues, %v", len(issues), err)
+	}
+
+	// Rebuilding from JSONL writes a fresh file and renames it into place.
+	rebuilt := filepath.Join(filepath.Dir(dbPath), "rebuilt.db")
+	createTestBrDB(t, rebuilt)
+	db, err := sql.Open("sqlite", rebuilt)
+	if err != nil {
+		t.Fatalf("open rebuilt db: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO issues (id, title, status, priority, issue_type, created_at, updated_at)
+		VALUES ('ab-100', 'Rebuilt', 'open', 2, 'task', '2025-03-01T00:00:00Z', '2025-03-01T00:00:00Z')`); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	_ = db.Close()
+	if err := os.Rename(rebuilt, dbPath); err != nil {
+		t.Fatalf("replace db: %v", err)
+	}
+
+	issues, err := client.Export(ctx)
+	if err != nil {
+		t.Fatalf("Export after rebuild: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != "ab-100" {
+		t.Errorf("expected the rebuilt database to be read, got %+v", issues)
+	}
+}
+
 func TestBrSQLiteClient_List_EmptyDB(t *testing.T) {
 	t.Parallel()
 
@@ -1034,3 +1260,126 @@ func TestDeriveWorkDirFromDBPath(t *testing.T) {
 		})
 	}
 }
+
+func TestBrSQLiteClient_History_Events(t *testing.T) {
+	t.Parallel()
+
+	dbPath := testBrDB(t)
+	seedTestData(t, dbPath)
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	_, err = db.Exec(`
+		CREATE TABLE events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			issue_id TEXT NOT NULL,
+			event_type TEXT NOT NULL,
+			actor TEXT NOT NULL,
+			old_value TEXT,
+			new_value TEXT,
+			comment TEXT,
+			created_at TEXT NOT NULL
+		);
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at) VALUES
+			('ab-001', 'created', 'alice', NULL, NULL, NULL, '2026-01-01T10:00:00Z'),
+			('ab-001', 'status_changed', 'bob', 'open', 'in_progress', NULL, '2026-01-02T10:00:00Z'),
+			('ab-001', 'updated', 'carol', '{"priority":2,"title":"Old"}', '{"priority":0}', NULL, '2026-01-03T10:00:00Z'),
+			('ab-001', 'commented', 'bob', NULL, NULL, 'Looking', '2026-01-03T11:00:00Z'),
+			('ab-001', 'label_added', 'bob', NULL, NULL, 'Added label: urgent', '2026-01-04T10:00:00Z'),
+			('ab-002', 'created', 'alice', NULL, NULL, NULL, '2026-01-01T10:00:00Z');
+	`)
+	if err != nil {
+		t.Fatalf("seed events: %v", err)
+	}
+
+	history, err := NewBrSQLiteClient(dbPath).History(context.Background(), "ab-001")
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	want := []HistoryEvent{
+		{IssueID: "ab-001", Field: HistoryCreated, Actor: "alice", CreatedAt: "2026-01-01T10:00:00Z"},
+		{IssueID: "ab-001", Field: HistoryStatus, Actor: "bob", OldValue: "open", NewValue: "in_progress", CreatedAt: "2026-01-02T10:00:00Z"},
+		{IssueID: "ab-001", Field: HistoryPriority, Actor: "carol", OldValue: "2", NewValue: "0", CreatedAt: "2026-01-03T10:00:00Z"},
+		{IssueID: "ab-001", Field: HistoryLabels, Actor: "bob", NewValue: "urgent", CreatedAt: "2026-01-04T10:00:00Z"},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), history)
+	}
+	for i := range want {
+		if history[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, history[i], want[i])
+		}
+	}
+}
+
+func TestBrSQLiteClient_History_JSONLFallback(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	root := t.TempDir()
+	dbPath := filepath.Join(root, ".beads", "beads.db")
+	createTestBrDB(t, dbPath)
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		cmd.Env = append(cmd.Environ(),
+			"GIT_AUTHOR_NAME=Dana", "GIT_AUTHOR_EMAIL=dana@example.com",
+			"GIT_COMMITTER_NAME=Dana", "GIT_COMMITTER_EMAIL=dana@example.com",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	jsonl := filepath.Join(root, ".beads", "issues.jsonl")
+	for i, line := range []string{
+		`{"id":"ab-001","title":"Crash","status":"open","priority":2,"labels":["bug"],"created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-01T10:00:00Z"}`,
+		`{"id":"ab-001","title":"Crash","status":"open","priority":0,"assignee":"erin","labels":["bug","urgent"],"dependencies":[{"issue_id":"ab-001","depends_on_id":"ab-009","type":"blocks"}],"created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-02T10:00:00Z"}`,
+	} {
+		if err := os.WriteFile(jsonl, []byte(line+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".beads/issues.jsonl")
+		git("commit", "-q", "-m", fmt.Sprintf("sync %d", i))
+	}
+
+	history, err := NewBrSQLiteClient(dbPath).History(context.Background(), "ab-001")
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	want := []HistoryEvent{
+		{IssueID: "ab-001", Field: HistoryCreated, Actor: "Dana", CreatedAt: "2026-01-01T10:00:00Z"},
+		{IssueID: "ab-001", Field: HistoryPriority, Actor: "Dana", OldValue: "2", NewValue: "0", CreatedAt: "2026-01-02T10:00:00Z"},
+		{IssueID: "ab-001", Field: HistoryAssignee, Actor: "Dana", NewValue: "erin", CreatedAt: "2026-01-02T10:00:00Z"},
+		{IssueID: "ab-001", Field: HistoryLabels, Actor: "Dana", NewValue: "urgent", CreatedAt: "2026-01-02T10:00:00Z"},
+		{IssueID: "ab-001", Field: HistoryDependencies, Actor: "Dana", NewValue: "blocks ab-009", CreatedAt: "2026-01-02T10:00:00Z"},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), history)
+	}
+	for i := range want {
+		if history[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, history[i], want[i])
+		}
+	}
+}
+
+func TestBrSQLiteClient_History_NoSources(t *testing.T) {
+	t.Parallel()
+
+	dbPath := testBrDB(t)
+	history, err := NewBrSQLiteClient(dbPath).History(context.Background(), "ab-001")
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if history == nil || len(history) != 0 {
+		t.Errorf("expected empty history, got %#v", history)
+	}
+}
@@ -0,0 +1,952 @@
+package beads
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	appErrors "abacus/internal/errors"
+)
+
+// brSQLiteWriter implements Writer by changing the br database directly, one
+// SQLite transaction per operation, instead of starting br for each mutation.
+// Changes are recorded the way br records its own: events for history, dirty
+// marks so br exports the touched issues to JSONL on its next flush, and a
+// rebuilt blocked-issues cache.
+//
+// Only databases whose schema is recognized are written directly. Everything
+// else goes through the br CLI: unrecognized schemas, operations br
+// normalizes itself (deletes and schedules), and input br would reject, so
+// users still see br's own validation errors.
+//
+// The database is opened and its schema detected on the first write and
+// kept until Shutdown. The schema is detected again when a write fails
+// because a table or column changed under it, and the handle is reopened
+// when the database file is replaced.
+type brSQLiteWriter struct {
+	dbPath string
+	dsn    string
+	actor  string // recorded on events, comments and new issues
+	cli    Writer
+
+	mu     sync.Mutex
+	db     *sql.DB     // write handle, nil until first use or after Shutdown
+	file   os.FileInfo // the database file db was opened on
+	schema *brWriteSchema
+}
+
+func newBrSQLiteWriter(dbPath string, cli *brCLIClient) *brSQLiteWriter {
+	actor := cli.author
+	if actor == "" {
+		actor = strings.TrimSpace(os.Getenv("USER"))
+	}
+	if actor == "" {
+		actor = "abacus"
+	}
+	return &brSQLiteWriter{dbPath: dbPath, dsn: buildSQLiteWriteDSN(dbPath), actor: actor, cli: cli}
+}
+
+// errBrUseCLI is returned inside a write to hand the operation to br.
+var errBrUseCLI = errors.New("beads: leave write to br")
+
+// brStatuses and brIssueTypes are the values br accepts; others are passed
+// to br so it can report them.
+var (
+	brStatuses   = []string{"open", "in_progress", "blocked", "deferred", "closed", "pinned"}
+	brIssueTypes = []string{"task", "bug", "feature", "epic", "chore", "docs", "question"}
+)
+
+// brTimestampLayout is fixed-width so timestamps sort as text.
+const brTimestampLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// brWriteSchemaV1 is the br database layout brSQLiteWriter writes.
+const brWriteSchemaV1 = 1
+
+// brWriteTable describes a table the direct writer changes: the columns it
+// needs and the optional ones it fills in when they exist.
+type brWriteTable struct {
+	required bool
+	columns  []string
+	optional []string
+}
+
+var brWriteTables = map[string]brWriteTable{
+	"issues": {
+		required: true,
+		columns: []string{
+			"id", "title", "description", "design", "acceptance_criteria", "notes", "status", "priority",
+			"issue_type", "assignee", "created_at", "updated_at", "closed_at", "close_reason", "external_ref", "deleted_at",
+		},
+		optional: []string{"created_by"},
+	},
+	"labels":               {required: true, columns: []string{"issue_id", "label"}},
+	"dependencies":         {required: true, columns: []string{"issue_id", "depends_on_id", "type"}, optional: []string{"created_at", "created_by"}},
+	"comments":             {required: true, columns: []string{"issue_id", "author", "text", "created_at"}},
+	"dirty_issues":         {required: true, columns: []string{"issue_id"}, optional: []string{"marked_at"}},
+	"events":               {columns: []string{"issue_id", "event_type", "actor", "old_value", "new_value", "comment", "created_at"}},
+	"blocked_issues_cache": {columns: []string{"issue_id"}},
+}
+
+// brWriteSchema is the detected layout: its version, or 0 when it is not
+// one the writer knows, and the columns of each table present.
+type brWriteSchema struct {
+	version int
+	columns map[string]map[string]bool
+}
+
+func (s brWriteSchema) has(table string) bool {
+	return s.columns[table] != nil
+}
+
+// detectBrWriteSchema identifies the schema version from the tables and
+// columns present. A layout is recognized when every required table has the
+// columns the writer sets and no table has a mandatory column the writer
+// would leave empty, so a br release that adds such a column falls back to
+// the CLI instead of failing or writing rows br does not expect.
+func detectBrWriteSchema(ctx context.Context, db *sql.DB) (brWriteSchema, error) {
+	schema := brWriteSchema{columns: make(map[string]map[string]bool)}
+	for name, table := range brWriteTables {
+		cols, mandatory, err := brTableColumns(ctx, db, name)
+		if err != nil {
+			return brWriteSchema{}, err
+		}
+		if len(cols) == 0 {
+			if table.required {
+				return brWriteSchema{}, nil
+			}
+			continue
+		}
+		writable := append(slices.Clone(table.columns), table.optional...)
+		for _, col := range table.columns {
+			if !cols[col] {
+				return brWriteSchema{}, nil
+			}
+		}
+		for _, col := range mandatory {
+			if !slices.Contains(writable, col) {
+				return brWriteSchema{}, nil
+			}
+		}
+		schema.columns[name] = cols
+	}
+	schema.version = brWriteSchemaV1
+	return schema, nil
+}
+
+// brTableColumns returns a table's columns (none if it does not exist) and
+// those that must be given a value on insert.
+func brTableColumns(ctx context.Context, db *sql.DB, table string) (map[string]bool, []string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name, type, "notnull", dflt_value IS NOT NULL, pk FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query %s columns: %w", table, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	cols := make(map[string]bool)
+	var mandatory []string
+	for rows.Next() {
+		var name, colType string
+		var notNull, hasDefault bool
+		var pk int
+		if err := rows.Scan(&name, &colType, &notNull, &hasDefault, &pk); err != nil {
+			return nil, nil, fmt.Errorf("scan %s column: %w", table, err)
+		}
+		cols[name] = true
+		rowID := pk == 1 && strings.EqualFold(colType, "INTEGER")
+		if notNull && !hasDefault && !rowID {
+			mandatory = append(mandatory, name)
+		}
+	}
+	return cols, mandatory, rows.Err()
+}
+
+// isBrSchemaError reports whether err means a table or column the write
+// used no longer matches the detected schema.
+func isBrSchemaError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	for _, s := range []string{"no such table", "no such column", "has no column named", "NOT NULL constraint failed"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// conn returns the write handle and its detected schema, opening the
+// database on first use or after the file was replaced. redetect discards
+// the cached schema.
+func (w *brSQLiteWriter) conn(ctx context.Context, redetect bool) (*sql.DB, brWriteSchema, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	info, err := os.Stat(w.dbPath)
+	if err != nil {
+		w.closeLocked()
+		return nil, brWriteSchema{}, fmt.Errorf("stat br database: %w", err)
+	}
+	if w.db == nil || !os.SameFile(w.file, info) {
+		w.closeLocked()
+		db, err := sql.Open("sqlite", w.dsn)
+		if err != nil {
+			return nil, brWriteSchema{}, err
+		}
+		db.SetMaxOpenConns(1)
+		w.db, w.file = db, info
+	}
+	if w.schema == nil || redetect {
+		schema, err := detectBrWriteSchema(ctx, w.db)
+		if err != nil {
+			return nil, brWriteSchema{}, err
+		}
+		w.schema = &schema
+	}
+	return w.db, *w.schema, nil
+}
+
+// Shutdown closes the write handle. A later write opens it again.
+func (w *brSQLiteWriter) Shutdown() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closeLocked()
+	return nil
+}
+
+func (w *brSQLiteWriter) closeLocked() {
+	if w.db != nil {
+		_ = w.db.Close()
+	}
+	w.db, w.file, w.schema = nil, nil, nil
+}
+
+// apply runs fn in a write transaction when the schema is recognized and
+// viaCLI otherwise, or when fn hands the operation back with errBrUseCLI.
+// A write that fails on a schema change is retried once against the
+// schema detected again.
+func (w *brSQLiteWriter) apply(ctx context.Context, fn func(*brWriteTx) error, viaCLI func() error) error {
+	db, schema, err := w.conn(ctx, false)
+	if err != nil || schema.version != brWriteSchemaV1 {
+		return viaCLI()
+	}
+	err = w.write(ctx, db, schema, fn)
+	if isBrSchemaError(err) {
+		db, schema, err = w.conn(ctx, true)
+		if err != nil || schema.version != brWriteSchemaV1 {
+			return viaCLI()
+		}
+		err = w.write(ctx, db, schema, fn)
+	}
+	if errors.Is(err, errBrUseCLI) {
+		return viaCLI()
+	}
+	return err
+}
+
+// write runs fn in one transaction on db.
+func (w *brSQLiteWriter) write(ctx context.Context, db *sql.DB, schema brWriteSchema, fn func(*brWriteTx) error) error {
+	sqlTx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin br write: %w", err)
+	}
+	tx := &brWriteTx{
+		tx:     sqlTx,
+		schema: schema,
+		actor:  w.actor,
+		now:    time.Now().UTC().Format(brTimestampLayout),
+	}
+	if err := fn(tx); err != nil {
+		_ = sqlTx.Rollback()
+		return err
+	}
+	if tx.blockingChanged && schema.has("blocked_issues_cache") {
+		if err := tx.rebuildBlockedCache(ctx); err != nil {
+			_ = sqlTx.Rollback()
+			return err
+		}
+	}
+	if err := sqlTx.Commit(); err != nil {
+		return fmt.Errorf("commit br write: %w", err)
+	}
+	return nil
+}
+
+// brWriteTx is one direct write in progress.
+type brWriteTx struct {
+	tx     *sql.Tx
+	schema brWriteSchema
+	actor  string
+	now    string
+
+	blockingChanged bool // statuses or dependencies changed
+}
+
+// brIssueState is what writes need to know about an existing issue.
+type brIssueState struct {
+	title, status, issueType, assignee string
+	priority                           int
+}
+
+func brIssueNotFound(id string) error {
+	return appErrors.New(appErrors.CodeNotFound, fmt.Sprintf("issue not found: %s", id), ErrNotFound)
+}
+
+// issue loads a live issue; deleted issues are not found, as in br.
+func (tx *brWriteTx) issue(ctx context.Context, id string) (brIssueState, error) {
+	var st brIssueState
+	err := tx.tx.QueryRowContext(ctx, `
+		SELECT title, status, issue_type, COALESCE(assignee, ''), priority
+		FROM issues WHERE id = ? AND status != 'tombstone' AND deleted_at IS NULL
+	`, id).Scan(&st.title, &st.status, &st.issueType, &st.assignee, &st.priority)
+	if errors.Is(err, sql.ErrNoRows) {
+		return brIssueState{}, brIssueNotFound(id)
+	}
+	if err != nil {
+		return brIssueState{}, fmt.Errorf("load issue %s: %w", id, err)
+	}
+	return st, nil
+}
+
+// insert adds a row, skipping optional columns the table does not have.
+func (tx *brWriteTx) insert(ctx context.Context, verb, table string, values map[string]any) (sql.Result, error) {
+	cols := make([]string, 0, len(values))
+	for col := range values {
+		if tx.schema.columns[table][col] {
+			cols = append(cols, col)
+		}
+	}
+	sort.Strings(cols)
+	args := make([]any, len(cols))
+	for i, col := range cols {
+		args[i] = values[col]
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ")
+	query := fmt.Sprintf(`%s INTO %s (%s) VALUES (%s)`, verb, table, strings.Join(cols, ", "), placeholders) //nolint:gosec // G201: table and columns come from brWriteTables
+	res, err := tx.tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("insert into %s: %w", table, err)
+	}
+	return res, nil
+}
+
+// update sets issue columns, bumps updated_at and marks the issue dirty.
+func (tx *brWriteTx) update(ctx context.Context, id string, values map[string]any) error {
+	values["updated_at"] = tx.now
+	cols := make([]string, 0, len(values))
+	for col := range values {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+	sets := make([]string, len(cols))
+	args := make([]any, 0, len(cols)+1)
+	for i, col := range cols {
+		sets[i] = col + " = ?"
+		args = append(args, values[col])
+	}
+	args = append(args, id)
+	query := `UPDATE issues SET ` + strings.Join(sets, ", ") + ` WHERE id = ?` //nolint:gosec // G202: columns are fixed by the callers
+	if _, err := tx.tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("update issue %s: %w", id, err)
+	}
+	return tx.markDirty(ctx, id)
+}
+
+// markDirty queues the issue for br's next JSONL export.
+func (tx *brWriteTx) markDirty(ctx context.Context, id string) error {
+	_, err := tx.insert(ctx, "INSERT OR REPLACE", "dirty_issues", map[string]any{"issue_id": id, "marked_at": tx.now})
+	return err
+}
+
+// event records a history event when the database keeps them.
+func (tx *brWriteTx) event(ctx context.Context, id, eventType, oldValue, newValue, comment string) error {
+	if !tx.schema.has("events") {
+		return nil
+	}
+	_, err := tx.insert(ctx, "INSERT", "events", map[string]any{
+		"issue_id":   id,
+		"event_type": eventType,
+		"actor":      tx.actor,
+		"old_value":  nullIfEmpty(oldValue),
+		"new_value":  nullIfEmpty(newValue),
+		"comment":    nullIfEmpty(comment),
+		"created_at": tx.now,
+	})
+	return err
+}
+
+// changed records a "<field>_changed" event when a value differs.
+func (tx *brWriteTx) changed(ctx context.Context, id, field, oldValue, newValue string) error {
+	if oldValue == newValue {
+		return nil
+	}
+	return tx.event(ctx, id, field+"_changed", oldValue, newValue, "")
+}
+
+func (tx *brWriteTx) setLabels(ctx context.Context, id string, labels []string) error {
+	for _, label := range labels {
+		if _, err := tx.insert(ctx, "INSERT OR IGNORE", "labels", map[string]any{"issue_id": id, "label": label}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebuildBlockedCache recomputes the issues that open blockers hold up:
+// those with an unfinished "blocks" dependency and, transitively, the
+// children of blocked parents.
+func (tx *brWriteTx) rebuildBlockedCache(ctx context.Context) error {
+	if _, err := tx.tx.ExecContext(ctx, `DELETE FROM blocked_issues_cache`); err != nil {
+		return fmt.Errorf("clear blocked issues cache: %w", err)
+	}
+	_, err := tx.tx.ExecContext(ctx, `
+		WITH RECURSIVE blocked(id) AS (
+			SELECT d.issue_id
+			FROM dependencies d
+			JOIN issues blocker ON blocker.id = d.depends_on_id
+			WHERE d.type = 'blocks'
+			  AND blocker.status NOT IN ('closed', 'tombstone') AND blocker.deleted_at IS NULL
+			UNION
+			SELECT d.issue_id
+			FROM dependencies d
+			JOIN blocked b ON d.depends_on_id = b.id
+			WHERE d.type = 'parent-child'
+		)
+		INSERT INTO blocked_issues_cache (issue_id)
+		SELECT i.id FROM issues i JOIN blocked b ON b.id = i.id
+		WHERE i.status IN ('open', 'in_progress', 'blocked', 'deferred') AND i.deleted_at IS NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("rebuild blocked issues cache: %w", err)
+	}
+	return nil
+}
+
+// issuePrefix returns the workspace's ID prefix from br's config, or from
+// the most recent issue. Empty means br has to pick the ID.
+func (tx *brWriteTx) issuePrefix(ctx context.Context) (string, error) {
+	var prefix string
+	err := tx.tx.QueryRowContext(ctx, `SELECT value FROM config WHERE key = 'issue_prefix'`).Scan(&prefix)
+	if err == nil && strings.TrimSpace(prefix) != "" {
+		return strings.TrimSuffix(strings.TrimSpace(prefix), "-"), nil
+	}
+
+	var lastID string
+	err = tx.tx.QueryRowContext(ctx, `SELECT id FROM issues ORDER BY created_at DESC, id DESC LIMIT 1`).Scan(&lastID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("query issue prefix: %w", err)
+	}
+	base, _, _ := strings.Cut(lastID, ".")
+	if i := strings.LastIndex(base, "-"); i > 0 {
+		return base[:i], nil
+	}
+	return "", nil
+}
+
+// newIssueID picks an unused ID with a random base36 suffix, lengthening it
+// on collisions.
+func (tx *brWriteTx) newIssueID(ctx context.Context, prefix string) (string, error) {
+	const alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+	for length := 4; length <= 8; length++ {
+		for attempt := 0; attempt < 3; attempt++ {
+			buf := make([]byte, length)
+			if _, err := rand.Read(buf); err != nil {
+				return "", fmt.Errorf("generate issue id: %w", err)
+			}
+			for i, b := range buf {
+				buf[i] = alphabet[int(b)%len(alphabet)]
+			}
+			id := prefix + "-" + string(buf)
+			var exists int
+			if err := tx.tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, id).Scan(&exists); err != nil {
+				return "", fmt.Errorf("check issue id: %w", err)
+			}
+			if exists == 0 {
+				return id, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no free issue id with prefix %q", prefix)
+}
+
+func (tx *brWriteTx) addDependency(ctx context.Context, fromID, toID, depType string) error {
+	for _, id := range []string{fromID, toID} {
+		if _, err := tx.issue(ctx, id); err != nil {
+			return err
+		}
+	}
+	var existing string
+	err := tx.tx.QueryRowContext(ctx, `SELECT type FROM dependencies WHERE issue_id = ? AND depends_on_id = ?`, fromID, toID).Scan(&existing)
+	switch {
+	case err == nil && existing == depType:
+		return nil
+	case err == nil:
+		// Changing a dependency's type is br's call.
+		return errBrUseCLI
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("query dependency: %w", err)
+	}
+
+	// Leave cycles for br to reject.
+	var cycle int
+	err = tx.tx.QueryRowContext(ctx, `
+		WITH RECURSIVE reach(id) AS (
+			SELECT ?
+			UNION
+			SELECT d.depends_on_id FROM dependencies d JOIN reach r ON d.issue_id = r.id
+		)
+		SELECT COUNT(*) FROM reach WHERE id = ?
+	`, toID, fromID).Scan(&cycle)
+	if err != nil {
+		return fmt.Errorf("check dependency cycle: %w", err)
+	}
+	if cycle > 0 {
+		return errBrUseCLI
+	}
+
+	_, err = tx.insert(ctx, "INSERT", "dependencies", map[string]any{
+		"issue_id":      fromID,
+		"depends_on_id": toID,
+		"type":          depType,
+		"created_at":    tx.now,
+		"created_by":    tx.actor,
+	})
+	if err != nil {
+		return err
+	}
+	tx.blockingChanged = true
+	if err := tx.markDirty(ctx, fromID); err != nil {
+		return err
+	}
+	return tx.event(ctx, fromID, "dependency_added", "", toID, "")
+}
+
+func (w *brSQLiteWriter) UpdateStatus(ctx context.Context, issueID, newStatus string) error {
+	viaCLI := func() error { return w.cli.UpdateStatus(ctx, issueID, newStatus) }
+	if strings.TrimSpace(issueID) == "" || !slices.Contains(brStatuses, newStatus) {
+		return viaCLI()
+	}
+	return w.apply(ctx, func(tx *brWriteTx) error {
+		cur, err := tx.issue(ctx, issueID)
+		if err != nil {
+			return err
+		}
+		var closedAt any
+		if newStatus == "closed" {
+			closedAt = tx.now
+		}
+		if err := tx.update(ctx, issueID, map[string]any{"status": newStatus, "closed_at": closedAt}); err != nil {
+			return err
+		}
+		tx.blockingChanged = true
+		return tx.changed(ctx, issueID, "status", cur.status, newStatus)
+	}, viaCLI)
+}
+
+func (w *brSQLiteWriter) Close(ctx context.Context, issueID string) error {
+	return w.closeIssue(ctx, issueID, "", func() error { return w.cli.Close(ctx, issueID) })
+}
+
+func (w *brSQLiteWriter) CloseWithReason(ctx context.Context, issueID, reason string) error {
+	return w.closeIssue(ctx, issueID, reason, func() error { return w.cli.CloseWithReason(ctx, issueID, reason) })
+}
+
+func (w *brSQLiteWriter) closeIssue(ctx context.Context, issueID, reason string, viaCLI func() error) error {
+	if strings.TrimSpace(issueID) == "" {
+		return viaCLI()
+	}
+	return w.apply(ctx, func(tx *brWriteTx) error {
+		cur, err := tx.issue(ctx, issueID)
+		if err != nil {
+			return err
+		}
+		if err := tx.update(ctx, issueID, map[string]any{"status": "closed", "closed_at": tx.now, "close_reason": reason}); err != nil {
+			return err
+		}
+		tx.blockingChanged = true
+		return tx.event(ctx, issueID, "closed", cur.status, "closed", reason)
+	}, viaCLI)
+}
+
+func (w *brSQLiteWriter) Reopen(ctx context.Context, issueID string) error {
+	viaCLI := func() error { return w.cli.Reopen(ctx, issueID) }
+	if strings.TrimSpace(issueID) == "" {
+		return viaCLI()
+	}
+	return w.apply(ctx, func(tx *brWriteTx) error {
+		cur, err := tx.issue(ctx, issueID)
+		if err != nil {
+			return err
+		}
+		if err := tx.update(ctx, issueID, map[string]any{"status": "open", "closed_at": nil, "close_reason": ""}); err != nil {
+			return err
+		}
+		tx.blockingChanged = true
+		return tx.event(ctx, issueID, "reopened", cur.status, "open", "")
+	}, viaCLI)
+}
+
+func (w *brSQLiteWriter) AddLabel(ctx context.Context, issueID, label string) error {
+	viaCLI := func() error { return w.cli.AddLabel(ctx, issueID, label) }
+	label = strings.TrimSpace(label)
+	if strings.TrimSpace(issueID) == "" || label == "" || strings.Contains(label, ",") {
+		return viaCLI()
+	}
+	return w.apply(ctx, func(tx *brWriteTx) error {
+		if _, err := tx.issue(ctx, issueID); err != nil {
+			return err
+		}
+		res, err := tx.insert(ctx, "INSERT OR IGNORE", "labels", map[string]any{"issue_id": issueID, "label": label})
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil
+		}
+		if err := tx.update(ctx, issueID, map[string]any{}); err != nil {
+			return err
+		}
+		return tx.event(ctx, issueID, "label_added", "", label, "")
+	}, viaCLI)
+}
+
+func (w *brSQLiteWriter) RemoveLabel(ctx context.Context, issueID, label string) error {
+	viaCLI := func() error { return w.cli.RemoveLabel(ctx, issueID, label) }
+	label = strings.TrimSpace(label)
+	if strings.TrimSpace(issueID) == "" || label == "" {
+		return viaCLI()
+	}
+	return w.apply(ctx, func(tx *brWriteTx) error {
+		if _, err := tx.issue(ctx, issueID); err != nil {
+			return err
+		}
+		res, err := tx.tx.ExecContext(ctx, `DELETE FROM labels WHERE issue_id = ? AND label = ?`, issueID, label)
+		if err != nil {
+			return fmt.Errorf("remove label: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil
+		}
+		if err := tx.update(ctx, issueID, map[string]any{}); err != nil {
+			return err
+		}
+		return tx.event(ctx, issueID, "label_removed", label, "", "")
+	}, viaCLI)
+}
+
+func (w *brSQLiteWriter) UpdatePriority(ctx context.Context, issueID string, priority int) error {
+	viaCLI := func() error { return w.cli.UpdatePriority(ctx, issueID, priority) }
+	if strings.TrimSpace(issueID) == "" || priority < 0 || priority > 4 {
+		return viaCLI()
+	}
+	return w.apply(ctx, func(tx *brWriteTx) error {
+		cur, err := tx.issue(ctx, issueID)
+		if err != nil {
+			return err
+		}
+		if err := tx.update(ctx, issueID, map[string]any{"priority": priority}); err != nil {
+			return err
+		}
+		return tx.changed(ctx, issueID, "priority", strconv.Itoa(cur.priority), strconv.Itoa(priority))
+	}, viaCLI)
+}
+
+func (w *brSQLiteWriter) UpdateAssignee(ctx context.Context, issueID, assignee string) error {
+	viaCLI := func() error { return w.cli.UpdateAssignee(ctx, issueID, assignee) }
+	if strings.TrimSpace(issueID) == "" {
+		return viaCLI()
+	}
+	assignee = strings.TrimSpace(assignee)
+	return w.apply(ctx, func(tx *brWriteTx) error {
+		cur, err := tx.issue(ctx, issueID)
+		if err != nil {
+			return err
+		}
+		if err := tx.update(ctx, issueID, map[string]any{"assignee": nullIfEmpty(assignee)}); err != nil {
+			return err
+		}
+		return tx.changed(ctx, issueID, "assignee", cur.assignee, assignee)
+	}, viaCLI)
+}
+
+// UpdateSchedule goes through br, which parses and normalizes the dates.
+func (w *brSQLiteWriter) UpdateSchedule(ctx context.Context, issueID, due, deferUntil string, estimateMinutes int) error {
+	return w.cli.UpdateSchedule(ctx, issueID, due, deferUntil, estimateMinutes)
+}
+
+func (w *brSQLiteWriter) UpdateExternalRef(ctx context.Context, issueID, ref string) error {
+	viaCLI := func() error { return w.cli.UpdateExternalRef(ctx, issueID, ref) }
+	if strings.TrimSpace(issueID) == "" {
+		return viaCLI()
+	}
+	ref = strings.TrimSpace(ref)
+	return w.apply(ctx, func(tx *brWriteTx) error {
+		if _, err := tx.issue(ctx, issueID); err != nil {
+			return err
+		}
+		return tx.update(ctx, issueID, map[string]any{"external_ref": nullIfEmpty(ref)})
+	}, viaCLI)
+}
+
+func (w *brSQLiteWriter) UpdateFull(ctx context.Context, issueID, title, issueType string, priority int, labels []string, assignee, description string) error {
+	viaCLI := func() error {
+		return w.cli.UpdateFull(ctx, issueID, title, issueType, priority, labels, assignee, description)
+	}
+	if strings.TrimSpace(issueID) == "" || strings.TrimSpace(title) == "" || priority < 0 || priority > 4 ||
+		(issueType != "" && !slices.Contains(brIssueTypes, issueType)) {
+		return viaCLI()
+	}
+	return w.apply(ctx, func(tx *brWriteTx) error {
+		cur, err := tx.issue(ctx, issueID)
+		if err != nil {
+			return err
+		}
+		values := map[string]any{
+			"title":       title,
+			"description": description,
+			"priority":    priority,
+			"assignee":    nullIfEmpty(assignee),
+		}
+		if issueType != "" {
+			values["issue_type"] = issueType
+		}
+		if err := tx.update(ctx, issueID, values); err != nil {
+			return err
+		}
+		if _, err := tx.tx.ExecContext(ctx, `DELETE FROM labels WHERE issue_id = ?`, issueID); err != nil {
+			return fmt.Errorf("replace labels: %w", err)
+		}
+		if err := tx.setLabels(ctx, issueID, normalizeBrLabels(labels)); err != nil {
+			return err
+		}
+		if err := tx.changed(ctx, issueID, "title", cur.title, title); err != nil {
+			return err
+		}
+		if err := tx.changed(ctx, issueID, "priority", strconv.Itoa(cur.priority), strconv.Itoa(priority)); err != nil {
+			return err
+		}
+		return tx.changed(ctx, issueID, "assignee", cur.assignee, assignee)
+	}, viaCLI)
+}
+
+func (w *brSQLiteWriter) Create(ctx context.Context, title, issueType string, priority int, labels []string, assignee string) (string, error) {
+	issue, err := w.create(ctx, title, issueType, priority, labels, assignee, "", "", func() (FullIssue, error) {
+		id, err := w.cli.Create(ctx, title, issueType, priority, labels, assignee)
+		return FullIssue{ID: id}, err
+	})
+	return issue.ID, err
+}
+
+func (w *brSQLiteWriter) CreateFull(ctx context.Context, title, issueType string, priority int, labels []string, assignee, description, parentID string) (FullIssue, error) {
+	return w.create(ctx, title, issueType, priority, labels, assignee, description, parentID, func() (FullIssue, error) {
+		return w.cli.CreateFull(ctx, title, issueType, priority, labels, assignee, description, parentID)
+	})
+}
+
+// create inserts the issue, its labels and its parent link in one
+// transaction, so a missing parent leaves nothing behind.
+func (w *brSQLiteWriter) create(ctx context.Context, title, issueType string, priority int, labels []string, assignee, description, parentID string, viaCLI func() (FullIssue, error)) (FullIssue, error) {
+	if strings.TrimSpace(issueType) == "" {
+		issueType = "task"
+	}
+	if strings.TrimSpace(title) == "" || !slices.Contains(brIssueTypes, issueType) || priority < 0 || priority > 4 {
+		return viaCLI()
+	}
+	assignee = strings.TrimSpace(assignee)
+	labels = normalizeBrLabels(labels)
+	var created, fromCLI FullIssue
+	err := w.apply(ctx, func(tx *brWriteTx) error {
+		prefix, err := tx.issuePrefix(ctx)
+		if err != nil {
+			return err
+		}
+		if prefix == "" {
+			return errBrUseCLI
+		}
+		id, err := tx.newIssueID(ctx, prefix)
+		if err != nil {
+			return err
+		}
+		_, err = tx.insert(ctx, "INSERT", "issues", map[string]any{
+			"id":                  id,
+			"title":               title,
+			"description":         description,
+			"design":              "",
+			"acceptance_criteria": "",
+			"notes":               "",
+			"status":              "open",
+			"priority":            priority,
+			"issue_type":          issueType,
+			"assignee":            nullIfEmpty(assignee),
+			"created_by":          tx.actor,
+			"created_at":          tx.now,
+			"updated_at":          tx.now,
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.setLabels(ctx, id, labels); err != nil {
+			return err
+		}
+		if err := tx.markDirty(ctx, id); err != nil {
+			return err
+		}
+		if err := tx.event(ctx, id, "created", "", "", ""); err != nil {
+			return err
+		}
+		if strings.TrimSpace(parentID) != "" {
+			if err := tx.addDependency(ctx, id, parentID, "parent-child"); err != nil {
+				return fmt.Errorf("add parent-child dependency: %w", err)
+			}
+		}
+		created = FullIssue{
+			ID:          id,
+			Title:       title,
+			Status:      "open",
+			IssueType:   issueType,
+			Priority:    priority,
+			Description: description,
+			Assignee:    assignee,
+			CreatedBy:   tx.actor,
+			CreatedAt:   tx.now,
+			UpdatedAt:   tx.now,
+			Labels:      labels,
+		}
+		return nil
+	}, func() error {
+		var err error
+		fromCLI, err = viaCLI()
+		return err
+	})
+	if err != nil {
+		return FullIssue{}, err
+	}
+	if created.ID == "" {
+		return fromCLI, nil
+	}
+	return created, nil
+}
+
+func (w *brSQLiteWriter) AddDependency(ctx context.Context, fromID, toID, depType string) error {
+	viaCLI := func() error { return w.cli.AddDependency(ctx, fromID, toID, depType) }
+	if strings.TrimSpace(fromID) == "" || strings.TrimSpace(toID) == "" || fromID == toID {
+		return viaCLI()
+	}
+	if strings.TrimSpace(depType) == "" {
+		depType = "blocks"
+	}
+	return w.apply(ctx, func(tx *brWriteTx) error {
+		return tx.addDependency(ctx, fromID, toID, depType)
+	}, viaCLI)
+}
+
+func (w *brSQLiteWriter) RemoveDependency(ctx context.Context, fromID, toID, depType string) error {
+	viaCLI := func() error { return w.cli.RemoveDependency(ctx, fromID, toID, depType) }
+	if strings.TrimSpace(fromID) == "" || strings.TrimSpace(toID) == "" {
+		return viaCLI()
+	}
+	query := `DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?`
+	args := []any{fromID, toID}
+	if depType = strings.TrimSpace(depType); depType != "" {
+		query += ` AND type = ?`
+		args = append(args, depType)
+	}
+	return w.apply(ctx, func(tx *brWriteTx) error {
+		res, err := tx.tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("remove dependency: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			if depType != "" {
+				// br removes a link of any type, so it must not see a
+				// link of another type.
+				var other string
+				err := tx.tx.QueryRowContext(ctx, `SELECT type FROM dependencies WHERE issue_id = ? AND depends_on_id = ?`, fromID, toID).Scan(&other)
+				if err == nil {
+					msg := fmt.Sprintf("%s has a %s dependency on %s, not %s", fromID, other, toID, depType)
+					return appErrors.New(appErrors.CodeNotFound, msg, ErrNotFound)
+				}
+				if !errors.Is(err, sql.ErrNoRows) {
+					return fmt.Errorf("load dependency: %w", err)
+				}
+			}
+			// Let br explain what is missing.
+			return errBrUseCLI
+		}
+		tx.blockingChanged = true
+		if err := tx.markDirty(ctx, fromID); err != nil {
+			return err
+		}
+		return tx.event(ctx, fromID, "dependency_removed", toID, "", "")
+	}, viaCLI)
+}
+
+// Delete goes through br, which also rewrites references to the issue.
+func (w *brSQLiteWriter) Delete(ctx context.Context, issueID string, cascade bool) error {
+	return w.cli.Delete(ctx, issueID, cascade)
+}
+
+func (w *brSQLiteWriter) AddComment(ctx context.Context, issueID, text string) error {
+	viaCLI := func() error { return w.cli.AddComment(ctx, issueID, text) }
+	if strings.TrimSpace(issueID) == "" || strings.TrimSpace(text) == "" {
+		return viaCLI()
+	}
+	return w.apply(ctx, func(tx *brWriteTx) error {
+		if _, err := tx.issue(ctx, issueID); err != nil {
+			return err
+		}
+		_, err := tx.insert(ctx, "INSERT", "comments", map[string]any{
+			"issue_id":   issueID,
+			"author":     tx.actor,
+			"text":       text,
+			"created_at": tx.now,
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.markDirty(ctx, issueID); err != nil {
+			return err
+		}
+		return tx.event(ctx, issueID, "commented", "", "", text)
+	}, viaCLI)
+}
+
+func (w *brSQLiteWriter) UpdateComment(ctx context.Context, issueID string, commentID int, text string) error {
+	return w.cli.UpdateComment(ctx, issueID, commentID, text)
+}
+
+func (w *brSQLiteWriter) DeleteComment(ctx context.Context, issueID string, commentID int) error {
+	return w.cli.DeleteComment(ctx, issueID, commentID)
+}
+
+// normalizeBrLabels trims labels and drops empty and repeated ones.
+func normalizeBrLabels(labels []string) []string {
+	out := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label = strings.TrimSpace(label); label != "" && !slices.Contains(out, label) {
+			out = append(out, label)
+		}
+	}
+	return out
+}
+
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,363 @@
+package beads
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	appErrors "abacus/internal/errors"
+)
+
+// brWriteTestSchema adds the tables direct writes need to the test br schema.
+const brWriteTestSchema = `
+	CREATE TABLE dirty_issues (
+		issue_id TEXT PRIMARY KEY,
+		marked_at TEXT NOT NULL
+	);
+	CREATE TABLE events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		issue_id TEXT NOT NULL,
+		event_type TEXT NOT NULL,
+		actor TEXT NOT NULL,
+		old_value TEXT,
+		new_value TEXT,
+		comment TEXT,
+		created_at TEXT NOT NULL
+	);
+	CREATE TABLE config (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	);
+	INSERT INTO config (key, value) VALUES ('issue_prefix', 'ab');
+`
+
+// newDirectWriteTestClient returns a br client with direct writes enabled
+// over a seeded database, and the log file its fake br CLI appends to.
+func newDirectWriteTestClient(t *testing.T, extraSchema string) (Client, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "args.log")
+	script := filepath.Join(dir, "fakebr.sh")
+	writeTestScript(t, script, "#!/bin/sh\necho \"$@\" >> "+logFile+"\nexit 0\n")
+
+	dbPath := testBrDB(t)
+	seedTestData(t, dbPath)
+	execTestSQL(t, dbPath, extraSchema)
+	client := NewBrSQLiteClient(dbPath, WithBrBinaryPath(script), WithBrAuthor("alice"), WithBrDirectWrites(true))
+	return client, dbPath, logFile
+}
+
+func execTestSQL(t *testing.T, dbPath, query string) {
+	t.Helper()
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	if _, err := db.Exec(query); err != nil {
+		t.Fatalf("exec test sql: %v", err)
+	}
+}
+
+func queryTestStrings(t *testing.T, dbPath, query string, args ...any) []string {
+	t.Helper()
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var out []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func cliCalls(t *testing.T, logFile string) string {
+	t.Helper()
+	data, err := os.ReadFile(logFile)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("read args log: %v", err)
+	}
+	return string(data)
+}
+
+func TestBrSQLiteWriter_WritesDirectly(t *testing.T) {
+	t.Parallel()
+	client, dbPath, logFile := newDirectWriteTestClient(t, brWriteTestSchema)
+	ctx := context.Background()
+
+	created, err := client.CreateFull(ctx, "New child", "bug", 1, []string{"ui", " ui ", ""}, "bob", "Details", "ab-001")
+	if err != nil {
+		t.Fatalf("CreateFull: %v", err)
+	}
+	if !strings.HasPrefix(created.ID, "ab-") || created.Status != "open" || created.CreatedBy != "alice" {
+		t.Fatalf("unexpected created issue %+v", created)
+	}
+	if err := client.UpdateStatus(ctx, "ab-001", "in_progress"); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if err := client.AddLabel(ctx, "ab-002", "backend"); err != nil {
+		t.Fatalf("AddLabel: %v", err)
+	}
+	if err := client.AddComment(ctx, "ab-002", "On it"); err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+	if err := client.CloseWithReason(ctx, "ab-003", "Done"); err != nil {
+		t.Fatalf("CloseWithReason: %v", err)
+	}
+
+	if calls := cliCalls(t, logFile); calls != "" {
+		t.Fatalf("expected no br invocations, got %q", calls)
+	}
+
+	issues, err := client.Show(ctx, []string{created.ID, "ab-002", "ab-003"})
+	if err != nil || len(issues) != 3 {
+		t.Fatalf("Show: %v (%d issues)", err, len(issues))
+	}
+	byID := make(map[string]FullIssue, len(issues))
+	for _, iss := range issues {
+		byID[iss.ID] = iss
+	}
+	child, second, third := byID[created.ID], byID["ab-002"], byID["ab-003"]
+	if child.Title != "New child" || child.Assignee != "bob" || !slices.Equal(child.Labels, []string{"ui"}) {
+		t.Errorf("unexpected child %+v", child)
+	}
+	if len(child.Dependencies) != 1 || child.Dependencies[0] != (Dependency{TargetID: "ab-001", Type: "parent-child"}) {
+		t.Errorf("expected parent link, got %+v", child.Dependencies)
+	}
+	if !slices.Contains(second.Labels, "backend") {
+		t.Errorf("unexpected ab-002 %+v", second)
+	}
+	if n := len(second.Comments); n == 0 || second.Comments[n-1].Author != "alice" || second.Comments[n-1].Text != "On it" {
+		t.Errorf("unexpected comments %+v", second.Comments)
+	}
+	if third.Status != "closed" || third.CloseReason != "Done" || third.ClosedAt == "" {
+		t.Errorf("unexpected ab-003 %+v", third)
+	}
+
+	dirty := queryTestStrings(t, dbPath, `SELECT issue_id FROM dirty_issues ORDER BY issue_id`)
+	for _, id := range []string{created.ID, "ab-001", "ab-002", "ab-003"} {
+		if !slices.Contains(dirty, id) {
+			t.Errorf("%s not marked dirty: %v", id, dirty)
+		}
+	}
+
+	history, err := client.History(ctx, "ab-001")
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(history) == 0 || history[0].Field != HistoryStatus || history[0].NewValue != "in_progress" || history[0].Actor != "alice" {
+		t.Errorf("expected status change in history, got %+v", history)
+	}
+}
+
+func TestBrSQLiteWriter_MissingIssue(t *testing.T) {
+	t.Parallel()
+	client, _, logFile := newDirectWriteTestClient(t, brWriteTestSchema)
+
+	err := client.UpdatePriority(context.Background(), "ab-missing", 1)
+	if !errors.Is(err, ErrNotFound) || !appErrors.IsCode(err, appErrors.CodeNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if calls := cliCalls(t, logFile); calls != "" {
+		t.Fatalf("expected no br invocations, got %q", calls)
+	}
+}
+
+func TestBrSQLiteWriter_CreateWithMissingParentLeavesNothing(t *testing.T) {
+	t.Parallel()
+	client, dbPath, _ := newDirectWriteTestClient(t, brWriteTestSchema)
+
+	before := queryTestStrings(t, dbPath, `SELECT id FROM issues`)
+	if _, err := client.CreateFull(context.Background(), "Orphan", "task", 2, nil, "", "", "ab-missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected missing parent error, got %v", err)
+	}
+	if after := queryTestStrings(t, dbPath, `SELECT id FROM issues`); len(after) != len(before) {
+		t.Fatalf("issue created despite missing parent: %v", after)
+	}
+}
+
+func TestBrSQLiteWriter_FallsBackToCLI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		schema string
+		write  func(Client) error
+		want   string
+	}{
+		{
+			name:   "no dirty tracking",
+			schema: `SELECT 1`,
+			write:  func(c Client) error { return c.UpdateStatus(context.Background(), "ab-001", "closed") },
+			want:   "update ab-001 --status=closed",
+		},
+		{
+			name:   "unknown mandatory column",
+			schema: brWriteTestSchema + `CREATE TABLE blocked_issues_cache (issue_id TEXT PRIMARY KEY, blocked_by TEXT NOT NULL);`,
+			write:  func(c Client) error { return c.UpdatePriority(context.Background(), "ab-001", 0) },
+			want:   "update ab-001 --priority=0",
+		},
+		{
+			name:   "value br must validate",
+			schema: brWriteTestSchema,
+			write:  func(c Client) error { return c.UpdatePriority(context.Background(), "ab-001", 9) },
+			want:   "update ab-001 --priority=9",
+		},
+		{
+			name:   "delete",
+			schema: brWriteTestSchema,
+			write:  func(c Client) error { return c.Delete(context.Background(), "ab-001", false) },
+			want:   "delete ab-001 --force",
+		},
+		{
+			name:   "dependency cycle",
+			schema: brWriteTestSchema,
+			write:  func(c Client) error { return c.AddDependency(context.Background(), "ab-001", "ab-002", "blocks") },
+			want:   "dep add ab-001 ab-002 --type blocks",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, _, logFile := newDirectWriteTestClient(t, tt.schema)
+			if err := tt.write(client); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if calls := cliCalls(t, logFile); !strings.Contains(calls, tt.want) {
+				t.Fatalf("expected br call %q, got %q", tt.want, calls)
+			}
+		})
+	}
+}
+
+func TestBrSQLiteWriter_RebuildsBlockedCache(t *testing.T) {
+	t.Parallel()
+	client, dbPath, _ := newDirectWriteTestClient(t, brWriteTestSchema+`CREATE TABLE blocked_issues_cache (issue_id TEXT PRIMARY KEY);`)
+	ctx := context.Background()
+
+	// Seed data: ab-002 is blocked by ab-001, and closed ab-003 is a child of ab-001.
+	blockerID, err := client.Create(ctx, "Blocker", "task", 2, nil, "")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	child, err := client.CreateFull(ctx, "Child", "task", 2, nil, "", "", "ab-002")
+	if err != nil {
+		t.Fatalf("CreateFull: %v", err)
+	}
+	if err := client.AddDependency(ctx, "ab-001", blockerID, ""); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+	assertBlocked := func(want ...string) {
+		t.Helper()
+		got := queryTestStrings(t, dbPath, `SELECT issue_id FROM blocked_issues_cache`)
+		slices.Sort(got)
+		slices.Sort(want)
+		if !slices.Equal(got, want) {
+			t.Fatalf("blocked cache = %v, want %v", got, want)
+		}
+	}
+	assertBlocked("ab-001", "ab-002", child.ID)
+
+	if err := client.Close(ctx, blockerID); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	assertBlocked("ab-002", child.ID)
+}
+
+func TestBrSQLiteWriter_RemoveDependencyMatchesType(t *testing.T) {
+	t.Parallel()
+	client, dbPath, logFile := newDirectWriteTestClient(t, brWriteTestSchema)
+	ctx := context.Background()
+
+	// ab-003 is seeded as a child of ab-001.
+	err := client.RemoveDependency(ctx, "ab-003", "ab-001", "blocks")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a type mismatch to be not found, got %v", err)
+	}
+	if got := queryTestStrings(t, dbPath, `SELECT type FROM dependencies WHERE issue_id = 'ab-003' AND depends_on_id = 'ab-001'`); !slices.Equal(got, []string{"parent-child"}) {
+		t.Fatalf("expected the parent link to remain, got %v", got)
+	}
+
+	if err := client.RemoveDependency(ctx, "ab-003", "ab-001", "parent-child"); err != nil {
+		t.Fatalf("RemoveDependency: %v", err)
+	}
+	if got := queryTestStrings(t, dbPath, `SELECT type FROM dependencies WHERE issue_id = 'ab-003' AND depends_on_id = 'ab-001'`); len(got) != 0 {
+		t.Fatalf("expected the link to be removed, got %v", got)
+	}
+	if calls := cliCalls(t, logFile); calls != "" {
+		t.Fatalf("expected no br invocations, got %q", calls)
+	}
+}
+
+func TestBrSQLiteWriter_RedetectsSchemaAfterChange(t *testing.T) {
+	t.Parallel()
+	client, _, logFile := newDirectWriteTestClient(t, brWriteTestSchema)
+	ctx := context.Background()
+
+	if err := client.UpdatePriority(ctx, "ab-001", 1); err != nil {
+		t.Fatalf("UpdatePriority: %v", err)
+	}
+	writer := client.(*brSQLiteClient).writer.(*brSQLiteWriter)
+	writer.mu.Lock()
+	db := writer.db
+	writer.mu.Unlock()
+	if db == nil {
+		t.Fatal("expected the write handle to be kept")
+	}
+
+	// br drops dirty tracking: the cached schema no longer fits, so the
+	// write is detected again and handed to br.
+	if _, err := db.ExecContext(ctx, `DROP TABLE dirty_issues`); err != nil {
+		t.Fatalf("drop table: %v", err)
+	}
+	if err := client.UpdatePriority(ctx, "ab-001", 0); err != nil {
+		t.Fatalf("UpdatePriority after schema change: %v", err)
+	}
+	if calls := cliCalls(t, logFile); !strings.Contains(calls, "update ab-001 --priority=0") {
+		t.Fatalf("expected the write to go through br, got %q", calls)
+	}
+
+	if err := ShutdownClient(client); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+	if writer.db != nil || writer.schema != nil {
+		t.Error("expected Shutdown to close the write handle")
+	}
+}
+
+func TestBuildSQLiteWriteDSN(t *testing.T) {
+	t.Parallel()
+	dsn := buildSQLiteWriteDSN("/tmp/project#1/.beads/beads.db")
+	if !strings.HasPrefix(dsn, "file:/tmp/project%231/.beads/beads.db?") {
+		t.Fatalf("unexpected DSN: %q", dsn)
+	}
+	for _, want := range []string{"mode=rw", "_txlock=immediate", "_pragma=busy_timeout%285000%29"} {
+		if !strings.Contains(dsn, want) {
+			t.Errorf("DSN missing %s: %q", want, dsn)
+		}
+	}
+}
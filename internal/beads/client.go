@@ -10,6 +10,7 @@ type Reader interface {
 	Show(ctx context.Context, ids []string) ([]FullIssue, error)
 	Export(ctx context.Context) ([]FullIssue, error)
 	Comments(ctx context.Context, issueID string) ([]Comment, error)
+	History(ctx context.Context, issueID string) ([]HistoryEvent, error)
 }
 
 // Writer handles all mutation operations.
@@ -18,10 +19,14 @@ type Reader interface {
 type Writer interface {
 	UpdateStatus(ctx context.Context, issueID, newStatus string) error
 	Close(ctx context.Context, issueID string) error
+	CloseWithReason(ctx context.Context, issueID, reason string) error
 	Reopen(ctx context.Context, issueID string) error
 	AddLabel(ctx context.Context, issueID, label string) error
 	RemoveLabel(ctx context.Context, issueID, label string) error
 	UpdatePriority(ctx context.Context, issueID string, priority int) error
+	UpdateAssignee(ctx context.Context, issueID, assignee string) error
+	UpdateSchedule(ctx context.Context, issueID, due, deferUntil string, estimateMinutes int) error
+	UpdateExternalRef(ctx context.Context, issueID, ref string) error
 	UpdateFull(ctx context.Context, issueID, title, issueType string, priority int, labels []string, assignee, description string) error
 	Create(ctx context.Context, title, issueType string, priority int, labels []string, assignee string) (string, error)
 	CreateFull(ctx context.Context, title, issueType string, priority int, labels []string, assignee, description, parentID string) (FullIssue, error)
@@ -29,6 +34,8 @@ type Writer interface {
 	RemoveDependency(ctx context.Context, fromID, toID, depType string) error
 	Delete(ctx context.Context, issueID string, cascade bool) error
 	AddComment(ctx context.Context, issueID, text string) error
+	UpdateComment(ctx context.Context, issueID string, commentID int, text string) error
+	DeleteComment(ctx context.Context, issueID string, commentID int) error
 }
 
 // Client combines Reader and Writer for full functionality.
@@ -38,3 +45,23 @@ type Client interface {
 	Reader
 	Writer
 }
+
+// ShutdownClient releases what c holds open between calls, such as the br
+// SQLite client's read connections or a plugin process. Clients that hold
+// nothing are left alone.
+func ShutdownClient(c Client) error {
+	if s, ok := c.(interface{ Shutdown() error }); ok {
+		return s.Shutdown()
+	}
+	return nil
+}
+
+// CanEditComments reports whether c can edit and delete existing comments.
+// Clients that know they cannot say so with a CanEditComments method; others,
+// such as plugins, are assumed to and report ErrNotSupported if they cannot.
+func CanEditComments(c Client) bool {
+	if e, ok := c.(interface{ CanEditComments() bool }); ok {
+		return e.CanEditComments()
+	}
+	return true
+}
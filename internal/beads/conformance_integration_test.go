@@ -0,0 +1,27 @@
+//go:build integration
+
+package beads_test
+
+import (
+	"testing"
+
+	"abacus/beadstest"
+	"abacus/internal/beads"
+)
+
+// TestConformance_Binaries runs the conformance suite against the real bd
+// and br binaries, each on a freshly initialized database.
+//
+// Run with: go test -tags=integration -run Conformance_Binaries -v ./internal/beads/
+func TestConformance_Binaries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping conformance test in short mode")
+	}
+	for _, backend := range []string{"br", "bd"} {
+		t.Run(backend, func(t *testing.T) {
+			beadstest.RunConformance(t, func(t *testing.T) beads.Client {
+				return beads.NewBackendTestClient(t, backend)
+			})
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package beads
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os/exec"
+	"strings"
 
 	appErrors "abacus/internal/errors"
 )
@@ -11,6 +13,8 @@ import (
 var (
 	// ErrNotFound indicates the CLI could not find the requested issue.
 	ErrNotFound = errors.New("beads: issue not found")
+	// ErrNotSupported indicates the active backend cannot perform the operation.
+	ErrNotSupported = errors.New("beads: operation not supported by backend")
 )
 
 // CLIError wraps errors coming from invoking a beads CLI (bd or br).
@@ -43,5 +47,64 @@ func classifyCLIError(binary string, cmd []string, err error, output string) err
 	if errors.Is(err, ErrNotFound) {
 		return appErrors.New(appErrors.CodeNotFound, "issue not found", err)
 	}
-	return appErrors.New(appErrors.CodeCLIFailed, CLIError{Binary: binary, Command: cmd, Output: output, Err: err}.Error(), err)
+	msg := CLIError{Binary: binary, Command: cmd, Output: output, Err: err}.Error()
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || isTimeoutMessage(output):
+		return appErrors.New(appErrors.CodeTimeout, msg, err)
+	case isBusyMessage(output):
+		return appErrors.New(appErrors.CodeBusy, msg, err)
+	}
+	return appErrors.New(appErrors.CodeCLIFailed, msg, err)
+}
+
+// busyMarkers are lower-case fragments of SQLite and beads CLI messages
+// reporting that another process holds the database.
+var busyMarkers = []string{
+	"database is locked",
+	"database table is locked",
+	"database is busy",
+	"sqlite_busy",
+	"sqlite_locked",
+	"lock contention",
+	"could not acquire lock",
+	"resource temporarily unavailable",
+}
+
+func isBusyMessage(s string) bool {
+	s = strings.ToLower(s)
+	for _, marker := range busyMarkers {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func isTimeoutMessage(s string) bool {
+	s = strings.ToLower(s)
+	return strings.Contains(s, "timed out") || strings.Contains(s, "timeout expired")
+}
+
+// IsRetryable reports whether a write failed for a reason that may clear up
+// on its own: the database was locked by another writer, the command timed
+// out, or the CLI binary was briefly unavailable. Errors from backends that
+// do not classify their failures (direct SQLite writes, plugins) are matched
+// on the SQLite busy and lock messages.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch appErrors.CodeOf(err) {
+	case appErrors.CodeBusy, appErrors.CodeTimeout, appErrors.CodeCLINotFound:
+		return true
+	case appErrors.CodeUnknown:
+		return errors.Is(err, context.DeadlineExceeded) || isBusyMessage(err.Error())
+	}
+	return false
+}
+
+// IsTimeout reports whether err is a timeout. A timed-out write may still
+// have been applied, so only idempotent writes should be retried after one.
+func IsTimeout(err error) bool {
+	return appErrors.IsCode(err, appErrors.CodeTimeout) || errors.Is(err, context.DeadlineExceeded)
 }
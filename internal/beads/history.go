@@ -0,0 +1,302 @@
+package beads
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"abacus/internal/gitinfo"
+)
+
+// History fields. Labels and dependencies record one entry per added or
+// removed value: an empty OldValue means added, an empty NewValue removed.
+const (
+	HistoryCreated      = "created"
+	HistoryStatus       = "status"
+	HistoryPriority     = "priority"
+	HistoryAssignee     = "assignee"
+	HistoryTitle        = "title"
+	HistoryLabels       = "labels"
+	HistoryDependencies = "dependencies"
+)
+
+// historyJSONLName is the JSONL export bd and br keep next to the database.
+const historyJSONLName = "issues.jsonl"
+
+// loadHistory returns an issue's changes, oldest first. The events table is
+// used when it has rows for the issue; otherwise history is rebuilt from the
+// git log of the JSONL export next to the database, which only sees changes
+// that were committed and attributes them to the committer.
+func loadHistory(ctx context.Context, db *sql.DB, dbPath, issueID string) ([]HistoryEvent, error) {
+	events, err := loadEventHistory(ctx, db, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) > 0 {
+		return events, nil
+	}
+	events, err = jsonlHistory(ctx, filepath.Join(filepath.Dir(dbPath), historyJSONLName), issueID)
+	if err != nil {
+		return nil, err
+	}
+	if events == nil {
+		events = []HistoryEvent{}
+	}
+	return events, nil
+}
+
+type rawEvent struct {
+	eventType, actor, oldValue, newValue, comment, createdAt string
+}
+
+func loadEventHistory(ctx context.Context, db *sql.DB, issueID string) ([]HistoryEvent, error) {
+	var exists int
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'events'`).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("query events table: %w", err)
+	}
+	if exists == 0 {
+		return nil, nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT event_type, COALESCE(actor, ''), COALESCE(old_value, ''), COALESCE(new_value, ''),
+		       COALESCE(comment, ''), COALESCE(created_at, '')
+		FROM events
+		WHERE issue_id = ?
+		ORDER BY created_at, id
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("query events: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var events []HistoryEvent
+	for rows.Next() {
+		var ev rawEvent
+		if err := rows.Scan(&ev.eventType, &ev.actor, &ev.oldValue, &ev.newValue, &ev.comment, &ev.createdAt); err != nil {
+			return nil, fmt.Errorf("scan event: %w", err)
+		}
+		events = append(events, convertEvent(issueID, ev)...)
+	}
+	return events, rows.Err()
+}
+
+// convertEvent maps an events row onto history entries. Field updates are
+// recorded either with plain old/new values or, as bd does, with the old
+// issue and the applied updates as JSON objects; the latter are expanded to
+// one entry per changed field. Comment events are left to the comments
+// section.
+func convertEvent(issueID string, ev rawEvent) []HistoryEvent {
+	base := HistoryEvent{IssueID: issueID, Actor: ev.actor, CreatedAt: ev.createdAt}
+	with := func(field, oldValue, newValue, comment string) HistoryEvent {
+		e := base
+		e.Field, e.OldValue, e.NewValue, e.Comment = field, oldValue, newValue, comment
+		return e
+	}
+
+	switch ev.eventType {
+	case "commented", "comment_added":
+		return nil
+	case "created":
+		return []HistoryEvent{with(HistoryCreated, "", "", "")}
+	case "closed":
+		return []HistoryEvent{with(HistoryStatus, ev.oldValue, "closed", ev.comment)}
+	case "reopened":
+		return []HistoryEvent{with(HistoryStatus, ev.oldValue, firstNonEmpty(ev.newValue, "open"), ev.comment)}
+	case "label_added":
+		return []HistoryEvent{with(HistoryLabels, "", eventSubject(ev), "")}
+	case "label_removed":
+		return []HistoryEvent{with(HistoryLabels, eventSubject(ev), "", "")}
+	case "dependency_added":
+		return []HistoryEvent{with(HistoryDependencies, "", eventSubject(ev), "")}
+	case "dependency_removed":
+		return []HistoryEvent{with(HistoryDependencies, eventSubject(ev), "", "")}
+	}
+
+	if changes := jsonFieldChanges(ev.oldValue, ev.newValue); changes != nil {
+		events := make([]HistoryEvent, 0, len(changes))
+		for _, c := range changes {
+			events = append(events, with(c[0], c[1], c[2], ev.comment))
+		}
+		return events
+	}
+
+	// "status_changed", "priority_changed", ... name their field.
+	return []HistoryEvent{with(strings.TrimSuffix(ev.eventType, "_changed"), ev.oldValue, ev.newValue, ev.comment)}
+}
+
+// historyTrackedFields are the issue fields expanded from JSON update events.
+var historyTrackedFields = []string{HistoryStatus, HistoryPriority, HistoryAssignee, HistoryTitle}
+
+// jsonFieldChanges diffs the tracked fields of an old issue object against an
+// updates object, returning [field, old, new] triples, or nil when either
+// value is not a JSON object.
+func jsonFieldChanges(oldValue, newValue string) [][3]string {
+	var oldObj, newObj map[string]any
+	if json.Unmarshal([]byte(oldValue), &oldObj) != nil || json.Unmarshal([]byte(newValue), &newObj) != nil ||
+		oldObj == nil || newObj == nil {
+		return nil
+	}
+	changes := [][3]string{}
+	for _, field := range historyTrackedFields {
+		v, ok := newObj[field]
+		if !ok {
+			continue
+		}
+		oldText, newText := jsonText(oldObj[field]), jsonText(v)
+		if oldText != newText {
+			changes = append(changes, [3]string{field, oldText, newText})
+		}
+	}
+	return changes
+}
+
+func jsonText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// eventSubject returns the label or dependency an event is about: its value
+// column, or the text after the colon in comments such as "Added label: bug".
+func eventSubject(ev rawEvent) string {
+	if v := firstNonEmpty(ev.newValue, ev.oldValue); v != "" {
+		return v
+	}
+	if _, after, ok := strings.Cut(ev.comment, ": "); ok {
+		return strings.TrimSpace(after)
+	}
+	return ev.comment
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// jsonlSnapshot is the part of an exported issue line history compares.
+type jsonlSnapshot struct {
+	Title        string   `json:"title"`
+	Status       string   `json:"status"`
+	Priority     *int     `json:"priority"`
+	Assignee     string   `json:"assignee"`
+	Labels       []string `json:"labels"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+	Dependencies []struct {
+		DependsOnID string `json:"depends_on_id"`
+		Type        string `json:"type"`
+	} `json:"dependencies"`
+}
+
+func (s jsonlSnapshot) priority() string {
+	if s.Priority == nil {
+		return ""
+	}
+	return strconv.Itoa(*s.Priority)
+}
+
+func (s jsonlSnapshot) dependencies() []string {
+	deps := make([]string, 0, len(s.Dependencies))
+	for _, d := range s.Dependencies {
+		deps = append(deps, d.Type+" "+d.DependsOnID)
+	}
+	return deps
+}
+
+// jsonlHistory rebuilds an issue's history by diffing successive committed
+// versions of its line in the JSONL export. Missing exports and exports
+// outside a git repository have no history.
+func jsonlHistory(ctx context.Context, path, issueID string) ([]HistoryEvent, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil
+	}
+	repo, err := gitinfo.Open(ctx, filepath.Dir(path))
+	if errors.Is(err, gitinfo.ErrNotRepository) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := repo.LineHistory(ctx, path, `"id":"`+issueID+`"`)
+	if err != nil {
+		return nil, fmt.Errorf("read %s history: %w", historyJSONLName, err)
+	}
+
+	var events []HistoryEvent
+	var prev *jsonlSnapshot
+	for _, rev := range revisions {
+		var snap jsonlSnapshot
+		if err := json.Unmarshal([]byte(rev.Line), &snap); err != nil {
+			continue
+		}
+		base := HistoryEvent{
+			IssueID:   issueID,
+			Actor:     rev.Author,
+			CreatedAt: firstNonEmpty(snap.UpdatedAt, rev.Date.UTC().Format(time.RFC3339)),
+		}
+		add := func(field, oldValue, newValue string) {
+			e := base
+			e.Field, e.OldValue, e.NewValue = field, oldValue, newValue
+			events = append(events, e)
+		}
+		if prev == nil {
+			base.CreatedAt = firstNonEmpty(snap.CreatedAt, base.CreatedAt)
+			add(HistoryCreated, "", "")
+			prev = &snap
+			continue
+		}
+		for _, c := range [][3]string{
+			{HistoryStatus, prev.Status, snap.Status},
+			{HistoryPriority, prev.priority(), snap.priority()},
+			{HistoryAssignee, prev.Assignee, snap.Assignee},
+			{HistoryTitle, prev.Title, snap.Title},
+		} {
+			if c[1] != c[2] {
+				add(c[0], c[1], c[2])
+			}
+		}
+		diffSets(prev.Labels, snap.Labels, func(v string) { add(HistoryLabels, "", v) }, func(v string) { add(HistoryLabels, v, "") })
+		diffSets(prev.dependencies(), snap.dependencies(),
+			func(v string) { add(HistoryDependencies, "", v) }, func(v string) { add(HistoryDependencies, v, "") })
+		prev = &snap
+	}
+	return events, nil
+}
+
+// diffSets calls added for values only in next and removed for values only
+// in prev.
+func diffSets(prev, next []string, added, removed func(string)) {
+	for _, v := range next {
+		if !slices.Contains(prev, v) {
+			added(v)
+		}
+	}
+	for _, v := range prev {
+		if !slices.Contains(next, v) {
+			removed(v)
+		}
+	}
+}
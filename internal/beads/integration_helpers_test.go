@@ -80,6 +80,19 @@ func newClientForBackend(t *testing.T, env backendTestEnv) Client {
 	}
 }
 
+// NewBackendTestClient returns a client for the given backend binary on a
+// freshly initialized database, skipping the test when the binary is not
+// installed. It is exported for the conformance test in package beads_test.
+func NewBackendTestClient(t *testing.T, backend string) Client {
+	t.Helper()
+	skipIfNoBackend(t, backend)
+	client := newClientForBackend(t, setupBackendTestDB(t, backend))
+	t.Cleanup(func() {
+		_ = ShutdownClient(client)
+	})
+	return client
+}
+
 // extractCreatedID extracts the issue ID from create command output.
 // Expected formats include:
 // - br: "Created test-xxx: Title"
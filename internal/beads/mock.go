@@ -11,60 +11,89 @@ var ErrMockNotImplemented = errors.New("beads.MockClient: method not implemented
 
 // MockClient is a test double for the Beads client interface.
 type MockClient struct {
-	ListFn             func(context.Context) ([]LiteIssue, error)
-	ShowFn             func(context.Context, []string) ([]FullIssue, error)
-	ExportFn           func(context.Context) ([]FullIssue, error)
-	CommentsFn         func(context.Context, string) ([]Comment, error)
-	UpdateStatusFn     func(context.Context, string, string) error
-	UpdatePriorityFn   func(context.Context, string, int) error
-	CloseFn            func(context.Context, string) error
-	ReopenFn           func(context.Context, string) error
-	AddLabelFn         func(context.Context, string, string) error
-	RemoveLabelFn      func(context.Context, string, string) error
-	UpdateFullFn       func(context.Context, string, string, string, int, []string, string, string) error
-	CreateFn           func(context.Context, string, string, int, []string, string) (string, error)
-	CreateFullFn       func(context.Context, string, string, int, []string, string, string, string) (FullIssue, error)
-	AddDependencyFn    func(context.Context, string, string, string) error
-	RemoveDependencyFn func(context.Context, string, string, string) error
-	DeleteFn           func(context.Context, string, bool) error
-	AddCommentFn       func(context.Context, string, string) error
-
-	mu                        sync.Mutex
-	ListCallCount             int
-	ShowCallCount             int
-	ExportCallCount           int
-	CommentsCallCount         int
-	UpdateStatusCallCount     int
-	UpdatePriorityCallCount   int
-	CloseCallCount            int
-	ReopenCallCount           int
-	AddLabelCallCount         int
-	RemoveLabelCallCount      int
-	UpdateFullCallCount       int
-	CreateCallCount           int
-	CreateFullCallCount       int
-	AddDependencyCallCount    int
-	RemoveDependencyCallCount int
-	ShowCallArgs              [][]string
-	CommentIDs                []string
-	UpdateStatusCallArgs      [][]string // [issueID, newStatus]
-	UpdatePriorityCallArgs    []UpdatePriorityCallArg
-	CloseCallArgs             []string
-	ReopenCallArgs            []string
-	AddLabelCallArgs          [][]string // [issueID, label]
-	RemoveLabelCallArgs       [][]string // [issueID, label]
-	UpdateFullCallArgs        []UpdateFullCallArg
-	CreateCallArgs            []CreateCallArg
-	CreateFullCallArgs        []CreateFullCallArg
-	AddDependencyCallArgs     [][]string // [fromID, toID, depType]
-	RemoveDependencyCallArgs  [][]string // [fromID, toID, depType]
-	DeleteCallCount           int
-	DeleteCallArgs            []struct {
+	ListFn              func(context.Context) ([]LiteIssue, error)
+	ShowFn              func(context.Context, []string) ([]FullIssue, error)
+	ExportFn            func(context.Context) ([]FullIssue, error)
+	CommentsFn          func(context.Context, string) ([]Comment, error)
+	HistoryFn           func(context.Context, string) ([]HistoryEvent, error)
+	UpdateStatusFn      func(context.Context, string, string) error
+	UpdatePriorityFn    func(context.Context, string, int) error
+	UpdateAssigneeFn    func(context.Context, string, string) error
+	UpdateScheduleFn    func(context.Context, string, string, string, int) error
+	UpdateExternalRefFn func(context.Context, string, string) error
+	CloseFn             func(context.Context, string) error
+	CloseWithReasonFn   func(context.Context, string, string) error
+	ReopenFn            func(context.Context, string) error
+	AddLabelFn          func(context.Context, string, string) error
+	RemoveLabelFn       func(context.Context, string, string) error
+	UpdateFullFn        func(context.Context, string, string, string, int, []string, string, string) error
+	CreateFn            func(context.Context, string, string, int, []string, string) (string, error)
+	CreateFullFn        func(context.Context, string, string, int, []string, string, string, string) (FullIssue, error)
+	AddDependencyFn     func(context.Context, string, string, string) error
+	RemoveDependencyFn  func(context.Context, string, string, string) error
+	DeleteFn            func(context.Context, string, bool) error
+	AddCommentFn        func(context.Context, string, string) error
+	UpdateCommentFn     func(context.Context, string, int, string) error
+	DeleteCommentFn     func(context.Context, string, int) error
+
+	mu                         sync.Mutex
+	ListCallCount              int
+	ShowCallCount              int
+	ExportCallCount            int
+	CommentsCallCount          int
+	HistoryCallCount           int
+	UpdateStatusCallCount      int
+	UpdatePriorityCallCount    int
+	UpdateAssigneeCallCount    int
+	UpdateScheduleCallCount    int
+	UpdateExternalRefCallCount int
+	CloseCallCount             int
+	CloseWithReasonCallCount   int
+	ReopenCallCount            int
+	AddLabelCallCount          int
+	RemoveLabelCallCount       int
+	UpdateFullCallCount        int
+	CreateCallCount            int
+	CreateFullCallCount        int
+	AddDependencyCallCount     int
+	RemoveDependencyCallCount  int
+	ShowCallArgs               [][]string
+	CommentIDs                 []string
+	UpdateStatusCallArgs       [][]string // [issueID, newStatus]
+	UpdatePriorityCallArgs     []UpdatePriorityCallArg
+	UpdateAssigneeCallArgs     [][]string // [issueID, assignee]
+	UpdateScheduleCallArgs     []UpdateScheduleCallArg
+	UpdateExternalRefCallArgs  [][]string // [issueID, ref]
+	CloseCallArgs              []string
+	CloseWithReasonCallArgs    [][]string // [issueID, reason]
+	ReopenCallArgs             []string
+	AddLabelCallArgs           [][]string // [issueID, label]
+	RemoveLabelCallArgs        [][]string // [issueID, label]
+	UpdateFullCallArgs         []UpdateFullCallArg
+	CreateCallArgs             []CreateCallArg
+	CreateFullCallArgs         []CreateFullCallArg
+	AddDependencyCallArgs      [][]string // [fromID, toID, depType]
+	RemoveDependencyCallArgs   [][]string // [fromID, toID, depType]
+	DeleteCallCount            int
+	DeleteCallArgs             []struct {
 		IssueID string
 		Cascade bool
 	}
 	AddCommentCallCount int
 	AddCommentCallArgs  [][]string // [issueID, text]
+
+	UpdateCommentCallCount int
+	UpdateCommentCallArgs  []CommentCallArg
+	DeleteCommentCallCount int
+	DeleteCommentCallArgs  []CommentCallArg
+}
+
+// CommentCallArg captures arguments passed to UpdateComment and DeleteComment.
+// Text is empty for deletes.
+type CommentCallArg struct {
+	IssueID   string
+	CommentID int
+	Text      string
 }
 
 // CreateCallArg captures arguments passed to Create.
@@ -93,6 +122,14 @@ type UpdatePriorityCallArg struct {
 	Priority int
 }
 
+// UpdateScheduleCallArg captures arguments passed to UpdateSchedule.
+type UpdateScheduleCallArg struct {
+	IssueID         string
+	Due             string
+	DeferUntil      string
+	EstimateMinutes int
+}
+
 // UpdateFullCallArg captures arguments passed to UpdateFull.
 type UpdateFullCallArg struct {
 	IssueID     string
@@ -159,6 +196,18 @@ func (m *MockClient) Comments(ctx context.Context, issueID string) ([]Comment, e
 	return m.CommentsFn(ctx, issueID)
 }
 
+// History invokes the configured stub or returns ErrMockNotImplemented.
+func (m *MockClient) History(ctx context.Context, issueID string) ([]HistoryEvent, error) {
+	m.mu.Lock()
+	m.HistoryCallCount++
+	m.mu.Unlock()
+
+	if m.HistoryFn == nil {
+		return nil, ErrMockNotImplemented
+	}
+	return m.HistoryFn(ctx, issueID)
+}
+
 // UpdateStatus invokes the configured stub or returns nil (no-op by default).
 func (m *MockClient) UpdateStatus(ctx context.Context, issueID, newStatus string) error {
 	m.mu.Lock()
@@ -188,6 +237,50 @@ func (m *MockClient) UpdatePriority(ctx context.Context, issueID string, priorit
 	return m.UpdatePriorityFn(ctx, issueID, priority)
 }
 
+// UpdateAssignee invokes the configured stub or returns nil (no-op by default).
+func (m *MockClient) UpdateAssignee(ctx context.Context, issueID, assignee string) error {
+	m.mu.Lock()
+	m.UpdateAssigneeCallCount++
+	m.UpdateAssigneeCallArgs = append(m.UpdateAssigneeCallArgs, []string{issueID, assignee})
+	m.mu.Unlock()
+
+	if m.UpdateAssigneeFn == nil {
+		return nil // Default to no-op for tests
+	}
+	return m.UpdateAssigneeFn(ctx, issueID, assignee)
+}
+
+// UpdateSchedule invokes the configured stub or returns nil (no-op by default).
+func (m *MockClient) UpdateSchedule(ctx context.Context, issueID, due, deferUntil string, estimateMinutes int) error {
+	m.mu.Lock()
+	m.UpdateScheduleCallCount++
+	m.UpdateScheduleCallArgs = append(m.UpdateScheduleCallArgs, UpdateScheduleCallArg{
+		IssueID:         issueID,
+		Due:             due,
+		DeferUntil:      deferUntil,
+		EstimateMinutes: estimateMinutes,
+	})
+	m.mu.Unlock()
+
+	if m.UpdateScheduleFn == nil {
+		return nil // Default to no-op for tests
+	}
+	return m.UpdateScheduleFn(ctx, issueID, due, deferUntil, estimateMinutes)
+}
+
+// UpdateExternalRef invokes the configured stub or returns nil (no-op by default).
+func (m *MockClient) UpdateExternalRef(ctx context.Context, issueID, ref string) error {
+	m.mu.Lock()
+	m.UpdateExternalRefCallCount++
+	m.UpdateExternalRefCallArgs = append(m.UpdateExternalRefCallArgs, []string{issueID, ref})
+	m.mu.Unlock()
+
+	if m.UpdateExternalRefFn == nil {
+		return nil // Default to no-op for tests
+	}
+	return m.UpdateExternalRefFn(ctx, issueID, ref)
+}
+
 // Close invokes the configured stub or returns nil (no-op by default).
 func (m *MockClient) Close(ctx context.Context, issueID string) error {
 	m.mu.Lock()
@@ -201,6 +294,19 @@ func (m *MockClient) Close(ctx context.Context, issueID string) error {
 	return m.CloseFn(ctx, issueID)
 }
 
+// CloseWithReason invokes the configured stub or returns nil (no-op by default).
+func (m *MockClient) CloseWithReason(ctx context.Context, issueID, reason string) error {
+	m.mu.Lock()
+	m.CloseWithReasonCallCount++
+	m.CloseWithReasonCallArgs = append(m.CloseWithReasonCallArgs, []string{issueID, reason})
+	m.mu.Unlock()
+
+	if m.CloseWithReasonFn == nil {
+		return nil // Default to no-op for tests
+	}
+	return m.CloseWithReasonFn(ctx, issueID, reason)
+}
+
 // Reopen invokes the configured stub or returns nil (no-op by default).
 func (m *MockClient) Reopen(ctx context.Context, issueID string) error {
 	m.mu.Lock()
@@ -365,3 +471,29 @@ func (m *MockClient) AddComment(ctx context.Context, issueID, text string) error
 	}
 	return m.AddCommentFn(ctx, issueID, text)
 }
+
+// UpdateComment invokes the configured stub or returns nil (no-op by default).
+func (m *MockClient) UpdateComment(ctx context.Context, issueID string, commentID int, text string) error {
+	m.mu.Lock()
+	m.UpdateCommentCallCount++
+	m.UpdateCommentCallArgs = append(m.UpdateCommentCallArgs, CommentCallArg{IssueID: issueID, CommentID: commentID, Text: text})
+	m.mu.Unlock()
+
+	if m.UpdateCommentFn == nil {
+		return nil // Default to no-op for tests
+	}
+	return m.UpdateCommentFn(ctx, issueID, commentID, text)
+}
+
+// DeleteComment invokes the configured stub or returns nil (no-op by default).
+func (m *MockClient) DeleteComment(ctx context.Context, issueID string, commentID int) error {
+	m.mu.Lock()
+	m.DeleteCommentCallCount++
+	m.DeleteCommentCallArgs = append(m.DeleteCommentCallArgs, CommentCallArg{IssueID: issueID, CommentID: commentID})
+	m.mu.Unlock()
+
+	if m.DeleteCommentFn == nil {
+		return nil // Default to no-op for tests
+	}
+	return m.DeleteCommentFn(ctx, issueID, commentID)
+}
@@ -42,3 +42,53 @@ func TestMockClient_UpdatePriority_InvokesStub(t *testing.T) {
 		t.Errorf("expected stub error, got %v", err)
 	}
 }
+
+func TestMockClient_UpdateAssignee_RecordsCall(t *testing.T) {
+	t.Parallel()
+
+	m := NewMockClient()
+	if err := m.UpdateAssignee(context.Background(), "ab-own", "alice"); err != nil {
+		t.Fatalf("UpdateAssignee returned error: %v", err)
+	}
+
+	if m.UpdateAssigneeCallCount != 1 {
+		t.Errorf("expected 1 call, got %d", m.UpdateAssigneeCallCount)
+	}
+	if len(m.UpdateAssigneeCallArgs) != 1 || m.UpdateAssigneeCallArgs[0][0] != "ab-own" || m.UpdateAssigneeCallArgs[0][1] != "alice" {
+		t.Errorf("unexpected call args: %+v", m.UpdateAssigneeCallArgs)
+	}
+}
+
+func TestMockClient_UpdateSchedule_RecordsCall(t *testing.T) {
+	t.Parallel()
+
+	m := NewMockClient()
+	if err := m.UpdateSchedule(context.Background(), "ab-due", "2025-02-01", "2025-01-20", 45); err != nil {
+		t.Fatalf("UpdateSchedule returned error: %v", err)
+	}
+
+	want := UpdateScheduleCallArg{IssueID: "ab-due", Due: "2025-02-01", DeferUntil: "2025-01-20", EstimateMinutes: 45}
+	if m.UpdateScheduleCallCount != 1 || len(m.UpdateScheduleCallArgs) != 1 || m.UpdateScheduleCallArgs[0] != want {
+		t.Errorf("unexpected calls: count=%d args=%+v", m.UpdateScheduleCallCount, m.UpdateScheduleCallArgs)
+	}
+}
+
+func TestMockClient_CommentEditDelete_RecordsCalls(t *testing.T) {
+	t.Parallel()
+
+	m := NewMockClient()
+	ctx := context.Background()
+	if err := m.UpdateComment(ctx, "ab-c", 3, "fixed typo"); err != nil {
+		t.Fatalf("UpdateComment returned error: %v", err)
+	}
+	if err := m.DeleteComment(ctx, "ab-c", 4); err != nil {
+		t.Fatalf("DeleteComment returned error: %v", err)
+	}
+
+	if m.UpdateCommentCallCount != 1 || m.UpdateCommentCallArgs[0] != (CommentCallArg{IssueID: "ab-c", CommentID: 3, Text: "fixed typo"}) {
+		t.Errorf("unexpected update calls: %+v", m.UpdateCommentCallArgs)
+	}
+	if m.DeleteCommentCallCount != 1 || m.DeleteCommentCallArgs[0] != (CommentCallArg{IssueID: "ab-c", CommentID: 4}) {
+		t.Errorf("unexpected delete calls: %+v", m.DeleteCommentCallArgs)
+	}
+}
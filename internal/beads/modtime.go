@@ -0,0 +1,38 @@
+package beads
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// LatestModTime returns the newest modification time of a SQLite database
+// and its WAL and shared-memory files, so writes that have not been
+// checkpointed yet still count as changes.
+func LatestModTime(dbPath string) (time.Time, error) {
+	info, err := os.Stat(dbPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	latest := info.ModTime()
+	for _, path := range []string{dbPath + "-wal", dbPath + "-shm"} {
+		if modTime, err := optionalModTime(path); err != nil {
+			return time.Time{}, err
+		} else if modTime.After(latest) {
+			latest = modTime
+		}
+	}
+	return latest, nil
+}
+
+func optionalModTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
@@ -0,0 +1,542 @@
+// Package beads provides client implementations for beads issue tracking.
+//
+// This file contains the external backend plugin protocol: any backend other
+// than bd and br is an `abacus-backend-<name>` executable on PATH that
+// answers JSON-RPC 2.0 requests mirroring Reader and Writer on stdin/stdout.
+// See docs/BACKEND_PLUGINS.md for the protocol.
+package beads
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PluginPrefix is prepended to a plugin backend's name to form its executable.
+const PluginPrefix = "abacus-backend-"
+
+// PluginProtocolVersion is the plugin protocol revision abacus speaks. A
+// plugin must answer initialize with the same version.
+const PluginProtocolVersion = 1
+
+// Plugin error codes, in the JSON-RPC implementation-defined range. A plugin
+// that does not implement a method may also answer -32601 (method not found).
+const (
+	PluginCodeNotSupported = -32001
+	PluginCodeNotFound     = -32002
+
+	pluginCodeMethodNotFound = -32601
+	pluginCodeInternal       = -32603
+)
+
+// maxPluginMessage bounds a single plugin response, which may carry a full
+// export.
+const maxPluginMessage = 64 << 20
+
+// pluginStopTimeout is how long a plugin has to exit after its stdin closes.
+const pluginStopTimeout = 2 * time.Second
+
+// pluginStderrTail is how much of a plugin's stderr is kept for errors.
+const pluginStderrTail = 4 << 10
+
+var pluginNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// IsPluginBackendName reports whether name could name a plugin backend:
+// lowercase letters, digits, '-' and '_', and not bd or br.
+func IsPluginBackendName(name string) bool {
+	return name != BackendBd && name != BackendBr && pluginNamePattern.MatchString(name)
+}
+
+// PluginCommand returns the executable name for plugin backend name.
+func PluginCommand(name string) string {
+	return PluginPrefix + name
+}
+
+// pluginExists reports whether the plugin backend's executable is on PATH.
+func pluginExists(name string) bool {
+	return IsPluginBackendName(name) && commandExists(PluginCommand(name))
+}
+
+// findPluginBackends returns the names of the plugin backends on PATH,
+// sorted and without duplicates.
+func findPluginBackends() []string {
+	var names []string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), PluginPrefix)
+			if !ok {
+				continue
+			}
+			name = strings.TrimSuffix(name, filepath.Ext(name)) // .exe on Windows
+			if pluginExists(name) && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// pluginParams carries the parameters of every plugin method; each method
+// uses the subset documented in docs/BACKEND_PLUGINS.md.
+type pluginParams struct {
+	ProtocolVersion int      `json:"protocol_version,omitempty"`
+	DBPath          string   `json:"db_path,omitempty"`
+	IssueID         string   `json:"issue_id,omitempty"`
+	IDs             []string `json:"ids,omitempty"`
+	Status          string   `json:"status,omitempty"`
+	Reason          string   `json:"reason,omitempty"`
+	Label           string   `json:"label,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	Priority        int      `json:"priority,omitempty"`
+	Assignee        string   `json:"assignee,omitempty"`
+	Due             string   `json:"due,omitempty"`
+	DeferUntil      string   `json:"defer_until,omitempty"`
+	EstimateMinutes int      `json:"estimate_minutes,omitempty"`
+	Ref             string   `json:"ref,omitempty"`
+	Title           string   `json:"title,omitempty"`
+	IssueType       string   `json:"issue_type,omitempty"`
+	Description     string   `json:"description,omitempty"`
+	ParentID        string   `json:"parent_id,omitempty"`
+	FromID          string   `json:"from_id,omitempty"`
+	ToID            string   `json:"to_id,omitempty"`
+	DepType         string   `json:"dep_type,omitempty"`
+	Cascade         bool     `json:"cascade,omitempty"`
+	Text            string   `json:"text,omitempty"`
+	CommentID       int      `json:"comment_id,omitempty"`
+}
+
+type pluginRequest struct {
+	JSONRPC string       `json:"jsonrpc"`
+	ID      int64        `json:"id"`
+	Method  string       `json:"method"`
+	Params  pluginParams `json:"params"`
+}
+
+type pluginResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int64           `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *PluginError    `json:"error,omitempty"`
+}
+
+// initializeResult is a plugin's answer to initialize.
+type initializeResult struct {
+	ProtocolVersion int    `json:"protocol_version"`
+	Name            string `json:"name,omitempty"`
+}
+
+// createResult is a plugin's answer to create.
+type createResult struct {
+	ID string `json:"id"`
+}
+
+// PluginError is an error reported by a plugin. It matches ErrNotSupported
+// and ErrNotFound for the corresponding codes.
+type PluginError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *PluginError) Error() string {
+	return e.Message
+}
+
+func (e *PluginError) Unwrap() error {
+	switch e.Code {
+	case PluginCodeNotSupported, pluginCodeMethodNotFound:
+		return ErrNotSupported
+	case PluginCodeNotFound:
+		return ErrNotFound
+	}
+	return nil
+}
+
+// pluginClient talks to a plugin backend process. The process is started on
+// first use and restarted on the next call if it exits.
+type pluginClient struct {
+	name    string
+	dbPath  string
+	command []string
+	env     []string
+
+	mu   sync.Mutex
+	proc *pluginProcess
+}
+
+// NewPluginClient returns a client for the plugin backend name, which runs
+// `abacus-backend-<name>` from PATH for the database at dbPath.
+func NewPluginClient(name, dbPath string) Client {
+	return &pluginClient{name: name, dbPath: dbPath, command: []string{PluginCommand(name)}}
+}
+
+// Shutdown stops the plugin process, if it is running.
+func (c *pluginClient) Shutdown() error {
+	c.mu.Lock()
+	proc := c.proc
+	c.proc = nil
+	c.mu.Unlock()
+	if proc == nil {
+		return nil
+	}
+	return proc.stop()
+}
+
+// process returns the running plugin process, starting it and performing
+// the initialize handshake when needed.
+func (c *pluginClient) process(ctx context.Context) (*pluginProcess, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.proc != nil && !c.proc.exited() {
+		return c.proc, nil
+	}
+	proc, err := startPluginProcess(c.command, c.env, deriveWorkDirFromDBPath(c.dbPath))
+	if err != nil {
+		return nil, fmt.Errorf("start backend %s: %w", c.name, err)
+	}
+	var hello initializeResult
+	params := pluginParams{ProtocolVersion: PluginProtocolVersion, DBPath: c.dbPath}
+	if err := proc.call(ctx, "initialize", params, &hello); err != nil {
+		_ = proc.stop()
+		return nil, fmt.Errorf("backend %s initialize: %w", c.name, err)
+	}
+	if hello.ProtocolVersion != PluginProtocolVersion {
+		_ = proc.stop()
+		return nil, fmt.Errorf("backend %s speaks protocol version %d, abacus requires %d", c.name, hello.ProtocolVersion, PluginProtocolVersion)
+	}
+	c.proc = proc
+	return proc, nil
+}
+
+func (c *pluginClient) call(ctx context.Context, method string, params pluginParams, result any) error {
+	proc, err := c.process(ctx)
+	if err != nil {
+		return err
+	}
+	if err := proc.call(ctx, method, params, result); err != nil {
+		return fmt.Errorf("backend %s %s: %w", c.name, method, err)
+	}
+	return nil
+}
+
+// pluginProcess is one running plugin. Requests may be issued concurrently;
+// responses are matched to them by ID.
+type pluginProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stderr *tailBuffer
+
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan pluginResponse
+	done    chan struct{}
+	err     error
+}
+
+func startPluginProcess(command, env []string, dir string) (*pluginProcess, error) {
+	//nolint:gosec // G204: the plugin executable is chosen by the user's --backend or config
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Dir = dir
+	if env != nil {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	p := &pluginProcess{
+		cmd:     cmd,
+		stdin:   stdin,
+		stderr:  &tailBuffer{limit: pluginStderrTail},
+		pending: make(map[int64]chan pluginResponse),
+		done:    make(chan struct{}),
+	}
+	cmd.Stderr = p.stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	go p.readLoop(stdout)
+	return p, nil
+}
+
+// readLoop delivers responses until the plugin closes stdout, then records
+// why it stopped.
+func (p *pluginProcess) readLoop(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxPluginMessage)
+	for scanner.Scan() {
+		var resp pluginResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			continue // not a response; plugins should log to stderr instead
+		}
+		p.mu.Lock()
+		ch, ok := p.pending[resp.ID]
+		delete(p.pending, resp.ID)
+		p.mu.Unlock()
+		if ok {
+			ch <- resp
+		}
+	}
+	err := scanner.Err()
+	waitErr := p.cmd.Wait()
+	if err == nil {
+		err = waitErr
+	}
+	if err == nil {
+		err = errors.New("exited")
+	}
+	if tail := strings.TrimSpace(p.stderr.String()); tail != "" {
+		err = fmt.Errorf("%w: %s", err, tail)
+	}
+	p.mu.Lock()
+	p.err = fmt.Errorf("plugin process stopped: %w", err)
+	p.mu.Unlock()
+	close(p.done)
+}
+
+func (p *pluginProcess) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *pluginProcess) call(ctx context.Context, method string, params pluginParams, result any) error {
+	ch := make(chan pluginResponse, 1)
+	p.mu.Lock()
+	p.nextID++
+	id := p.nextID
+	p.pending[id] = ch
+	p.mu.Unlock()
+	forget := func() {
+		p.mu.Lock()
+		delete(p.pending, id)
+		p.mu.Unlock()
+	}
+
+	data, err := json.Marshal(pluginRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		forget()
+		return fmt.Errorf("encode request: %w", err)
+	}
+	p.writeMu.Lock()
+	_, err = p.stdin.Write(append(data, '\n'))
+	p.writeMu.Unlock()
+	if err != nil {
+		forget()
+		return fmt.Errorf("send request: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		forget()
+		return ctx.Err()
+	case <-p.done:
+		forget()
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.err
+	case resp := <-ch:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result == nil || len(resp.Result) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return fmt.Errorf("decode result: %w", err)
+		}
+		return nil
+	}
+}
+
+// stop closes the plugin's stdin, which asks it to exit, and kills it if it
+// has not exited within pluginStopTimeout.
+func (p *pluginProcess) stop() error {
+	_ = p.stdin.Close()
+	select {
+	case <-p.done:
+	case <-time.After(pluginStopTimeout):
+		_ = p.cmd.Process.Kill()
+		<-p.done
+	}
+	return nil
+}
+
+// tailBuffer keeps the last limit bytes written to it.
+type tailBuffer struct {
+	mu    sync.Mutex
+	limit int
+	buf   []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.limit {
+		b.buf = b.buf[len(b.buf)-b.limit:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
+
+// Reader methods.
+
+func (c *pluginClient) List(ctx context.Context) ([]LiteIssue, error) {
+	var issues []LiteIssue
+	return issues, c.call(ctx, "list", pluginParams{}, &issues)
+}
+
+func (c *pluginClient) Show(ctx context.Context, ids []string) ([]FullIssue, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var issues []FullIssue
+	return issues, c.call(ctx, "show", pluginParams{IDs: ids}, &issues)
+}
+
+func (c *pluginClient) Export(ctx context.Context) ([]FullIssue, error) {
+	var issues []FullIssue
+	return issues, c.call(ctx, "export", pluginParams{}, &issues)
+}
+
+func (c *pluginClient) Comments(ctx context.Context, issueID string) ([]Comment, error) {
+	var comments []Comment
+	return comments, c.call(ctx, "comments", pluginParams{IssueID: issueID}, &comments)
+}
+
+func (c *pluginClient) History(ctx context.Context, issueID string) ([]HistoryEvent, error) {
+	var events []HistoryEvent
+	return events, c.call(ctx, "history", pluginParams{IssueID: issueID}, &events)
+}
+
+// Writer methods.
+
+func (c *pluginClient) UpdateStatus(ctx context.Context, issueID, newStatus string) error {
+	return c.call(ctx, "update_status", pluginParams{IssueID: issueID, Status: newStatus}, nil)
+}
+
+func (c *pluginClient) Close(ctx context.Context, issueID string) error {
+	return c.call(ctx, "close", pluginParams{IssueID: issueID}, nil)
+}
+
+func (c *pluginClient) CloseWithReason(ctx context.Context, issueID, reason string) error {
+	return c.call(ctx, "close_with_reason", pluginParams{IssueID: issueID, Reason: reason}, nil)
+}
+
+func (c *pluginClient) Reopen(ctx context.Context, issueID string) error {
+	return c.call(ctx, "reopen", pluginParams{IssueID: issueID}, nil)
+}
+
+func (c *pluginClient) AddLabel(ctx context.Context, issueID, label string) error {
+	return c.call(ctx, "add_label", pluginParams{IssueID: issueID, Label: label}, nil)
+}
+
+func (c *pluginClient) RemoveLabel(ctx context.Context, issueID, label string) error {
+	return c.call(ctx, "remove_label", pluginParams{IssueID: issueID, Label: label}, nil)
+}
+
+func (c *pluginClient) UpdatePriority(ctx context.Context, issueID string, priority int) error {
+	return c.call(ctx, "update_priority", pluginParams{IssueID: issueID, Priority: priority}, nil)
+}
+
+func (c *pluginClient) UpdateAssignee(ctx context.Context, issueID, assignee string) error {
+	return c.call(ctx, "update_assignee", pluginParams{IssueID: issueID, Assignee: assignee}, nil)
+}
+
+func (c *pluginClient) UpdateSchedule(ctx context.Context, issueID, due, deferUntil string, estimateMinutes int) error {
+	params := pluginParams{IssueID: issueID, Due: due, DeferUntil: deferUntil, EstimateMinutes: estimateMinutes}
+	return c.call(ctx, "update_schedule", params, nil)
+}
+
+func (c *pluginClient) UpdateExternalRef(ctx context.Context, issueID, ref string) error {
+	return c.call(ctx, "update_external_ref", pluginParams{IssueID: issueID, Ref: ref}, nil)
+}
+
+func (c *pluginClient) UpdateFull(ctx context.Context, issueID, title, issueType string, priority int, labels []string, assignee, description string) error {
+	params := pluginParams{
+		IssueID:     issueID,
+		Title:       title,
+		IssueType:   issueType,
+		Priority:    priority,
+		Labels:      labels,
+		Assignee:    assignee,
+		Description: description,
+	}
+	return c.call(ctx, "update_full", params, nil)
+}
+
+func (c *pluginClient) Create(ctx context.Context, title, issueType string, priority int, labels []string, assignee string) (string, error) {
+	params := pluginParams{Title: title, IssueType: issueType, Priority: priority, Labels: labels, Assignee: assignee}
+	var created createResult
+	if err := c.call(ctx, "create", params, &created); err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+func (c *pluginClient) CreateFull(ctx context.Context, title, issueType string, priority int, labels []string, assignee, description, parentID string) (FullIssue, error) {
+	params := pluginParams{
+		Title:       title,
+		IssueType:   issueType,
+		Priority:    priority,
+		Labels:      labels,
+		Assignee:    assignee,
+		Description: description,
+		ParentID:    parentID,
+	}
+	var issue FullIssue
+	return issue, c.call(ctx, "create_full", params, &issue)
+}
+
+func (c *pluginClient) AddDependency(ctx context.Context, fromID, toID, depType string) error {
+	return c.call(ctx, "add_dependency", pluginParams{FromID: fromID, ToID: toID, DepType: depType}, nil)
+}
+
+func (c *pluginClient) RemoveDependency(ctx context.Context, fromID, toID, depType string) error {
+	return c.call(ctx, "remove_dependency", pluginParams{FromID: fromID, ToID: toID, DepType: depType}, nil)
+}
+
+func (c *pluginClient) Delete(ctx context.Context, issueID string, cascade bool) error {
+	return c.call(ctx, "delete", pluginParams{IssueID: issueID, Cascade: cascade}, nil)
+}
+
+func (c *pluginClient) AddComment(ctx context.Context, issueID, text string) error {
+	return c.call(ctx, "add_comment", pluginParams{IssueID: issueID, Text: text}, nil)
+}
+
+func (c *pluginClient) UpdateComment(ctx context.Context, issueID string, commentID int, text string) error {
+	return c.call(ctx, "update_comment", pluginParams{IssueID: issueID, CommentID: commentID, Text: text}, nil)
+}
+
+func (c *pluginClient) DeleteComment(ctx context.Context, issueID string, commentID int) error {
+	return c.call(ctx, "delete_comment", pluginParams{IssueID: issueID, CommentID: commentID}, nil)
+}
@@ -0,0 +1,132 @@
+package beads
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ServePlugin answers plugin protocol requests from r on w using client
+// until r is exhausted, so a backend written in Go only has to implement
+// Client:
+//
+//	func main() {
+//		client := mybackend.New()
+//		if err := beads.ServePlugin(context.Background(), client, os.Stdin, os.Stdout); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+//
+// Requests are answered one at a time. Errors matching ErrNotSupported and
+// ErrNotFound are reported with the corresponding plugin error codes.
+func ServePlugin(ctx context.Context, client Client, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxPluginMessage)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var req pluginRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			return fmt.Errorf("decode request: %w", err)
+		}
+		resp := pluginResponse{JSONRPC: "2.0", ID: req.ID}
+		result, err := servePluginMethod(ctx, client, req.Method, req.Params)
+		if err == nil {
+			resp.Result, err = json.Marshal(result)
+		}
+		if err != nil {
+			resp.Result = nil
+			resp.Error = pluginErrorFor(err)
+		}
+		data, err := json.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("encode response: %w", err)
+		}
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("write response: %w", err)
+		}
+	}
+	return scanner.Err()
+}
+
+func pluginErrorFor(err error) *PluginError {
+	var pluginErr *PluginError
+	switch {
+	case errors.As(err, &pluginErr):
+		return pluginErr
+	case errors.Is(err, ErrNotSupported):
+		return &PluginError{Code: PluginCodeNotSupported, Message: err.Error()}
+	case errors.Is(err, ErrNotFound):
+		return &PluginError{Code: PluginCodeNotFound, Message: err.Error()}
+	default:
+		return &PluginError{Code: pluginCodeInternal, Message: err.Error()}
+	}
+}
+
+// servePluginMethod runs one request against client. Methods without a
+// result return struct{}{}, which encodes as {}.
+func servePluginMethod(ctx context.Context, c Client, method string, p pluginParams) (any, error) {
+	done := struct{}{}
+	switch method {
+	case "initialize":
+		if p.ProtocolVersion != PluginProtocolVersion {
+			return nil, fmt.Errorf("unsupported protocol version %d (want %d)", p.ProtocolVersion, PluginProtocolVersion)
+		}
+		return initializeResult{ProtocolVersion: PluginProtocolVersion}, nil
+	case "list":
+		return c.List(ctx)
+	case "show":
+		return c.Show(ctx, p.IDs)
+	case "export":
+		return c.Export(ctx)
+	case "comments":
+		return c.Comments(ctx, p.IssueID)
+	case "history":
+		return c.History(ctx, p.IssueID)
+	case "update_status":
+		return done, c.UpdateStatus(ctx, p.IssueID, p.Status)
+	case "close":
+		return done, c.Close(ctx, p.IssueID)
+	case "close_with_reason":
+		return done, c.CloseWithReason(ctx, p.IssueID, p.Reason)
+	case "reopen":
+		return done, c.Reopen(ctx, p.IssueID)
+	case "add_label":
+		return done, c.AddLabel(ctx, p.IssueID, p.Label)
+	case "remove_label":
+		return done, c.RemoveLabel(ctx, p.IssueID, p.Label)
+	case "update_priority":
+		return done, c.UpdatePriority(ctx, p.IssueID, p.Priority)
+	case "update_assignee":
+		return done, c.UpdateAssignee(ctx, p.IssueID, p.Assignee)
+	case "update_schedule":
+		return done, c.UpdateSchedule(ctx, p.IssueID, p.Due, p.DeferUntil, p.EstimateMinutes)
+	case "update_external_ref":
+		return done, c.UpdateExternalRef(ctx, p.IssueID, p.Ref)
+	case "update_full":
+		return done, c.UpdateFull(ctx, p.IssueID, p.Title, p.IssueType, p.Priority, p.Labels, p.Assignee, p.Description)
+	case "create":
+		id, err := c.Create(ctx, p.Title, p.IssueType, p.Priority, p.Labels, p.Assignee)
+		return createResult{ID: id}, err
+	case "create_full":
+		return c.CreateFull(ctx, p.Title, p.IssueType, p.Priority, p.Labels, p.Assignee, p.Description, p.ParentID)
+	case "add_dependency":
+		return done, c.AddDependency(ctx, p.FromID, p.ToID, p.DepType)
+	case "remove_dependency":
+		return done, c.RemoveDependency(ctx, p.FromID, p.ToID, p.DepType)
+	case "delete":
+		return done, c.Delete(ctx, p.IssueID, p.Cascade)
+	case "add_comment":
+		return done, c.AddComment(ctx, p.IssueID, p.Text)
+	case "update_comment":
+		return done, c.UpdateComment(ctx, p.IssueID, p.CommentID, p.Text)
+	case "delete_comment":
+		return done, c.DeleteComment(ctx, p.IssueID, p.CommentID)
+	default:
+		return nil, &PluginError{Code: pluginCodeMethodNotFound, Message: "method not found: " + method}
+	}
+}
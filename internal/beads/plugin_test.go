@@ -0,0 +1,153 @@
+package beads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestPluginHelperProcess is not a real test: it is the plugin that the
+// other tests in this file launch, serving a MockClient over the protocol.
+func TestPluginHelperProcess(t *testing.T) {
+	if os.Getenv("ABACUS_PLUGIN_HELPER") != "1" {
+		t.Skip("helper process for plugin tests")
+	}
+	mock := NewMockClient()
+	mock.ExportFn = func(context.Context) ([]FullIssue, error) {
+		return []FullIssue{{ID: "ab-1", Title: "From plugin", Status: "open", Labels: []string{"ui"}}}, nil
+	}
+	mock.ShowFn = func(_ context.Context, ids []string) ([]FullIssue, error) {
+		if ids[0] == "ab-missing" {
+			return nil, fmt.Errorf("show %s: %w", ids[0], ErrNotFound)
+		}
+		return []FullIssue{{ID: ids[0]}}, nil
+	}
+	mock.UpdatePriorityFn = func(_ context.Context, _ string, priority int) error {
+		if priority != 0 {
+			return fmt.Errorf("priority arrived as %d", priority)
+		}
+		return nil
+	}
+	mock.CreateFullFn = func(_ context.Context, title, issueType string, priority int, labels []string, _, _, parentID string) (FullIssue, error) {
+		return FullIssue{ID: "ab-2", Title: title, IssueType: issueType, Priority: priority, Labels: labels, Description: "parent " + parentID}, nil
+	}
+	mock.AddCommentFn = func(context.Context, string, string) error { return ErrNotSupported }
+	if os.Getenv("ABACUS_PLUGIN_HELPER_CRASH") == "1" {
+		fmt.Fprintln(os.Stderr, "storage unavailable")
+		os.Exit(3)
+	}
+	if err := ServePlugin(context.Background(), mock, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func newHelperPluginClient(t *testing.T, env ...string) *pluginClient {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, ".beads"), 0o750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	c := &pluginClient{
+		name:    "helper",
+		dbPath:  filepath.Join(dir, ".beads", "beads.db"),
+		command: []string{os.Args[0], "-test.run=^TestPluginHelperProcess$"},
+		env:     append([]string{"ABACUS_PLUGIN_HELPER=1"}, env...),
+	}
+	t.Cleanup(func() { _ = c.Shutdown() })
+	return c
+}
+
+func TestPluginClientRoundTrip(t *testing.T) {
+	c := newHelperPluginClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	issues, err := c.Export(ctx)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Title != "From plugin" || issues[0].Labels[0] != "ui" {
+		t.Fatalf("unexpected export %+v", issues)
+	}
+
+	if err := c.UpdatePriority(ctx, "ab-1", 0); err != nil {
+		t.Fatalf("UpdatePriority(0): %v", err)
+	}
+
+	created, err := c.CreateFull(ctx, "New", "bug", 1, []string{"x"}, "", "", "ab-1")
+	if err != nil {
+		t.Fatalf("CreateFull: %v", err)
+	}
+	if created.ID != "ab-2" || created.IssueType != "bug" || created.Description != "parent ab-1" {
+		t.Fatalf("unexpected created issue %+v", created)
+	}
+}
+
+func TestPluginClientErrors(t *testing.T) {
+	c := newHelperPluginClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := c.AddComment(ctx, "ab-1", "hi"); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("expected ErrNotSupported, got %v", err)
+	}
+	if _, err := c.Show(ctx, []string{"ab-missing"}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	// The mock leaves History unset, so it reports an ordinary error.
+	_, err := c.History(ctx, "ab-1")
+	if err == nil || errors.Is(err, ErrNotSupported) || !strings.Contains(err.Error(), "backend helper history") {
+		t.Fatalf("expected wrapped plugin error, got %v", err)
+	}
+}
+
+func TestPluginClientRestartsAfterShutdown(t *testing.T) {
+	c := newHelperPluginClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := c.Export(ctx); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if err := c.Shutdown(); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if _, err := c.Export(ctx); err != nil {
+		t.Fatalf("Export after restart: %v", err)
+	}
+}
+
+func TestPluginClientReportsCrash(t *testing.T) {
+	c := newHelperPluginClient(t, "ABACUS_PLUGIN_HELPER_CRASH=1")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := c.Export(ctx)
+	if err == nil || !strings.Contains(err.Error(), "storage unavailable") {
+		t.Fatalf("expected crash error with plugin stderr, got %v", err)
+	}
+}
+
+func TestIsPluginBackendName(t *testing.T) {
+	cases := map[string]bool{
+		"jira":     true,
+		"my_fork2": true,
+		"bd":       false,
+		"br":       false,
+		"":         false,
+		"../evil":  false,
+		"Upper":    false,
+	}
+	for name, want := range cases {
+		if got := IsPluginBackendName(name); got != want {
+			t.Errorf("IsPluginBackendName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
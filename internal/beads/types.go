@@ -14,6 +14,19 @@ type Comment struct {
 	CreatedAt string `json:"created_at"`
 }
 
+// HistoryEvent is one recorded change to an issue. Field is one of the
+// History* constants or, for backend events abacus does not recognize, the
+// event type.
+type HistoryEvent struct {
+	IssueID   string `json:"issue_id"`
+	Field     string `json:"field"`
+	Actor     string `json:"actor"`
+	OldValue  string `json:"old_value"`
+	NewValue  string `json:"new_value"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
 // Dependency captures dependency metadata from the Beads API.
 type Dependency struct {
 	TargetID string `json:"id"`
@@ -44,6 +57,9 @@ type FullIssue struct {
 	ExternalRef        string       `json:"external_ref"`
 	Assignee           string       `json:"assignee"`
 	CreatedBy          string       `json:"created_by"`
+	DueAt              string       `json:"due_at"`
+	DeferUntil         string       `json:"defer_until"`
+	EstimatedMinutes   int          `json:"estimated_minutes"`
 	Labels             []string     `json:"labels"`
 	Comments           []Comment    `json:"comments"`
 	Dependencies       []Dependency `json:"dependencies"`
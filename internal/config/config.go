@@ -31,13 +31,30 @@ const (
 	KeyTreeColumnsLastUpdated = "tree.columns.lastUpdated"
 	KeyTreeColumnsAssignee    = "tree.columns.assignee"
 	KeyTreeColumnsComments    = "tree.columns.comments"
+	KeyTreeColumnsDue         = "tree.columns.due"
 
 	// Backend selection keys
-	KeyBeadsBackend                  = "beads.backend"                       // "bd" or "br", empty means auto-detect
+	KeyBeadsBackend                  = "beads.backend"                       // "bd", "br" or a plugin name, empty means auto-detect
 	KeyBdUnsupportedVersionWarnShown = "beads.bd_unsupported_version_warned" // true if user has seen the bd > 0.38.0 warning
+	KeyBeadsDirectWrites             = "beads.directWrites"                  // br only: write to SQLite directly instead of via the CLI
 
 	// Layout
 	KeyLayoutMode = "layout.mode" // "wide" (default) or "tall"
+
+	// Tree sort mode, persisted per view mode (All/Active/Ready)
+	KeyTreeSortAll    = "tree.sort.all"
+	KeyTreeSortActive = "tree.sort.active"
+	KeyTreeSortReady  = "tree.sort.ready"
+
+	// Identity used for "Me", my-work filtering and comment authorship
+	KeyIdentityName    = "identity.name"    // empty means fall back to $USER
+	KeyIdentityAliases = "identity.aliases" // other names that also count as "me"
+
+	// Label colors and icons, a list of LabelStyle rules
+	KeyLabelStyles = "labels.styles"
+
+	// External ref link patterns, a list of LinkPattern rules
+	KeyLinkPatterns = "links.patterns"
 )
 
 const (
@@ -147,6 +164,76 @@ func GetInt(key string) int {
 	return v.GetInt(key)
 }
 
+// GetStringSlice fetches a string list configuration value, initializing on demand.
+func GetStringSlice(key string) []string {
+	v, err := getViper()
+	if err != nil {
+		return nil
+	}
+	return v.GetStringSlice(key)
+}
+
+// LabelStyle maps labels matching Match (an exact name or a glob such as
+// "area/*") to a color and an optional icon. Color is a theme color name
+// (error, warning, success, info, primary, secondary, accent), a hex value
+// or an ANSI color number.
+type LabelStyle struct {
+	Match string `mapstructure:"match"`
+	Color string `mapstructure:"color"`
+	Icon  string `mapstructure:"icon"`
+}
+
+// GetLabelStyles returns the configured label style rules in file order.
+// Malformed entries are skipped rather than failing the whole list.
+func GetLabelStyles() []LabelStyle {
+	v, err := getViper()
+	if err != nil {
+		return nil
+	}
+	var styles []LabelStyle
+	if err := v.UnmarshalKey(KeyLabelStyles, &styles); err != nil {
+		return nil
+	}
+	valid := styles[:0]
+	for _, s := range styles {
+		s.Match = strings.TrimSpace(s.Match)
+		if s.Match != "" {
+			valid = append(valid, s)
+		}
+	}
+	return valid
+}
+
+// LinkPattern turns text matching Match (a Go regular expression such as
+// `gh-(\d+)`) into a link. URL may reference capture groups as $1 or ${1};
+// $0 is the whole match.
+type LinkPattern struct {
+	Match string `mapstructure:"match"`
+	URL   string `mapstructure:"url"`
+}
+
+// GetLinkPatterns returns the configured link patterns in file order.
+// Entries missing a match or URL are skipped.
+func GetLinkPatterns() []LinkPattern {
+	v, err := getViper()
+	if err != nil {
+		return nil
+	}
+	var patterns []LinkPattern
+	if err := v.UnmarshalKey(KeyLinkPatterns, &patterns); err != nil {
+		return nil
+	}
+	valid := patterns[:0]
+	for _, p := range patterns {
+		p.Match = strings.TrimSpace(p.Match)
+		p.URL = strings.TrimSpace(p.URL)
+		if p.Match != "" && p.URL != "" {
+			valid = append(valid, p)
+		}
+	}
+	return valid
+}
+
 // GetDuration fetches a duration configuration value, initializing on demand.
 func GetDuration(key string) time.Duration {
 	v, err := getViper()
@@ -292,9 +379,16 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault(KeyTreeColumnsLastUpdated, true)
 	v.SetDefault(KeyTreeColumnsAssignee, true)
 	v.SetDefault(KeyTreeColumnsComments, true)
+	v.SetDefault(KeyTreeColumnsDue, false)
 	v.SetDefault(KeyBeadsBackend, "")                     // Empty means auto-detect
 	v.SetDefault(KeyBdUnsupportedVersionWarnShown, false) // One-time warning not yet shown
+	v.SetDefault(KeyBeadsDirectWrites, false)
 	v.SetDefault(KeyLayoutMode, "wide")
+	v.SetDefault(KeyTreeSortAll, "smart")
+	v.SetDefault(KeyTreeSortActive, "smart")
+	v.SetDefault(KeyTreeSortReady, "smart")
+	v.SetDefault(KeyIdentityName, "")
+	v.SetDefault(KeyIdentityAliases, []string{})
 }
 
 func getViper() (*viper.Viper, error) {
@@ -526,6 +620,19 @@ func SaveBdUnsupportedVersionWarned() error {
 // SaveLayout persists the layout mode to user config (~/.abacus/config.yaml).
 // Layout preference is always per-user, never per-project.
 func SaveLayout(mode string) error {
+	return saveUserValue(KeyLayoutMode, mode)
+}
+
+// SaveSortMode persists a tree sort mode to user config (~/.abacus/config.yaml).
+// key is one of the per-view KeyTreeSort* keys; like layout, sorting is a
+// per-user preference and never written to project config.
+func SaveSortMode(key, mode string) error {
+	return saveUserValue(key, mode)
+}
+
+// saveUserValue writes a single key to the user config file, preserving
+// any other settings already present.
+func saveUserValue(key string, value any) error {
 	targetPath := userConfigPathOverride
 	if targetPath == "" {
 		path, err := defaultUserConfigPath()
@@ -541,7 +648,7 @@ func SaveLayout(mode string) error {
 
 	_ = v.ReadInConfig() // ignore error if file doesn't exist
 
-	v.Set(KeyLayoutMode, mode)
+	v.Set(key, value)
 
 	dir := filepath.Dir(targetPath)
 	//nolint:gosec // G301: User config directory needs standard permissions
@@ -126,6 +126,109 @@ tree:
 	}
 }
 
+func TestIdentityConfig(t *testing.T) {
+	reset()
+	t.Cleanup(reset)
+
+	tmp := t.TempDir()
+	projectDir := filepath.Join(tmp, "repo")
+	mustMkdir(t, filepath.Join(projectDir, ".abacus"))
+	projectCfg := filepath.Join(projectDir, ".abacus", "config.yaml")
+	writeFile(t, projectCfg, `
+identity:
+  name: alice
+  aliases:
+    - alice@example.com
+    - asmith
+`)
+
+	if err := Initialize(
+		WithWorkingDir(projectDir),
+		WithProjectConfig(projectCfg),
+	); err != nil {
+		t.Fatalf("Initialize returned error: %v", err)
+	}
+
+	if got := GetString(KeyIdentityName); got != "alice" {
+		t.Fatalf("expected identity name alice, got %q", got)
+	}
+	aliases := GetStringSlice(KeyIdentityAliases)
+	if len(aliases) != 2 || aliases[0] != "alice@example.com" || aliases[1] != "asmith" {
+		t.Fatalf("unexpected identity aliases: %v", aliases)
+	}
+}
+
+func TestLabelStylesConfig(t *testing.T) {
+	reset()
+	t.Cleanup(reset)
+
+	tmp := t.TempDir()
+	projectDir := filepath.Join(tmp, "repo")
+	mustMkdir(t, filepath.Join(projectDir, ".abacus"))
+	projectCfg := filepath.Join(projectDir, ".abacus", "config.yaml")
+	writeFile(t, projectCfg, `
+labels:
+  styles:
+    - match: security
+      color: error
+      icon: "!"
+    - match: "area/*"
+      color: "#7aa2f7"
+    - color: info
+`)
+
+	if err := Initialize(
+		WithWorkingDir(projectDir),
+		WithProjectConfig(projectCfg),
+	); err != nil {
+		t.Fatalf("Initialize returned error: %v", err)
+	}
+
+	styles := GetLabelStyles()
+	want := []LabelStyle{
+		{Match: "security", Color: "error", Icon: "!"},
+		{Match: "area/*", Color: "#7aa2f7"},
+	}
+	if len(styles) != len(want) || styles[0] != want[0] || styles[1] != want[1] {
+		t.Fatalf("unexpected label styles (entries without match are dropped): %+v", styles)
+	}
+}
+
+func TestLinkPatternsConfig(t *testing.T) {
+	reset()
+	t.Cleanup(reset)
+
+	tmp := t.TempDir()
+	projectDir := filepath.Join(tmp, "repo")
+	mustMkdir(t, filepath.Join(projectDir, ".abacus"))
+	projectCfg := filepath.Join(projectDir, ".abacus", "config.yaml")
+	writeFile(t, projectCfg, `
+links:
+  patterns:
+    - match: 'gh-(\d+)'
+      url: https://github.com/acme/app/issues/$1
+    - match: 'JIRA-\d+'
+      url: "https://acme.atlassian.net/browse/$0"
+    - match: 'no-url-\d+'
+`)
+
+	if err := Initialize(
+		WithWorkingDir(projectDir),
+		WithProjectConfig(projectCfg),
+	); err != nil {
+		t.Fatalf("Initialize returned error: %v", err)
+	}
+
+	patterns := GetLinkPatterns()
+	want := []LinkPattern{
+		{Match: `gh-(\d+)`, URL: "https://github.com/acme/app/issues/$1"},
+		{Match: `JIRA-\d+`, URL: "https://acme.atlassian.net/browse/$0"},
+	}
+	if len(patterns) != len(want) || patterns[0] != want[0] || patterns[1] != want[1] {
+		t.Fatalf("unexpected link patterns (entries without url are dropped): %+v", patterns)
+	}
+}
+
 func TestEnvironmentBinding(t *testing.T) {
 	reset()
 	t.Cleanup(reset)
@@ -466,6 +569,38 @@ func TestSaveLayoutToUserConfig(t *testing.T) {
 	}
 }
 
+func TestSaveSortModeToUserConfig(t *testing.T) {
+	reset()
+	t.Cleanup(reset)
+
+	tmp := t.TempDir()
+	userCfg := filepath.Join(tmp, ".abacus", "config.yaml")
+
+	workDir := filepath.Join(tmp, "work")
+	mustMkdir(t, workDir)
+
+	if err := Initialize(WithWorkingDir(workDir), WithUserConfig(userCfg)); err != nil {
+		t.Fatalf("Initialize returned error: %v", err)
+	}
+	if got := GetString(KeyTreeSortReady); got != "smart" {
+		t.Fatalf("expected default sort mode 'smart', got %q", got)
+	}
+
+	setUserConfigPathOverride(userCfg)
+
+	if err := SaveSortMode(KeyTreeSortReady, "blocker-depth"); err != nil {
+		t.Fatalf("SaveSortMode returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(userCfg)
+	if err != nil {
+		t.Fatalf("failed to read user config: %v", err)
+	}
+	if !contains(string(data), "blocker-depth") {
+		t.Fatalf("expected user config to contain 'blocker-depth', got:\n%s", data)
+	}
+}
+
 func TestLayoutModeDefault(t *testing.T) {
 	reset()
 	t.Cleanup(reset)
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// TemplatesDir is the project-relative directory holding bead templates.
+const TemplatesDir = ".abacus/templates"
+
+// BeadTemplate is a create-overlay template loaded from a markdown file with
+// YAML front-matter. Empty fields leave the overlay's current value alone.
+type BeadTemplate struct {
+	Name     string   // front-matter "name", defaults to the file name without .md
+	Title    string   // initial title, may contain {{variables}}
+	Type     string   // issue type, e.g. "bug"
+	Priority int      // 0-4, or -1 when not set
+	Labels   []string // labels to preselect
+	Assignee string
+	Parent   string // glob matched against parent IDs and titles
+	Body     string // description, may contain {{variables}}
+	Path     string
+}
+
+// LoadTemplates reads every *.md file in <projectRoot>/.abacus/templates,
+// sorted by name. A missing directory yields no templates. Files that fail
+// to parse are skipped and reported together in the returned error.
+func LoadTemplates(projectRoot string) ([]BeadTemplate, error) {
+	if strings.TrimSpace(projectRoot) == "" {
+		return nil, nil
+	}
+	dir := filepath.Join(projectRoot, TemplatesDir)
+	paths, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("list templates: %w", err)
+	}
+
+	var templates []BeadTemplate
+	var errs []error
+	for _, path := range paths {
+		//nolint:gosec // G304: Templates are read from the project's .abacus directory
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("read %s: %w", path, err))
+			continue
+		}
+		tmpl, err := ParseTemplate(data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("parse %s: %w", path, err))
+			continue
+		}
+		tmpl.Path = path
+		if tmpl.Name == "" {
+			tmpl.Name = strings.TrimSuffix(filepath.Base(path), ".md")
+		}
+		templates = append(templates, tmpl)
+	}
+	sort.SliceStable(templates, func(i, j int) bool {
+		return strings.ToLower(templates[i].Name) < strings.ToLower(templates[j].Name)
+	})
+	return templates, errors.Join(errs...)
+}
+
+// ParseTemplate splits a template into its front-matter and body. A file
+// without a leading "---" line is treated as body only.
+func ParseTemplate(data []byte) (BeadTemplate, error) {
+	tmpl := BeadTemplate{Priority: -1}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	front, body, ok := splitFrontMatter(text)
+	if !ok {
+		tmpl.Body = strings.TrimSpace(text)
+		return tmpl, nil
+	}
+	tmpl.Body = strings.TrimSpace(body)
+	if strings.TrimSpace(front) == "" {
+		return tmpl, nil
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader([]byte(front))); err != nil {
+		return BeadTemplate{}, fmt.Errorf("front-matter: %w", err)
+	}
+	tmpl.Name = strings.TrimSpace(v.GetString("name"))
+	tmpl.Title = strings.TrimLeft(v.GetString("title"), " \t") // keep a trailing space after prefixes like "Bug: "
+	tmpl.Type = strings.ToLower(strings.TrimSpace(v.GetString("type")))
+	tmpl.Assignee = strings.TrimSpace(v.GetString("assignee"))
+	tmpl.Parent = strings.TrimSpace(v.GetString("parent"))
+	for _, l := range v.GetStringSlice("labels") {
+		if l = strings.TrimSpace(l); l != "" {
+			tmpl.Labels = append(tmpl.Labels, l)
+		}
+	}
+	if raw := strings.TrimSpace(v.GetString("priority")); raw != "" {
+		p, err := parseTemplatePriority(raw)
+		if err != nil {
+			return BeadTemplate{}, err
+		}
+		tmpl.Priority = p
+	}
+	return tmpl, nil
+}
+
+// splitFrontMatter returns the YAML between the opening and closing "---"
+// lines and the remaining body.
+func splitFrontMatter(text string) (front, body string, ok bool) {
+	if !strings.HasPrefix(text, "---\n") {
+		return "", text, false
+	}
+	lines := strings.Split(text[len("---\n"):], "\n")
+	for i, line := range lines {
+		if strings.TrimRight(line, " \t") == "---" {
+			return strings.Join(lines[:i], "\n"), strings.Join(lines[i+1:], "\n"), true
+		}
+	}
+	return "", text, false
+}
+
+// parseTemplatePriority accepts 0-4 or P0-P4.
+func parseTemplatePriority(raw string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(raw), "P"))
+	if err != nil || n < 0 || n > 4 {
+		return 0, fmt.Errorf("priority %q must be 0-4 or P0-P4", raw)
+	}
+	return n, nil
+}
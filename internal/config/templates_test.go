@@ -0,0 +1,81 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTemplate(t *testing.T) {
+	tmpl, err := ParseTemplate([]byte(`---
+name: Bug report
+title: "Bug: "
+type: Bug
+priority: P1
+labels: [bug, triage]
+assignee: alice
+parent: "*Bugs*"
+---
+## Repro
+
+## Expected
+
+## Actual
+`))
+	if err != nil {
+		t.Fatalf("ParseTemplate returned error: %v", err)
+	}
+	want := BeadTemplate{
+		Name:     "Bug report",
+		Title:    "Bug: ",
+		Type:     "bug",
+		Priority: 1,
+		Labels:   []string{"bug", "triage"},
+		Assignee: "alice",
+		Parent:   "*Bugs*",
+		Body:     "## Repro\n\n## Expected\n\n## Actual",
+	}
+	if !reflect.DeepEqual(tmpl, want) {
+		t.Fatalf("unexpected template:\n got %+v\nwant %+v", tmpl, want)
+	}
+
+	t.Run("bodyOnly", func(t *testing.T) {
+		tmpl, err := ParseTemplate([]byte("Just a body\n"))
+		if err != nil || tmpl.Body != "Just a body" || tmpl.Priority != -1 {
+			t.Fatalf("unexpected template %+v (err %v)", tmpl, err)
+		}
+	})
+
+	t.Run("badPriority", func(t *testing.T) {
+		if _, err := ParseTemplate([]byte("---\npriority: urgent\n---\n")); err == nil {
+			t.Fatal("expected invalid priority to fail")
+		}
+	})
+}
+
+func TestLoadTemplates(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, TemplatesDir)
+	mustMkdir(t, dir)
+	writeFile(t, filepath.Join(dir, "spike.md"), "---\ntype: task\n---\nTimebox: 1d\n")
+	writeFile(t, filepath.Join(dir, "bug.md"), "---\nname: Bug report\ntype: bug\n---\n")
+	writeFile(t, filepath.Join(dir, "broken.md"), "---\npriority: 9\n---\n")
+	writeFile(t, filepath.Join(dir, "notes.txt"), "ignored")
+
+	templates, err := LoadTemplates(root)
+	if err == nil || !strings.Contains(err.Error(), "broken.md") {
+		t.Fatalf("expected broken template reported, got %v", err)
+	}
+	var names []string
+	for _, tmpl := range templates {
+		names = append(names, tmpl.Name)
+	}
+	if strings.Join(names, ",") != "Bug report,spike" {
+		t.Fatalf("unexpected templates: %v", names)
+	}
+
+	if templates, err := LoadTemplates(t.TempDir()); err != nil || len(templates) != 0 {
+		t.Fatalf("expected no templates without directory, got %v (err %v)", templates, err)
+	}
+}
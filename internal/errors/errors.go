@@ -14,6 +14,8 @@ const (
 	CodeCLIFailed   Code = "cli_failed"
 	CodeParseFailed Code = "parse_failed"
 	CodeNotFound    Code = "not_found"
+	CodeBusy        Code = "busy"    // database locked by another writer
+	CodeTimeout     Code = "timeout" // command did not finish in time
 
 	// Domain/graph errors
 	CodeInvalidTransition  Code = "invalid_transition"
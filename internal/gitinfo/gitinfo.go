@@ -0,0 +1,379 @@
+// Package gitinfo finds the commits and branches in a local git repository
+// that mention a bead ID, names branches for beads and parses the
+// Closes/Refs trailers that tie commits to beads.
+//
+// Commits are found with `git log --all --grep <id>`; branches are any local
+// or remote-tracking branch whose name contains the ID. IDs only match as
+// whole words, so "ab-1" does not match "ab-12" or the child "ab-1.2".
+package gitinfo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotRepository is returned by Open when the directory is not inside a
+// git work tree (or git is not installed).
+var ErrNotRepository = errors.New("not a git repository")
+
+// grepBatchSize bounds how many --grep patterns go into one git log call,
+// keeping the command line well under platform limits.
+const grepBatchSize = 200
+
+// Commit is a commit whose message mentions a bead.
+type Commit struct {
+	Hash    string // abbreviated hash
+	Subject string
+	Author  string
+	Date    time.Time
+}
+
+// Refs holds the commits (newest first) and branches mentioning a bead.
+type Refs struct {
+	Commits  []Commit
+	Branches []string
+}
+
+// Empty reports whether nothing in the repository mentions the bead.
+func (r Refs) Empty() bool {
+	return len(r.Commits) == 0 && len(r.Branches) == 0
+}
+
+// Repo runs git commands in a work tree.
+type Repo struct {
+	dir string
+}
+
+// Open returns the repository containing dir.
+func Open(ctx context.Context, dir string) (*Repo, error) {
+	r := &Repo{dir: dir}
+	out, err := r.git(ctx, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotRepository, err)
+	}
+	r.dir = strings.TrimSpace(string(out))
+	return r, nil
+}
+
+// Dir returns the top-level directory of the work tree.
+func (r *Repo) Dir() string {
+	return r.dir
+}
+
+// Scan returns the commits and branches mentioning each of ids. IDs that
+// nothing mentions are absent from the result.
+func (r *Repo) Scan(ctx context.Context, ids []string) (map[string]Refs, error) {
+	result := make(map[string]Refs)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	seen := make(map[string]bool)
+	for start := 0; start < len(ids); start += grepBatchSize {
+		end := start + grepBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+		commits, err := r.logGrep(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range commits {
+			for _, id := range batch {
+				if !Mentions(c.message, id) {
+					continue
+				}
+				key := id + "\x00" + c.Hash
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				refs := result[id]
+				refs.Commits = append(refs.Commits, c.Commit)
+				result[id] = refs
+			}
+		}
+	}
+
+	branches, err := r.branches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, branch := range branches {
+		for _, id := range ids {
+			if Mentions(branch, id) {
+				refs := result[id]
+				refs.Branches = append(refs.Branches, branch)
+				result[id] = refs
+			}
+		}
+	}
+	return result, nil
+}
+
+// CreateBranch creates a branch at HEAD without checking it out.
+func (r *Repo) CreateBranch(ctx context.Context, name string) error {
+	if _, err := r.git(ctx, "check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("invalid branch name %q", name)
+	}
+	_, err := r.git(ctx, "branch", "--", name)
+	return err
+}
+
+// HeadCommit returns the commit at HEAD and its full message.
+func (r *Repo) HeadCommit(ctx context.Context) (Commit, string, error) {
+	out, err := r.git(ctx, "log", "-1", "--format="+logFormat)
+	if err != nil {
+		return Commit{}, "", err
+	}
+	commits := parseLog(out)
+	if len(commits) == 0 {
+		return Commit{}, "", fmt.Errorf("git log: no commit at HEAD")
+	}
+	return commits[0].Commit, commits[0].message, nil
+}
+
+// HooksDir returns the directory git runs hooks from, honoring core.hooksPath.
+func (r *Repo) HooksDir(ctx context.Context) (string, error) {
+	out, err := r.git(ctx, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	dir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.dir, dir)
+	}
+	return dir, nil
+}
+
+// LineRevision is a commit that changed a line of a file, with the line as
+// the commit left it.
+type LineRevision struct {
+	Commit
+	Line string
+}
+
+// LineHistory returns, oldest first, the commits that added or changed a line
+// of path containing needle, each with the new version of that line. Commits
+// that only removed the line are skipped.
+func (r *Repo) LineHistory(ctx context.Context, path, needle string) ([]LineRevision, error) {
+	rel, err := r.relPath(path)
+	if err != nil {
+		return nil, err
+	}
+	out, err := r.git(ctx, "log", "--reverse", "--no-color", "--no-ext-diff", "--patch", "--unified=0",
+		"-G"+quoteRegexp(needle), "--format=%x1e%h%x1f%ct%x1f%an%x1f%s", "--", rel)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []LineRevision
+	for _, record := range bytes.Split(out, []byte{0x1e}) {
+		header, patch, _ := strings.Cut(string(record), "\n")
+		fields := strings.SplitN(header, "\x1f", 4)
+		if len(fields) < 4 {
+			continue
+		}
+		rev := LineRevision{Commit: Commit{Hash: fields[0], Author: fields[2], Subject: fields[3]}}
+		if secs, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			rev.Date = time.Unix(secs, 0)
+		}
+		for _, line := range strings.Split(patch, "\n") {
+			if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") && strings.Contains(line, needle) {
+				rev.Line = line[1:]
+			}
+		}
+		if rev.Line != "" {
+			revisions = append(revisions, rev)
+		}
+	}
+	return revisions, nil
+}
+
+// relPath returns path relative to the work tree, resolving symlinks so it
+// matches the top-level directory git reports.
+func (r *Repo) relPath(path string) (string, error) {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(r.dir, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside the repository", path)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// quoteRegexp escapes the characters that are special in both basic and
+// extended POSIX regular expressions, which is all bead IDs can contain.
+func quoteRegexp(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`\.[]*^$`, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// logFormat separates fields with US and records with RS so subjects and
+// bodies can contain anything else.
+const logFormat = "%h%x1f%ct%x1f%an%x1f%s%x1f%B%x1e"
+
+type loggedCommit struct {
+	Commit
+	message string
+}
+
+// logGrep lists commits on any ref whose message contains one of ids.
+func (r *Repo) logGrep(ctx context.Context, ids []string) ([]loggedCommit, error) {
+	args := []string{"log", "--all", "--fixed-strings", "--format=" + logFormat}
+	for _, id := range ids {
+		args = append(args, "--grep="+id)
+	}
+	out, err := r.git(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLog(out), nil
+}
+
+func parseLog(out []byte) []loggedCommit {
+	var commits []loggedCommit
+	for _, record := range bytes.Split(out, []byte{0x1e}) {
+		fields := strings.SplitN(strings.TrimLeft(string(record), "\n"), "\x1f", 5)
+		if len(fields) < 5 {
+			continue
+		}
+		c := loggedCommit{
+			Commit: Commit{
+				Hash:    fields[0],
+				Author:  fields[2],
+				Subject: fields[3],
+			},
+			message: fields[4],
+		}
+		if secs, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			c.Date = time.Unix(secs, 0)
+		}
+		commits = append(commits, c)
+	}
+	return commits
+}
+
+// branches lists local and remote-tracking branch names, skipping symbolic
+// refs such as origin/HEAD.
+func (r *Repo) branches(ctx context.Context) ([]string, error) {
+	out, err := r.git(ctx, "for-each-ref", "--format=%(refname:short)%09%(symref)", "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(string(out), "\n") {
+		name, symref, _ := strings.Cut(line, "\t")
+		if name == "" || symref != "" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (r *Repo) git(ctx context.Context, args ...string) ([]byte, error) {
+	//nolint:gosec // G204: arguments are fixed git subcommands plus bead IDs
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = r.dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return nil, fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return nil, fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return out, nil
+}
+
+// Mentions reports whether text contains id as a whole word: the characters
+// around it must not extend it into a longer ID.
+func Mentions(text, id string) bool {
+	if id == "" {
+		return false
+	}
+	for offset := 0; offset < len(text); {
+		idx := strings.Index(text[offset:], id)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(id)
+		if (start == 0 || !isWordByte(text[start-1])) && !extendsID(text[end:]) {
+			return true
+		}
+		offset = start + 1
+	}
+	return false
+}
+
+// extendsID reports whether rest continues an ID: another word character,
+// or a dot followed by one (a hierarchical child such as "ab-1.2").
+func extendsID(rest string) bool {
+	if rest == "" {
+		return false
+	}
+	if isWordByte(rest[0]) {
+		return true
+	}
+	return rest[0] == '.' && len(rest) > 1 && isWordByte(rest[1])
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+// maxSlugLen bounds the title part of a branch name.
+const maxSlugLen = 40
+
+// BranchName names a branch for a bead: the ID followed by a slug of the
+// title, e.g. "ab-12-fix-crash-on-start".
+func BranchName(id, title string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	slug := sb.String()
+	if len(slug) > maxSlugLen {
+		slug = slug[:maxSlugLen]
+		if cut := strings.LastIndexByte(slug, '-'); cut > maxSlugLen/2 {
+			slug = slug[:cut]
+		}
+		slug = strings.TrimRight(slug, "-")
+	}
+	if slug == "" {
+		return id
+	}
+	return id + "-" + slug
+}
@@ -0,0 +1,205 @@
+package gitinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func initRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) { runGit(t, dir, args...) }
+	run("init", "-q", "-b", "main")
+	run("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	run("commit", "-q", "--allow-empty", "-m", "Fix crash on start (ab-12)")
+	run("commit", "-q", "--allow-empty", "-m", "Add settings page\n\nRefs: ab-1.2")
+	run("branch", "ab-12-fix-crash")
+	run("checkout", "-q", "-b", "feature/ab-7")
+	run("commit", "-q", "--allow-empty", "-m", "Start ab-7 on a branch")
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(cmd.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestScan(t *testing.T) {
+	dir := initRepo(t)
+	ctx := context.Background()
+	repo, err := Open(ctx, dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	refs, err := repo.Scan(ctx, []string{"ab-1", "ab-12", "ab-1.2", "ab-7", "ab-99"})
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+
+	if got := refs["ab-12"]; len(got.Commits) != 1 || got.Commits[0].Subject != "Fix crash on start (ab-12)" ||
+		len(got.Branches) != 1 || got.Branches[0] != "ab-12-fix-crash" {
+		t.Errorf("ab-12 refs = %+v", got)
+	}
+	if got := refs["ab-1.2"]; len(got.Commits) != 1 || got.Commits[0].Subject != "Add settings page" {
+		t.Errorf("expected ab-1.2 to match the commit body, got %+v", got)
+	}
+	if got := refs["ab-7"]; len(got.Commits) != 1 || len(got.Branches) != 1 || got.Branches[0] != "feature/ab-7" {
+		t.Errorf("expected ab-7 commit on a branch and its branch, got %+v", got)
+	}
+	if got, ok := refs["ab-1"]; ok {
+		t.Errorf("ab-1 should not match ab-12 or ab-1.2, got %+v", got)
+	}
+	if _, ok := refs["ab-99"]; ok {
+		t.Error("ab-99 should have no refs")
+	}
+}
+
+func TestCreateBranch(t *testing.T) {
+	dir := initRepo(t)
+	ctx := context.Background()
+	repo, err := Open(ctx, dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	name := BranchName("ab-99", "Add export")
+	if err := repo.CreateBranch(ctx, name); err != nil {
+		t.Fatalf("create branch: %v", err)
+	}
+	refs, err := repo.Scan(ctx, []string{"ab-99"})
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if got := refs["ab-99"].Branches; len(got) != 1 || got[0] != "ab-99-add-export" {
+		t.Errorf("branches = %v", got)
+	}
+	if err := repo.CreateBranch(ctx, name); err == nil {
+		t.Error("expected error creating an existing branch")
+	}
+}
+
+func TestOpenOutsideRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CEILING_DIRECTORIES", t.TempDir())
+	_, err := Open(context.Background(), t.TempDir())
+	if !errors.Is(err, ErrNotRepository) {
+		t.Errorf("expected ErrNotRepository, got %v", err)
+	}
+}
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		text, id string
+		want     bool
+	}{
+		{"Fix ab-12", "ab-12", true},
+		{"Fix ab-12.", "ab-12", true},
+		{"ab-12-fix-crash", "ab-12", true},
+		{"feature/ab-12", "ab-12", true},
+		{"Fix ab-123", "ab-12", false},
+		{"Fix ab-12.3", "ab-12", false},
+		{"xab-12", "ab-12", false},
+		{"ab-123 and ab-12", "ab-12", true},
+	}
+	for _, tt := range tests {
+		if got := Mentions(tt.text, tt.id); got != tt.want {
+			t.Errorf("Mentions(%q, %q) = %v, want %v", tt.text, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestBranchName(t *testing.T) {
+	tests := []struct {
+		id, title, want string
+	}{
+		{"ab-12", "Fix crash on start!", "ab-12-fix-crash-on-start"},
+		{"ab-1.2", "  Héllo, World  ", "ab-1.2-h-llo-world"},
+		{"ab-3", "???", "ab-3"},
+		{"ab-4", "Make the importer skip issues that were already imported last time", "ab-4-make-the-importer-skip-issues-that-were"},
+	}
+	for _, tt := range tests {
+		if got := BranchName(tt.id, tt.title); got != tt.want {
+			t.Errorf("BranchName(%q, %q) = %q, want %q", tt.id, tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestHeadCommitAndHooksDir(t *testing.T) {
+	dir := initRepo(t)
+	ctx := context.Background()
+	repo, err := Open(ctx, dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	commit, message, err := repo.HeadCommit(ctx)
+	if err != nil {
+		t.Fatalf("head commit: %v", err)
+	}
+	if commit.Subject != "Start ab-7 on a branch" || commit.Hash == "" || !strings.HasPrefix(message, "Start ab-7") {
+		t.Errorf("unexpected head commit %+v, message %q", commit, message)
+	}
+
+	hooks, err := repo.HooksDir(ctx)
+	if err != nil {
+		t.Fatalf("hooks dir: %v", err)
+	}
+	if want := filepath.Join(repo.Dir(), ".git", "hooks"); hooks != want {
+		t.Errorf("hooks dir = %q, want %q", hooks, want)
+	}
+}
+
+func TestLineHistory(t *testing.T) {
+	dir := initRepo(t)
+	path := filepath.Join(dir, "issues.jsonl")
+	versions := []string{
+		`{"id":"ab-1","status":"open"}` + "\n" + `{"id":"ab-12","status":"open"}` + "\n",
+		`{"id":"ab-1","status":"open"}` + "\n" + `{"id":"ab-12","status":"closed"}` + "\n",
+		`{"id":"ab-1","status":"in_progress"}` + "\n" + `{"id":"ab-12","status":"closed"}` + "\n",
+	}
+	for i, content := range versions {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, dir, "add", "issues.jsonl")
+		runGit(t, dir, "commit", "-q", "-m", fmt.Sprintf("Export %d", i+1))
+	}
+
+	ctx := context.Background()
+	repo, err := Open(ctx, dir)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	revs, err := repo.LineHistory(ctx, path, `"id":"ab-1"`)
+	if err != nil {
+		t.Fatalf("line history: %v", err)
+	}
+	if len(revs) != 2 {
+		t.Fatalf("expected 2 revisions of ab-1, got %+v", revs)
+	}
+	if revs[0].Subject != "Export 1" || revs[0].Line != `{"id":"ab-1","status":"open"}` {
+		t.Errorf("unexpected first revision %+v", revs[0])
+	}
+	if revs[1].Subject != "Export 3" || revs[1].Line != `{"id":"ab-1","status":"in_progress"}` {
+		t.Errorf("unexpected second revision %+v", revs[1])
+	}
+}
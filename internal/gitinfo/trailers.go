@@ -0,0 +1,107 @@
+package gitinfo
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TrailerAction is what a commit trailer asks for a bead.
+type TrailerAction string
+
+const (
+	TrailerCloses TrailerAction = "closes" // "Closes: ab-12" closes the bead
+	TrailerRefs   TrailerAction = "refs"   // "Refs: ab-12" comments on the bead
+)
+
+// Trailer is a bead reference from a commit message.
+type Trailer struct {
+	Action TrailerAction
+	ID     string
+}
+
+// trailerLine matches "Closes: <ids>" and "Refs: <ids>" (any case). Several
+// IDs may share a line, separated by commas or spaces.
+var trailerLine = regexp.MustCompile(`(?i)^(closes|refs)\s*:\s*(.+)$`)
+
+// anyTrailerLine matches a "Key: value" trailer such as Signed-off-by.
+var anyTrailerLine = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*\s*:\s*\S`)
+
+// beadIDPattern matches bead IDs: a lower-case prefix, a dash and a lower-case
+// hash or number, with optional ".N" suffixes for child beads.
+var beadIDPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*-[a-z0-9]+(\.[0-9]+)*$`)
+
+// scissorsLine starts the diff that `git commit --verbose` appends below
+// the message.
+const scissorsLine = "# ------------------------ >8 ------------------------"
+
+// ParseTrailers returns the beads referenced by Closes/Refs trailers in a
+// commit message, in order. Like `git interpret-trailers`, only the last
+// paragraph counts, and only when every line in it is a trailer, so prose
+// such as "refs: see above" in the body is not mistaken for one. Comment
+// lines are ignored, and so are values that are not bead IDs (#12,
+// JIRA-123). A bead that is both closed and referenced is only reported as
+// closed.
+func ParseTrailers(message string) []Trailer {
+	var trailers []Trailer
+	index := make(map[string]int)
+	for _, line := range trailerBlock(message) {
+		match := trailerLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		action := TrailerAction(strings.ToLower(match[1]))
+		for _, id := range strings.FieldsFunc(match[2], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			if !beadIDPattern.MatchString(id) {
+				continue
+			}
+			if i, ok := index[id]; ok {
+				if action == TrailerCloses {
+					trailers[i].Action = TrailerCloses
+				}
+				continue
+			}
+			index[id] = len(trailers)
+			trailers = append(trailers, Trailer{Action: action, ID: id})
+		}
+	}
+	return trailers
+}
+
+// trailerBlock returns the trailers of the message's last paragraph, with
+// folded values joined, or nil if any line in it is not a trailer.
+func trailerBlock(message string) []string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == scissorsLine {
+			break
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t\r"))
+	}
+
+	end := len(lines)
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	start := end
+	for start > 0 && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+
+	var block []string
+	for _, line := range lines[start:end] {
+		if len(block) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			// A folded value continues the trailer above it.
+			block[len(block)-1] += " " + strings.TrimSpace(line)
+			continue
+		}
+		if !anyTrailerLine.MatchString(line) {
+			return nil
+		}
+		block = append(block, line)
+	}
+	return block
+}
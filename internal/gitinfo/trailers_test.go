@@ -0,0 +1,68 @@
+package gitinfo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTrailers(t *testing.T) {
+	message := `Fix crash on start
+
+Closes: ab-12
+refs: ab-7, ab-8
+Refs: ab-12
+# Closes: ab-99
+# ------------------------ >8 ------------------------
+Closes: ab-100
+`
+	want := []Trailer{
+		{Action: TrailerCloses, ID: "ab-12"},
+		{Action: TrailerRefs, ID: "ab-7"},
+		{Action: TrailerRefs, ID: "ab-8"},
+	}
+	if got := ParseTrailers(message); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseTrailers = %+v, want %+v", got, want)
+	}
+
+	if got := ParseTrailers("Refs: ab-3\nCloses: ab-3"); len(got) != 1 || got[0].Action != TrailerCloses {
+		t.Errorf("expected close to win over refs, got %+v", got)
+	}
+	if got := ParseTrailers("Mention ab-1 without a trailer"); len(got) != 0 {
+		t.Errorf("expected no trailers, got %+v", got)
+	}
+}
+
+func TestParseTrailers_OnlyTrailerBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []Trailer
+	}{
+		{
+			name:    "body mentions are ignored",
+			message: "Fix crash\n\nCloses: ab-1 was the first attempt.\nrefs: see above\n\nSigned-off-by: A <a@example.com>\nRefs: ab-2\n",
+			want:    []Trailer{{Action: TrailerRefs, ID: "ab-2"}},
+		},
+		{
+			name:    "last paragraph with prose is not a trailer block",
+			message: "Fix crash\n\nRefs: ab-2\nas discussed\n",
+		},
+		{
+			name:    "non-bead values are skipped",
+			message: "Fix crash\n\nCloses: #12, JIRA-123, ab-3\nRefs: https://example.com/issues/4\n",
+			want:    []Trailer{{Action: TrailerCloses, ID: "ab-3"}},
+		},
+		{
+			name:    "folded values continue the trailer",
+			message: "Fix crash\n\nRefs: ab-4,\n  ab-5.1\n",
+			want:    []Trailer{{Action: TrailerRefs, ID: "ab-4"}, {Action: TrailerRefs, ID: "ab-5.1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseTrailers(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTrailers = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// csvHeader maps column names to their indexes. Jira repeats columns such
+// as "Labels" once per value, so each name can have several indexes.
+type csvHeader map[string][]int
+
+func newCSVHeader(row []string) csvHeader {
+	h := csvHeader{}
+	for i, name := range row {
+		name = strings.TrimSpace(name)
+		h[name] = append(h[name], i)
+	}
+	return h
+}
+
+func (h csvHeader) has(name string) bool {
+	return len(h[name]) > 0
+}
+
+// get returns the first non-empty value of the named column.
+func (h csvHeader) get(row []string, name string) string {
+	for _, i := range h[name] {
+		if i < len(row) {
+			if v := strings.TrimSpace(row[i]); v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// all returns every non-empty value of a repeated column.
+func (h csvHeader) all(row []string, name string) []string {
+	var values []string
+	for _, i := range h[name] {
+		if i < len(row) {
+			if v := strings.TrimSpace(row[i]); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// require reports the first missing column.
+func (h csvHeader) require(names ...string) error {
+	for _, name := range names {
+		if !h.has(name) {
+			return fmt.Errorf("missing column %q", name)
+		}
+	}
+	return nil
+}
+
+func newCSVReader(data []byte) *csv.Reader {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	return r
+}
+
+func readCSVHeader(data []byte) (csvHeader, error) {
+	row, err := newCSVReader(data).Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	return newCSVHeader(row), nil
+}
+
+// readCSV returns the header and the remaining rows. Rows are numbered from
+// 2 in errors so they match the line a spreadsheet shows.
+func readCSV(data []byte) (csvHeader, [][]string, error) {
+	r := newCSVReader(data)
+	first, err := r.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read csv header: %w", err)
+	}
+	var rows [][]string
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("read csv row %d: %w", len(rows)+2, err)
+		}
+		rows = append(rows, row)
+	}
+	return newCSVHeader(first), rows, nil
+}
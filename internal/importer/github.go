@@ -0,0 +1,66 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// githubIssue is the subset of `gh issue list --json` fields we read.
+type githubIssue struct {
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"`
+	URL    string `json:"url"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+}
+
+// ParseGitHubJSON reads the output of
+//
+//	gh issue list --state all --json number,title,body,state,labels,assignees,url
+//
+// The bead type comes from labels such as "bug" or "enhancement", and only
+// the first assignee is kept. gh does not export sub-issue links, so GitHub
+// records never have a parent.
+func ParseGitHubJSON(data []byte) ([]Record, error) {
+	var issues []githubIssue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		return nil, fmt.Errorf("parse github json: %w", err)
+	}
+	records := make([]Record, 0, len(issues))
+	for i, issue := range issues {
+		title := strings.TrimSpace(issue.Title)
+		if title == "" {
+			return nil, fmt.Errorf("github issue %d: missing title", i+1)
+		}
+		if issue.URL == "" {
+			return nil, fmt.Errorf("github issue %q: missing url (include \"url\" in --json)", title)
+		}
+		labels := make([]string, 0, len(issue.Labels))
+		for _, l := range issue.Labels {
+			labels = append(labels, l.Name)
+		}
+		rec := Record{
+			Ref:         issue.URL,
+			Title:       title,
+			Description: strings.TrimSpace(issue.Body),
+			IssueType:   typeFromLabels(labels),
+			Priority:    defaultPriority,
+			Status:      StatusOpen,
+			Labels:      cleanLabels(labels),
+		}
+		if strings.EqualFold(issue.State, "closed") {
+			rec.Status = StatusClosed
+		}
+		if len(issue.Assignees) > 0 {
+			rec.Assignee = issue.Assignees[0].Login
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
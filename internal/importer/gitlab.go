@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// gitlabEpicRefPrefix marks epics in external refs. The GitLab CSV only
+// exports an epic's ID and title, not its URL.
+const gitlabEpicRefPrefix = "gitlab-epic:"
+
+// ParseGitLabCSV reads a GitLab issue list exported with "Export as CSV".
+// Labels are comma-separated, the assignee comes from "Assignee Username",
+// and each distinct "Epic ID" becomes an epic bead that parents its issues.
+// Epics are listed before the issues that reference them.
+func ParseGitLabCSV(data []byte) ([]Record, error) {
+	header, rows, err := readCSV(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse gitlab csv: %w", err)
+	}
+	if err := header.require("Title", "URL", "State"); err != nil {
+		return nil, fmt.Errorf("parse gitlab csv: %w", err)
+	}
+
+	var epics, issues []Record
+	seenEpics := map[string]bool{}
+	for i, row := range rows {
+		title := header.get(row, "Title")
+		url := header.get(row, "URL")
+		if title == "" || url == "" {
+			return nil, fmt.Errorf("parse gitlab csv: row %d: title and url are required", i+2)
+		}
+		labels := cleanLabels(strings.Split(header.get(row, "Labels"), ","))
+		rec := Record{
+			Ref:         url,
+			Title:       title,
+			Description: header.get(row, "Description"),
+			IssueType:   typeFromLabels(labels),
+			Priority:    defaultPriority,
+			Status:      StatusOpen,
+			Labels:      labels,
+			Assignee:    firstListValue(header.get(row, "Assignee Username")),
+		}
+		if strings.EqualFold(header.get(row, "State"), "closed") {
+			rec.Status = StatusClosed
+		}
+		if epicID := header.get(row, "Epic ID"); epicID != "" {
+			epicRef := gitlabEpicRefPrefix + epicID
+			rec.ParentRef = epicRef
+			if !seenEpics[epicRef] {
+				seenEpics[epicRef] = true
+				epicTitle := header.get(row, "Epic Title")
+				if epicTitle == "" {
+					epicTitle = "Epic " + epicID
+				}
+				epics = append(epics, Record{
+					Ref:       epicRef,
+					Title:     epicTitle,
+					IssueType: "epic",
+					Priority:  defaultPriority,
+					Status:    StatusOpen,
+				})
+			}
+		}
+		issues = append(issues, rec)
+	}
+	return append(epics, issues...), nil
+}
+
+// firstListValue returns the first entry of a comma-separated cell; GitLab
+// joins multiple assignees this way.
+func firstListValue(s string) string {
+	first, _, _ := strings.Cut(s, ",")
+	return strings.TrimSpace(first)
+}
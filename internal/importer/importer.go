@@ -0,0 +1,159 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"abacus/internal/beads"
+)
+
+// Action describes what Import did with a record.
+type Action string
+
+const (
+	ActionCreated Action = "created"
+	ActionExists  Action = "exists" // a bead with the same external ref was found
+)
+
+// Entry reports the outcome for one record.
+type Entry struct {
+	Record Record
+	ID     string // bead ID; empty for records created in a dry run
+	Action Action
+	// Orphaned is set when the record names a parent that is neither in the
+	// export nor already imported; the bead is created without a parent.
+	Orphaned bool
+}
+
+// Result lists the outcome of every record Import reached.
+type Result struct {
+	Entries []Entry
+}
+
+// Count returns how many entries ended with the given action.
+func (r Result) Count(action Action) int {
+	n := 0
+	for _, e := range r.Entries {
+		if e.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Options controls an import run.
+type Options struct {
+	// DryRun reports what would be created without writing anything.
+	DryRun bool
+	// Progress, if set, is called after each record is handled.
+	Progress func(Entry)
+}
+
+// Import creates a bead for every record whose ref is not already the
+// external ref of an existing bead. Parents are created before their
+// children. Import stops at the first failed write and returns the entries
+// handled so far; because existing refs are skipped, running it again
+// resumes where it stopped.
+func Import(ctx context.Context, client beads.Client, records []Record, opts Options) (Result, error) {
+	var result Result
+	existing, err := client.Export(ctx)
+	if err != nil {
+		return result, fmt.Errorf("load existing beads: %w", err)
+	}
+	idByRef := make(map[string]string, len(existing))
+	for _, issue := range existing {
+		if issue.ExternalRef != "" {
+			idByRef[issue.ExternalRef] = issue.ID
+		}
+	}
+
+	for _, rec := range parentsFirst(records) {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		entry := Entry{Record: rec}
+		if id, ok := idByRef[rec.Ref]; ok {
+			entry.ID = id
+			entry.Action = ActionExists
+		} else {
+			parentID, ok := idByRef[rec.ParentRef]
+			entry.Orphaned = rec.ParentRef != "" && !ok
+			entry.Action = ActionCreated
+			if !opts.DryRun {
+				id, err := createRecord(ctx, client, rec, parentID)
+				if err != nil {
+					return result, fmt.Errorf("import %q: %w", rec.Ref, err)
+				}
+				entry.ID = id
+			}
+			idByRef[rec.Ref] = entry.ID
+		}
+		result.Entries = append(result.Entries, entry)
+		if opts.Progress != nil {
+			opts.Progress(entry)
+		}
+	}
+	return result, nil
+}
+
+// createRecord creates the bead, applies the source status and then links
+// it to its source. The ref is stored last because a bead that has it is
+// skipped by the next import: if any step fails the bead is deleted again,
+// so the next import creates it afresh instead of keeping a half-imported
+// bead or a duplicate.
+func createRecord(ctx context.Context, client beads.Client, rec Record, parentID string) (string, error) {
+	issue, err := client.CreateFull(ctx, rec.Title, rec.IssueType, rec.Priority, rec.Labels, rec.Assignee, rec.Description, parentID)
+	if err != nil {
+		return "", fmt.Errorf("create: %w", err)
+	}
+	discard := func(err error) error {
+		if delErr := client.Delete(ctx, issue.ID, false); delErr != nil {
+			return fmt.Errorf("%w (and delete %s: %v)", err, issue.ID, delErr)
+		}
+		return err
+	}
+	switch rec.Status {
+	case StatusClosed:
+		if err := client.Close(ctx, issue.ID); err != nil {
+			return "", discard(fmt.Errorf("close %s: %w", issue.ID, err))
+		}
+	case StatusInProgress:
+		if err := client.UpdateStatus(ctx, issue.ID, StatusInProgress); err != nil {
+			return "", discard(fmt.Errorf("start %s: %w", issue.ID, err))
+		}
+	}
+	if err := client.UpdateExternalRef(ctx, issue.ID, rec.Ref); err != nil {
+		return "", discard(fmt.Errorf("set external ref: %w", err))
+	}
+	return issue.ID, nil
+}
+
+// parentsFirst orders records so every parent in the export comes before
+// its children, keeping the export order otherwise. Records repeating an
+// earlier ref are dropped, and parent cycles are broken at the first record
+// reached.
+func parentsFirst(records []Record) []Record {
+	byRef := make(map[string]Record, len(records))
+	for _, rec := range records {
+		if _, dup := byRef[rec.Ref]; !dup {
+			byRef[rec.Ref] = rec
+		}
+	}
+	ordered := make([]Record, 0, len(byRef))
+	visited := map[string]bool{}
+	var visit func(rec Record)
+	visit = func(rec Record) {
+		if visited[rec.Ref] {
+			return
+		}
+		visited[rec.Ref] = true
+		if parent, ok := byRef[rec.ParentRef]; ok {
+			visit(parent)
+		}
+		ordered = append(ordered, rec)
+	}
+	for _, rec := range records {
+		visit(byRef[rec.Ref])
+	}
+	return ordered
+}
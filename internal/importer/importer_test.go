@@ -0,0 +1,165 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"abacus/internal/beads"
+)
+
+// newImportMock returns a mock whose CreateFull hands out sequential IDs.
+func newImportMock(existing ...beads.FullIssue) *beads.MockClient {
+	m := beads.NewMockClient()
+	m.ExportFn = func(context.Context) ([]beads.FullIssue, error) { return existing, nil }
+	n := 0
+	m.CreateFullFn = func(_ context.Context, title, issueType string, priority int, labels []string, assignee, description, parentID string) (beads.FullIssue, error) {
+		n++
+		return beads.FullIssue{ID: fmt.Sprintf("ab-%d", n), Title: title}, nil
+	}
+	return m
+}
+
+func TestImport_CreatesParentsFirstAndAppliesStatus(t *testing.T) {
+	t.Parallel()
+
+	records := []Record{
+		{Ref: "APP-2", Title: "Child", IssueType: "task", Priority: 1, Status: StatusClosed, ParentRef: "APP-1"},
+		{Ref: "APP-1", Title: "Epic", IssueType: "epic", Priority: 2, Status: StatusInProgress},
+		{Ref: "APP-3", Title: "Lost child", IssueType: "bug", Priority: 2, Status: StatusOpen, ParentRef: "APP-99"},
+	}
+	m := newImportMock()
+
+	result, err := Import(context.Background(), m, records, Options{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if got := result.Count(ActionCreated); got != 3 {
+		t.Fatalf("expected 3 created, got %d", got)
+	}
+	if m.CreateFullCallArgs[0].Title != "Epic" || m.CreateFullCallArgs[1].ParentID != "ab-1" {
+		t.Errorf("expected epic created first and used as parent, got %+v", m.CreateFullCallArgs)
+	}
+	if m.CreateFullCallArgs[2].ParentID != "" || !result.Entries[2].Orphaned {
+		t.Errorf("expected unknown parent to create a root bead, got %+v", result.Entries[2])
+	}
+	wantRefs := [][]string{{"ab-1", "APP-1"}, {"ab-2", "APP-2"}, {"ab-3", "APP-3"}}
+	for i, want := range wantRefs {
+		got := m.UpdateExternalRefCallArgs[i]
+		if got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("external ref %d: got %v, want %v", i, got, want)
+		}
+	}
+	if len(m.CloseCallArgs) != 1 || m.CloseCallArgs[0] != "ab-2" {
+		t.Errorf("expected closed record to be closed, got %v", m.CloseCallArgs)
+	}
+	if len(m.UpdateStatusCallArgs) != 1 || m.UpdateStatusCallArgs[0][0] != "ab-1" || m.UpdateStatusCallArgs[0][1] != StatusInProgress {
+		t.Errorf("expected in-progress record to be started, got %v", m.UpdateStatusCallArgs)
+	}
+}
+
+func TestImport_SkipsExistingRefs(t *testing.T) {
+	t.Parallel()
+
+	records := []Record{
+		{Ref: "https://example.com/1", Title: "Already here", IssueType: "epic", Status: StatusOpen},
+		{Ref: "https://example.com/2", Title: "New child", IssueType: "task", Status: StatusOpen, ParentRef: "https://example.com/1"},
+	}
+	m := newImportMock(beads.FullIssue{ID: "ab-old", ExternalRef: "https://example.com/1"})
+
+	result, err := Import(context.Background(), m, records, Options{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if result.Count(ActionExists) != 1 || result.Count(ActionCreated) != 1 {
+		t.Fatalf("unexpected result: %+v", result.Entries)
+	}
+	if m.CreateFullCallCount != 1 || m.CreateFullCallArgs[0].ParentID != "ab-old" {
+		t.Errorf("expected new child under existing bead, got %+v", m.CreateFullCallArgs)
+	}
+}
+
+func TestImport_DryRunWritesNothing(t *testing.T) {
+	t.Parallel()
+
+	records := []Record{
+		{Ref: "a", Title: "Parent", IssueType: "epic", Status: StatusClosed},
+		{Ref: "b", Title: "Child", IssueType: "task", Status: StatusOpen, ParentRef: "a"},
+	}
+	m := newImportMock()
+	var seen []Entry
+
+	result, err := Import(context.Background(), m, records, Options{DryRun: true, Progress: func(e Entry) { seen = append(seen, e) }})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if result.Count(ActionCreated) != 2 || len(seen) != 2 {
+		t.Fatalf("expected 2 planned creates, got %+v", result.Entries)
+	}
+	if result.Entries[1].Orphaned {
+		t.Error("parent planned in the same run should not count as missing")
+	}
+	if m.CreateFullCallCount != 0 || m.UpdateExternalRefCallCount != 0 || m.CloseCallCount != 0 {
+		t.Error("dry run should not write")
+	}
+}
+
+func TestImport_DeletesBeadWhenRefCannotBeStored(t *testing.T) {
+	t.Parallel()
+
+	m := newImportMock()
+	m.UpdateExternalRefFn = func(context.Context, string, string) error {
+		return fmt.Errorf("bd cannot set external refs: %w", beads.ErrNotSupported)
+	}
+	records := []Record{{Ref: "a", Title: "One", IssueType: "task"}, {Ref: "b", Title: "Two", IssueType: "task"}}
+
+	result, err := Import(context.Background(), m, records, Options{})
+	if !errors.Is(err, beads.ErrNotSupported) {
+		t.Fatalf("expected ErrNotSupported, got %v", err)
+	}
+	if len(result.Entries) != 0 || m.CreateFullCallCount != 1 {
+		t.Errorf("expected import to stop after the first record, got %+v", result.Entries)
+	}
+	if m.DeleteCallCount != 1 || m.DeleteCallArgs[0].IssueID != "ab-1" {
+		t.Errorf("expected the unlinked bead to be deleted, got %+v", m.DeleteCallArgs)
+	}
+}
+
+func TestImport_StatusFailureLeavesRecordForNextImport(t *testing.T) {
+	t.Parallel()
+
+	m := newImportMock()
+	m.CloseFn = func(context.Context, string) error { return errors.New("database is locked") }
+	records := []Record{{Ref: "a", Title: "Done", IssueType: "task", Status: StatusClosed}}
+
+	if _, err := Import(context.Background(), m, records, Options{}); err == nil {
+		t.Fatal("expected the close failure to be reported")
+	}
+	if m.UpdateExternalRefCallCount != 0 {
+		t.Errorf("expected no external ref before the status is set, got %v", m.UpdateExternalRefCallArgs)
+	}
+	if m.DeleteCallCount != 1 || m.DeleteCallArgs[0].IssueID != "ab-1" {
+		t.Errorf("expected the half-imported bead to be deleted, got %+v", m.DeleteCallArgs)
+	}
+
+	// The next run creates and closes it.
+	m.CloseFn = nil
+	result, err := Import(context.Background(), m, records, Options{})
+	if err != nil || result.Count(ActionCreated) != 1 || m.CloseCallCount != 2 {
+		t.Fatalf("expected the record to be imported again, got %+v, err %v", result.Entries, err)
+	}
+}
+
+func TestParentsFirst_DropsDuplicatesAndBreaksCycles(t *testing.T) {
+	t.Parallel()
+
+	ordered := parentsFirst([]Record{
+		{Ref: "a", ParentRef: "b"},
+		{Ref: "b", ParentRef: "a"},
+		{Ref: "a", Title: "duplicate"},
+	})
+	if len(ordered) != 2 || ordered[0].Ref != "b" || ordered[1].Ref != "a" {
+		t.Errorf("unexpected order: %+v", ordered)
+	}
+}
@@ -0,0 +1,111 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// jiraParentColumns name the columns that may hold a parent link, in order
+// of preference. Newer exports use "Parent" / "Parent id" with the numeric
+// issue id; classic projects link stories to epics via "Epic Link" keys.
+var jiraParentColumns = []string{"Parent", "Parent id", "Parent key", "Custom field (Epic Link)"}
+
+// ParseJiraCSV reads a Jira "Export CSV (all fields)" file. Repeated
+// "Labels" columns are merged, parents are resolved from issue ids or keys,
+// and the external ref is the issue's browse URL when baseURL is set,
+// otherwise its key (e.g. "APP-12").
+func ParseJiraCSV(data []byte, baseURL string) ([]Record, error) {
+	header, rows, err := readCSV(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse jira csv: %w", err)
+	}
+	if err := header.require("Summary", "Issue key"); err != nil {
+		return nil, fmt.Errorf("parse jira csv: %w", err)
+	}
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	ref := func(key string) string {
+		if baseURL == "" {
+			return key
+		}
+		return baseURL + "/browse/" + key
+	}
+
+	// Parent columns hold either keys or numeric ids, so map ids to keys first.
+	keyByID := map[string]string{}
+	for _, row := range rows {
+		if id, key := header.get(row, "Issue id"), header.get(row, "Issue key"); id != "" && key != "" {
+			keyByID[id] = key
+		}
+	}
+
+	records := make([]Record, 0, len(rows))
+	for i, row := range rows {
+		key := header.get(row, "Issue key")
+		title := header.get(row, "Summary")
+		if key == "" || title == "" {
+			return nil, fmt.Errorf("parse jira csv: row %d: summary and issue key are required", i+2)
+		}
+		rec := Record{
+			Ref:         ref(key),
+			Title:       title,
+			Description: header.get(row, "Description"),
+			IssueType:   mapIssueType(header.get(row, "Issue Type")),
+			Priority:    jiraPriority(header.get(row, "Priority")),
+			Status:      jiraStatus(header.get(row, "Status Category"), header.get(row, "Status")),
+			Labels:      cleanLabels(header.all(row, "Labels")),
+			Assignee:    header.get(row, "Assignee"),
+		}
+		for _, col := range jiraParentColumns {
+			parent := header.get(row, col)
+			if parent == "" {
+				continue
+			}
+			if k, ok := keyByID[parent]; ok {
+				parent = k
+			}
+			if parent != key {
+				rec.ParentRef = ref(parent)
+			}
+			break
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// jiraPriority maps Jira's default priority scheme onto 0-4.
+func jiraPriority(name string) int {
+	switch strings.ToLower(name) {
+	case "highest", "blocker", "critical":
+		return 0
+	case "high", "major":
+		return 1
+	case "medium":
+		return 2
+	case "low", "minor":
+		return 3
+	case "lowest", "trivial":
+		return 4
+	}
+	return defaultPriority
+}
+
+// jiraStatus prefers the status category, which is stable across workflows,
+// and falls back to common status names.
+func jiraStatus(category, status string) string {
+	switch strings.ToLower(category) {
+	case "done":
+		return StatusClosed
+	case "in progress":
+		return StatusInProgress
+	case "to do", "new":
+		return StatusOpen
+	}
+	switch strings.ToLower(status) {
+	case "done", "closed", "resolved", "won't do", "cancelled", "canceled":
+		return StatusClosed
+	case "in progress", "in review", "in development":
+		return StatusInProgress
+	}
+	return StatusOpen
+}
@@ -0,0 +1,135 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const githubExport = `[
+  {"number": 7, "title": "Crash on start", "body": "Stack trace", "state": "OPEN",
+   "url": "https://github.com/acme/app/issues/7",
+   "labels": [{"name": "bug"}, {"name": "ui"}], "assignees": [{"login": "alice"}, {"login": "bob"}]},
+  {"number": 8, "title": "Dark mode", "body": "", "state": "CLOSED",
+   "url": "https://github.com/acme/app/issues/8", "labels": [{"name": "enhancement"}], "assignees": []}
+]`
+
+const gitlabExport = `Title,Description,Issue ID,URL,State,Assignee,Assignee Username,Labels,Epic ID,Epic Title
+Fix login,"Users see a 500",12,https://gitlab.com/acme/app/-/issues/12,Open,Alice,"alice,bob","bug, backend",3,Auth revamp
+Add SSO,,13,https://gitlab.com/acme/app/-/issues/13,Closed,,,feature,3,Auth revamp
+Docs,,14,https://gitlab.com/acme/app/-/issues/14,Open,,,,,
+`
+
+const jiraExport = "\ufeffSummary,Issue key,Issue id,Issue Type,Status,Status Category,Priority,Assignee,Description,Labels,Labels,Parent,Custom field (Epic Link)\n" +
+	"Checkout epic,APP-1,10001,Epic,In Progress,In Progress,High,,,,,,\n" +
+	"Pay with card,APP-2,10002,Story,Done,Done,Highest,alice,Card flow,payments,web,10001,\n" +
+	"Fix rounding,APP-3,10003,Bug,Code Review,,Minor,,,,,,APP-1\n"
+
+func TestParseGitHubJSON(t *testing.T) {
+	t.Parallel()
+
+	records, err := ParseGitHubJSON([]byte(githubExport))
+	if err != nil {
+		t.Fatalf("ParseGitHubJSON: %v", err)
+	}
+	want := []Record{
+		{Ref: "https://github.com/acme/app/issues/7", Title: "Crash on start", Description: "Stack trace", IssueType: "bug", Priority: 2, Status: StatusOpen, Labels: []string{"bug", "ui"}, Assignee: "alice"},
+		{Ref: "https://github.com/acme/app/issues/8", Title: "Dark mode", IssueType: "feature", Priority: 2, Status: StatusClosed, Labels: []string{"enhancement"}},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got %+v\nwant %+v", records, want)
+	}
+
+	if _, err := ParseGitHubJSON([]byte(`[{"title": "No url"}]`)); err == nil || !strings.Contains(err.Error(), "url") {
+		t.Errorf("expected missing url error, got %v", err)
+	}
+}
+
+func TestParseGitLabCSV(t *testing.T) {
+	t.Parallel()
+
+	records, err := ParseGitLabCSV([]byte(gitlabExport))
+	if err != nil {
+		t.Fatalf("ParseGitLabCSV: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected 1 epic and 3 issues, got %d: %+v", len(records), records)
+	}
+	epic := records[0]
+	if epic.Ref != "gitlab-epic:3" || epic.Title != "Auth revamp" || epic.IssueType != "epic" {
+		t.Errorf("unexpected epic: %+v", epic)
+	}
+	fix := records[1]
+	if fix.ParentRef != "gitlab-epic:3" || fix.Assignee != "alice" || fix.IssueType != "bug" ||
+		!reflect.DeepEqual(fix.Labels, []string{"bug", "backend"}) || fix.Description != "Users see a 500" {
+		t.Errorf("unexpected issue: %+v", fix)
+	}
+	if records[2].Status != StatusClosed || records[2].IssueType != "feature" {
+		t.Errorf("expected closed feature, got %+v", records[2])
+	}
+	if records[3].ParentRef != "" || records[3].IssueType != "task" {
+		t.Errorf("expected root task, got %+v", records[3])
+	}
+}
+
+func TestParseJiraCSV(t *testing.T) {
+	t.Parallel()
+
+	records, err := Parse(FormatJira, []byte(jiraExport), ParseOptions{JiraBaseURL: "https://acme.atlassian.net/"})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	base := "https://acme.atlassian.net/browse/"
+	if r := records[0]; r.Ref != base+"APP-1" || r.IssueType != "epic" || r.Status != StatusInProgress || r.Priority != 1 {
+		t.Errorf("unexpected epic: %+v", r)
+	}
+	if r := records[1]; r.ParentRef != base+"APP-1" || r.Status != StatusClosed || r.Priority != 0 ||
+		r.IssueType != "feature" || r.Assignee != "alice" || !reflect.DeepEqual(r.Labels, []string{"payments", "web"}) {
+		t.Errorf("expected story under epic via parent id, got %+v", r)
+	}
+	if r := records[2]; r.ParentRef != base+"APP-1" || r.Status != StatusOpen || r.Priority != 3 || r.IssueType != "bug" {
+		t.Errorf("expected bug under epic via epic link, got %+v", r)
+	}
+
+	bare, err := ParseJiraCSV([]byte(strings.TrimPrefix(jiraExport, "\ufeff")), "")
+	if err != nil {
+		t.Fatalf("ParseJiraCSV: %v", err)
+	}
+	if bare[1].Ref != "APP-2" || bare[1].ParentRef != "APP-1" {
+		t.Errorf("expected bare keys without a base url, got %+v", bare[1])
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		file string
+		data string
+		want Format
+	}{
+		{"json extension", "issues.json", githubExport, FormatGitHub},
+		{"json content", "issues.txt", githubExport, FormatGitHub},
+		{"gitlab csv", "export.csv", gitlabExport, FormatGitLab},
+		{"jira csv with bom", "jira.csv", jiraExport, FormatJira},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectFormat(tt.file, []byte(tt.data))
+			if err != nil || got != tt.want {
+				t.Errorf("DetectFormat = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := DetectFormat("other.csv", []byte("a,b\n1,2\n")); err == nil {
+		t.Error("expected error for unknown csv")
+	}
+	if _, err := ParseFormat("trello"); err == nil {
+		t.Error("expected error for unknown format name")
+	}
+}
@@ -0,0 +1,143 @@
+// Package importer converts issue exports from other trackers into beads.
+//
+// Each supported format is parsed into Records, which Import then creates
+// through a beads.Client. The original issue URL (or a tracker-specific key
+// when no URL is exported) is stored in the bead's external ref, so running
+// the same import twice only creates what is missing.
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Format identifies an export file layout.
+type Format string
+
+const (
+	FormatGitHub Format = "github" // `gh issue list --json ...` output
+	FormatGitLab Format = "gitlab" // GitLab "Export as CSV"
+	FormatJira   Format = "jira"   // Jira "Export CSV (all fields)"
+)
+
+// Formats lists the supported formats, for flag help and validation.
+var Formats = []Format{FormatGitHub, FormatGitLab, FormatJira}
+
+// Record statuses, matching the beads workflow.
+const (
+	StatusOpen       = "open"
+	StatusInProgress = "in_progress"
+	StatusClosed     = "closed"
+)
+
+// defaultPriority is used when the source tracker has no priority field.
+const defaultPriority = 2
+
+// Record is one issue read from an export, already mapped to bead fields.
+type Record struct {
+	Ref         string // stored as the bead's external ref; unique within an export
+	Title       string
+	Description string
+	IssueType   string
+	Priority    int
+	Status      string
+	Labels      []string
+	Assignee    string
+	ParentRef   string // Ref of the parent, in this export or already imported
+}
+
+// ParseOptions tunes format-specific parsing.
+type ParseOptions struct {
+	// JiraBaseURL turns Jira issue keys into browse URLs, e.g.
+	// "https://acme.atlassian.net" gives "https://acme.atlassian.net/browse/APP-12".
+	// Without it the bare issue key is used as the external ref.
+	JiraBaseURL string
+}
+
+// ParseFormat validates a --format value.
+func ParseFormat(s string) (Format, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q (must be github, gitlab or jira)", s)
+}
+
+// DetectFormat guesses the format from the file name and contents: JSON is
+// a GitHub export, and CSV headers tell Jira ("Issue key") from GitLab
+// ("Issue ID" plus "URL").
+func DetectFormat(name string, data []byte) (Format, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, utf8BOM))
+	if strings.EqualFold(filepath.Ext(name), ".json") || bytes.HasPrefix(trimmed, []byte("[")) {
+		return FormatGitHub, nil
+	}
+	header, err := readCSVHeader(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("detect format: %w", err)
+	}
+	switch {
+	case header.has("Issue key"):
+		return FormatJira, nil
+	case header.has("Issue ID") && header.has("URL"):
+		return FormatGitLab, nil
+	}
+	return "", fmt.Errorf("detect format: unrecognized export, pass --format")
+}
+
+// Parse reads an export in the given format.
+func Parse(format Format, data []byte, opts ParseOptions) ([]Record, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+	switch format {
+	case FormatGitHub:
+		return ParseGitHubJSON(data)
+	case FormatGitLab:
+		return ParseGitLabCSV(data)
+	case FormatJira:
+		return ParseJiraCSV(data, opts.JiraBaseURL)
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// typeFromLabels picks a bead type from tracker labels, defaulting to task.
+func typeFromLabels(labels []string) string {
+	for _, l := range labels {
+		if t := mapIssueType(l); t != "task" {
+			return t
+		}
+	}
+	return "task"
+}
+
+// mapIssueType maps a tracker issue type or label onto a bead type.
+func mapIssueType(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "bug", "defect", "type::bug", "type: bug":
+		return "bug"
+	case "feature", "enhancement", "story", "new feature", "improvement", "type::feature", "type: feature":
+		return "feature"
+	case "epic":
+		return "epic"
+	case "chore", "maintenance":
+		return "chore"
+	}
+	return "task"
+}
+
+// cleanLabels trims labels, drops empties and removes duplicates.
+func cleanLabels(labels []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, l := range labels {
+		l = strings.TrimSpace(l)
+		if l == "" || seen[l] {
+			continue
+		}
+		seen[l] = true
+		out = append(out, l)
+	}
+	return out
+}
@@ -0,0 +1,182 @@
+// Package mcp serves beads to coding agents over the Model Context Protocol.
+//
+// The server speaks JSON-RPC 2.0 as newline-delimited messages on a reader
+// and writer (stdin and stdout for `abacus mcp`) and implements the tools
+// capability only. Tools run through api.Service, so agents get the same
+// status rules and ready/blocked analysis as the TUI and the HTTP API.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"abacus/internal/api"
+)
+
+// protocolVersion is the newest MCP revision the server implements.
+const protocolVersion = "2025-06-18"
+
+// supportedVersions are the revisions the server can speak; a client asking
+// for one of them gets it back, any other request gets protocolVersion.
+var supportedVersions = []string{"2024-11-05", "2025-03-26", protocolVersion}
+
+// maxMessageSize bounds a single JSON-RPC message.
+const maxMessageSize = 4 << 20
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// isNotification reports whether the client expects no response.
+func (r request) isNotification() bool {
+	return len(r.ID) == 0
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// Server answers MCP requests with tools backed by an api.Service.
+type Server struct {
+	version string
+	tools   []tool
+	out     io.Writer
+}
+
+// NewServer returns a server for svc that reports version as its own.
+func NewServer(svc *api.Service, version string) *Server {
+	return &Server{version: version, tools: newTools(svc)}
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted
+// or ctx is done. Requests are handled one at a time, in order.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.out = w
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if err := s.handleMessage(ctx, line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read request: %w", err)
+	}
+	return nil
+}
+
+// handleMessage answers one message. Only write failures are returned;
+// protocol errors are reported to the client.
+func (s *Server) handleMessage(ctx context.Context, line []byte) error {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return s.write(response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: "parse error: " + err.Error()}})
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		if req.isNotification() {
+			return nil
+		}
+		return s.write(response{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{Code: codeInvalidRequest, Message: "invalid request"}})
+	}
+
+	result, err := s.dispatch(ctx, req)
+	if req.isNotification() {
+		return nil
+	}
+	resp := response{JSONRPC: "2.0", ID: req.ID, Result: result}
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &rpcError{Code: codeInternalError, Message: err.Error()}
+		}
+		resp.Result = nil
+		resp.Error = rpcErr
+	}
+	return s.write(resp)
+}
+
+func (s *Server) dispatch(ctx context.Context, req request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		return s.initialize(req.Params)
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools(), nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	default:
+		// Notifications such as notifications/initialized need no handling.
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+func (s *Server) initialize(params json.RawMessage) (any, error) {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: "invalid initialize params: " + err.Error()}
+		}
+	}
+	version := protocolVersion
+	if slices.Contains(supportedVersions, p.ProtocolVersion) {
+		version = p.ProtocolVersion
+	}
+	return map[string]any{
+		"protocolVersion": version,
+		"capabilities":    map[string]any{"tools": map[string]any{}},
+		"serverInfo":      map[string]string{"name": "abacus", "version": s.version},
+		"instructions": "Tools for the beads issue tracker in the current project. " +
+			"Use list_ready to find unblocked work, show_bead for details and blockers, " +
+			"and update_status to claim (in_progress) or close beads.",
+	}, nil
+}
+
+func (s *Server) write(resp response) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("encode response: %w", err)
+	}
+	if _, err := s.out.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"abacus/internal/api"
+	"abacus/internal/beads"
+)
+
+func newTestClient() *beads.MockClient {
+	issues := []beads.FullIssue{
+		{ID: "ab-1", Title: "Epic", Status: "open", IssueType: "epic", Priority: 1},
+		{ID: "ab-2", Title: "Child", Status: "open", IssueType: "task", Priority: 2,
+			Dependencies: []beads.Dependency{{TargetID: "ab-1", Type: "parent-child"}}},
+		{ID: "ab-3", Title: "Done", Status: "closed", IssueType: "task", Priority: 2},
+	}
+	mock := beads.NewMockClient()
+	mock.ExportFn = func(context.Context) ([]beads.FullIssue, error) { return issues, nil }
+	mock.ShowFn = func(_ context.Context, ids []string) ([]beads.FullIssue, error) {
+		var out []beads.FullIssue
+		for _, iss := range issues {
+			for _, id := range ids {
+				if iss.ID == id {
+					out = append(out, iss)
+				}
+			}
+		}
+		return out, nil
+	}
+	return mock
+}
+
+// exchange sends each message to a fresh server and returns the decoded
+// responses in order.
+func exchange(t *testing.T, client beads.Client, messages ...string) []response {
+	t.Helper()
+	var out bytes.Buffer
+	server := NewServer(api.NewService(client), "test")
+	if err := server.Serve(context.Background(), strings.NewReader(strings.Join(messages, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	var responses []response
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp response
+		if err := dec.Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+// toolText returns the text content and error flag of a tools/call result.
+func toolText(t *testing.T, resp response) (string, bool) {
+	t.Helper()
+	if resp.Error != nil {
+		t.Fatalf("unexpected protocol error: %+v", resp.Error)
+	}
+	var result struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+	data, _ := json.Marshal(resp.Result)
+	if err := json.Unmarshal(data, &result); err != nil || len(result.Content) != 1 {
+		t.Fatalf("unexpected tool result %s (%v)", data, err)
+	}
+	return result.Content[0].Text, result.IsError
+}
+
+func TestInitializeAndListTools(t *testing.T) {
+	responses := exchange(t, newTestClient(),
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"agent","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+	)
+	if len(responses) != 2 {
+		t.Fatalf("expected responses to the two requests only, got %d", len(responses))
+	}
+	initResult, _ := responses[0].Result.(map[string]any)
+	if initResult["protocolVersion"] != "2025-03-26" {
+		t.Fatalf("expected the client's protocol version to be echoed, got %v", initResult["protocolVersion"])
+	}
+
+	list, _ := responses[1].Result.(map[string]any)
+	tools, _ := list["tools"].([]any)
+	var names []string
+	for _, tl := range tools {
+		names = append(names, tl.(map[string]any)["name"].(string))
+	}
+	want := "list_ready,show_bead,tree_for_epic,create_bead,update_status,add_comment,add_dependency"
+	if strings.Join(names, ",") != want {
+		t.Fatalf("tools = %v, want %s", names, want)
+	}
+}
+
+func TestToolCalls(t *testing.T) {
+	mock := newTestClient()
+	responses := exchange(t, mock,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_ready","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"tree_for_epic","arguments":{"id":"ab-1"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"update_status","arguments":{"id":"ab-2","status":"in_progress"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"update_status","arguments":{"id":"ab-2","status":"closed","reason":"done"}}}`,
+	)
+
+	text, isError := toolText(t, responses[0])
+	if isError || !strings.Contains(text, `"id": "ab-2"`) || strings.Contains(text, `"id": "ab-3"`) {
+		t.Fatalf("unexpected ready list: %s", text)
+	}
+	text, _ = toolText(t, responses[1])
+	if !strings.Contains(text, `"total": 1`) {
+		t.Fatalf("expected epic rollup in tree, got %s", text)
+	}
+	if _, isError := toolText(t, responses[2]); isError || mock.UpdateStatusCallCount != 1 {
+		t.Fatalf("expected status update, isError=%v calls=%d", isError, mock.UpdateStatusCallCount)
+	}
+	if _, isError := toolText(t, responses[3]); isError || mock.CloseWithReasonCallCount != 1 {
+		t.Fatalf("expected close with reason, isError=%v calls=%d", isError, mock.CloseWithReasonCallCount)
+	}
+}
+
+func TestToolErrors(t *testing.T) {
+	mock := newTestClient()
+	responses := exchange(t, mock,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"update_status","arguments":{"id":"ab-3","status":"in_progress"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"show_bead","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"nope"}}`,
+		`not json`,
+	)
+
+	text, isError := toolText(t, responses[0])
+	if !isError || mock.UpdateStatusCallCount != 0 {
+		t.Fatalf("expected closed -> in_progress to be rejected, got %q", text)
+	}
+	if text, isError := toolText(t, responses[1]); !isError || !strings.Contains(text, "id") {
+		t.Fatalf("expected missing id error, got %q", text)
+	}
+	if responses[2].Error == nil || responses[2].Error.Code != codeInvalidParams {
+		t.Fatalf("expected invalid params for unknown tool, got %+v", responses[2])
+	}
+	if responses[3].Error == nil || responses[3].Error.Code != codeParseError {
+		t.Fatalf("expected parse error, got %+v", responses[3])
+	}
+}
+
+func TestServerFailureIsInternalError(t *testing.T) {
+	server := NewServer(api.NewService(newTestClient()), "test")
+	server.tools = append(server.tools, tool{
+		Name: "broken",
+		run: func(context.Context, json.RawMessage) (any, error) {
+			return map[string]any{"ch": make(chan int)}, nil
+		},
+	})
+	var out bytes.Buffer
+	in := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"broken"}}` + "\n"
+	if err := server.Serve(context.Background(), strings.NewReader(in), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	var resp response
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != codeInternalError {
+		t.Fatalf("expected internal error, got %+v", resp)
+	}
+}
@@ -0,0 +1,271 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"abacus/internal/api"
+	"abacus/internal/domain"
+)
+
+// tool is one MCP tool: its advertised definition and the function that
+// runs it with the call's decoded arguments.
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+	run         func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// idArgs are the arguments of tools that act on one bead.
+type idArgs struct {
+	ID string `json:"id"`
+}
+
+type updateStatusArgs struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+type addCommentArgs struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+type addDependencyArgs struct {
+	ID        string `json:"id"`
+	DependsOn string `json:"depends_on"`
+	Type      string `json:"type"`
+}
+
+// beadDetail is show_bead's result: the bead and where it stands.
+type beadDetail struct {
+	api.Blockers
+	Issue any `json:"issue"`
+}
+
+func newTools(svc *api.Service) []tool {
+	statuses := []string{
+		string(domain.StatusOpen),
+		string(domain.StatusInProgress),
+		string(domain.StatusBlocked),
+		string(domain.StatusDeferred),
+		string(domain.StatusClosed),
+	}
+	return []tool{
+		{
+			Name:        "list_ready",
+			Description: "List open beads with no open blockers, most urgent first. These are the beads that can be worked on now.",
+			InputSchema: objectSchema(nil),
+			run: func(ctx context.Context, _ json.RawMessage) (any, error) {
+				return svc.Ready(ctx)
+			},
+		},
+		{
+			Name:        "show_bead",
+			Description: "Show a bead's full details, whether it is blocked, the open beads blocking it and the beads it blocks.",
+			InputSchema: objectSchema(map[string]any{"id": stringProp("Bead ID, e.g. ab-12")}, "id"),
+			run: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var args idArgs
+				if err := decodeArgs(raw, &args, "id"); err != nil {
+					return nil, err
+				}
+				issue, err := svc.Issue(ctx, args.ID)
+				if err != nil {
+					return nil, err
+				}
+				blockers, err := svc.Blockers(ctx, args.ID)
+				if err != nil {
+					return nil, err
+				}
+				return beadDetail{Blockers: blockers, Issue: issue}, nil
+			},
+		},
+		{
+			Name:        "tree_for_epic",
+			Description: "Show the tree of beads under an epic (or any bead) with per-node counts of total, closed, in-progress, blocked and ready descendants.",
+			InputSchema: objectSchema(map[string]any{"id": stringProp("ID of the epic or parent bead")}, "id"),
+			run: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var args idArgs
+				if err := decodeArgs(raw, &args, "id"); err != nil {
+					return nil, err
+				}
+				tree, err := svc.Tree(ctx, args.ID)
+				if err != nil {
+					return nil, err
+				}
+				return tree[0], nil
+			},
+		},
+		{
+			Name:        "create_bead",
+			Description: "Create a bead, optionally as a child of parent_id. Returns the created bead.",
+			InputSchema: objectSchema(map[string]any{
+				"title":       stringProp("Title"),
+				"issue_type":  enumProp("Type (default task)", []string{"task", "bug", "feature", "epic", "chore"}),
+				"priority":    map[string]any{"type": "integer", "minimum": 0, "maximum": 4, "description": "Priority, 0 (critical) to 4 (backlog); default 2"},
+				"labels":      map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Labels"},
+				"assignee":    stringProp("Assignee"),
+				"description": stringProp("Markdown description"),
+				"parent_id":   stringProp("ID of the parent bead"),
+			}, "title"),
+			run: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var req api.CreateRequest
+				if err := decodeArgs(raw, &req); err != nil {
+					return nil, err
+				}
+				return svc.Create(ctx, req)
+			},
+		},
+		{
+			Name: "update_status",
+			Description: "Change a bead's status. Use in_progress to claim a bead and closed (with an optional reason) to finish it. " +
+				"Transitions the tracker does not allow are rejected.",
+			InputSchema: objectSchema(map[string]any{
+				"id":     stringProp("Bead ID"),
+				"status": enumProp("New status", statuses),
+				"reason": stringProp("Close reason, used when status is closed"),
+			}, "id", "status"),
+			run: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var args updateStatusArgs
+				if err := decodeArgs(raw, &args, "id", "status"); err != nil {
+					return nil, err
+				}
+				var err error
+				if args.Status == string(domain.StatusClosed) {
+					err = svc.CloseIssue(ctx, args.ID, args.Reason)
+				} else {
+					err = svc.SetStatus(ctx, args.ID, args.Status)
+				}
+				if err != nil {
+					return nil, err
+				}
+				return svc.Issue(ctx, args.ID)
+			},
+		},
+		{
+			Name:        "add_comment",
+			Description: "Add a comment to a bead.",
+			InputSchema: objectSchema(map[string]any{
+				"id":   stringProp("Bead ID"),
+				"text": stringProp("Comment text (markdown)"),
+			}, "id", "text"),
+			run: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var args addCommentArgs
+				if err := decodeArgs(raw, &args, "id", "text"); err != nil {
+					return nil, err
+				}
+				if err := svc.AddComment(ctx, args.ID, args.Text); err != nil {
+					return nil, err
+				}
+				return fmt.Sprintf("Commented on %s", args.ID), nil
+			},
+		},
+		{
+			Name:        "add_dependency",
+			Description: "Record that a bead depends on another. With the default type blocks, id cannot be ready until depends_on is closed.",
+			InputSchema: objectSchema(map[string]any{
+				"id":         stringProp("ID of the dependent bead"),
+				"depends_on": stringProp("ID of the bead it depends on"),
+				"type":       enumProp("Dependency type (default blocks)", []string{"blocks", "parent-child", "related", "discovered-from"}),
+			}, "id", "depends_on"),
+			run: func(ctx context.Context, raw json.RawMessage) (any, error) {
+				var args addDependencyArgs
+				if err := decodeArgs(raw, &args, "id", "depends_on"); err != nil {
+					return nil, err
+				}
+				if err := svc.AddDependency(ctx, args.ID, args.DependsOn, args.Type); err != nil {
+					return nil, err
+				}
+				return fmt.Sprintf("%s now depends on %s", args.ID, args.DependsOn), nil
+			},
+		},
+	}
+}
+
+func (s *Server) listTools() any {
+	return map[string]any{"tools": s.tools}
+}
+
+// callTool runs a tool. Failures of the tool itself (unknown beads, invalid
+// transitions, backend errors) are returned as error results the agent can
+// read and react to; only malformed calls are protocol errors.
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (any, error) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid tools/call params: " + err.Error()}
+	}
+	for _, t := range s.tools {
+		if t.Name != p.Name {
+			continue
+		}
+		result, err := t.run(ctx, p.Arguments)
+		if err != nil {
+			return toolResult(err.Error(), true), nil
+		}
+		if text, ok := result.(string); ok {
+			return toolResult(text, false), nil
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encode %s result: %w", p.Name, err)
+		}
+		return toolResult(string(data), false), nil
+	}
+	return nil, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + p.Name}
+}
+
+func toolResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+// decodeArgs decodes tool arguments into v and checks that the named string
+// fields were given.
+func decodeArgs(raw json.RawMessage, v any, required ...string) error {
+	if len(raw) == 0 {
+		raw = json.RawMessage("{}")
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	var fields map[string]any
+	_ = json.Unmarshal(raw, &fields)
+	var missing []string
+	for _, name := range required {
+		if s, _ := fields[name].(string); strings.TrimSpace(s) == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required arguments: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func objectSchema(props map[string]any, required ...string) map[string]any {
+	if props == nil {
+		props = map[string]any{}
+	}
+	schema := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func stringProp(description string) map[string]any {
+	return map[string]any{"type": "string", "description": description}
+}
+
+func enumProp(description string, values []string) map[string]any {
+	return map[string]any{"type": "string", "enum": values, "description": description}
+}
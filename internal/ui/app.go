@@ -9,6 +9,8 @@ import (
 
 	"abacus/internal/beads"
 	"abacus/internal/config"
+	"abacus/internal/debug"
+	"abacus/internal/gitinfo"
 	"abacus/internal/graph"
 	"abacus/internal/update"
 
@@ -41,6 +43,11 @@ const (
 	OverlayDelete
 	OverlayComment
 	OverlayPriority
+	OverlayAssignee
+	OverlayCommentDelete
+	OverlayLabelManager
+	OverlayOutlineImport
+	OverlayEditConflict
 )
 
 // Layout describes how the tree and detail panes are arranged.
@@ -92,7 +99,7 @@ type Config struct {
 	Client          beads.Client
 	Version         string // Version string to display in header
 	UpdateChan      <-chan *update.UpdateInfo
-	Backend         string // Backend type: "bd" or "br"
+	Backend         string // Backend type: "bd", "br" or a plugin name
 }
 
 // errorSource tracks where the last error originated so refresh success can
@@ -119,10 +126,29 @@ type App struct {
 	ready         bool
 	detailIssueID string
 
+	// Mouse hit-testing state: header and footer heights from the last View,
+	// and the detail content lines that list a related bead.
+	mainBodyTop        int
+	footerHeight       int
+	detailRelatedLines map[int]string
+
+	// Comment cursor in the detail pane; only applies while selectedCommentIssue
+	// matches the bead being shown.
+	selectedCommentIssue string
+	selectedComment      int
+	commentScrollPending bool
+
 	textInput  textinput.Model
 	searching  bool
 	filterText string
 	viewMode   ViewMode // Current view filter mode (All, Active, Ready)
+	sortMode   SortMode // Sibling ordering for the tree and detail relationship lists
+	groupMode  GroupMode
+	myWorkOnly bool // Limit the tree to beads assigned to the current user
+	// collapsedGroups tracks group headers the user has collapsed in group mode.
+	collapsedGroups map[string]bool
+	// groupMemberships counts how many groups each bead appears in (group mode only).
+	groupMemberships map[string]int
 	// filterCollapsed tracks nodes explicitly collapsed while a search filter is active.
 	filterCollapsed map[string]bool
 	// filterForcedExpanded tracks nodes temporarily expanded to surface filter matches.
@@ -146,10 +172,36 @@ type App struct {
 	spinner          spinner.Model
 	outputFormat     string
 	version          string
-	backend          string // Backend type: "bd" or "br"
+	backend          string // Backend type: "bd", "br" or a plugin name
 
 	client beads.Client
 
+	// Git commits and branches mentioning each bead, loaded in the background
+	// from the repository containing gitDir. gitUnavailable hides the detail
+	// section when gitDir is not a git work tree.
+	gitDir         string
+	gitRefs        map[string]gitinfo.Refs
+	gitRefsLoaded  bool
+	gitRefsLoading bool
+	gitRefsError   string
+	gitUnavailable bool
+
+	// Bead history, shown in the detail pane while showHistory is on and
+	// loaded on demand for the selected bead. Cleared on refresh.
+	showHistory bool
+	history     map[string]historyState
+
+	// Writes that failed with a retryable error, persisted under .abacus
+	// and retried oldest first while mutationInFlight is false.
+	mutations        *mutationQueue
+	mutationInFlight bool
+
+	// Queued toast state: writes handed to the retry queue
+	queuedToastVisible bool
+	queuedToastStart   time.Time
+	queuedToastWrites  []pendingMutation
+	queuedToastBehind  bool // waiting behind earlier writes, not failed
+
 	// Error toast state
 	lastError       string // Full error message (separate from stats)
 	lastErrorSource errorSource
@@ -162,6 +214,16 @@ type App struct {
 	copyToastStart time.Time
 	copiedBeadID   string
 
+	// Link toast state
+	linkToastVisible bool
+	linkToastStart   time.Time
+	linkToastURL     string
+
+	// Branch toast state
+	branchToastVisible bool
+	branchToastStart   time.Time
+	branchToastName    string
+
 	// Status toast state
 	statusToastVisible   bool
 	statusToastStart     time.Time
@@ -180,6 +242,26 @@ type App struct {
 	deleteOverlay   *DeleteOverlay
 	commentOverlay  *CommentOverlay
 	priorityOverlay *PriorityOverlay
+	assigneeOverlay *AssigneeOverlay
+
+	commentDeleteOverlay *CommentDeleteOverlay
+	labelManagerOverlay  *LabelManagerOverlay
+	outlineOverlay       *OutlineImportOverlay
+	editConflictOverlay  *EditConflictOverlay // shown over a kept createOverlay
+
+	// commentEditUnsupported is set once the backend refuses a comment
+	// edit or delete, so later attempts fail before the overlay opens.
+	commentEditUnsupported bool
+
+	// Project-wide label change in progress (nil when idle)
+	labelJob             *labelJob
+	labelJobToastVisible bool
+	labelJobToastStart   time.Time
+
+	// Outline import in progress (nil when idle)
+	outlineJob             *outlineJob
+	outlineJobToastVisible bool
+	outlineJobToastStart   time.Time
 
 	// Labels toast state
 	labelsToastVisible bool
@@ -221,6 +303,7 @@ type App struct {
 	commentToastVisible bool
 	commentToastStart   time.Time
 	commentToastBeadID  string
+	commentToastAction  string // "added", "updated" or "deleted"
 
 	// Priority toast state
 	priorityToastVisible     bool
@@ -228,11 +311,26 @@ type App struct {
 	priorityToastBeadID      string
 	priorityToastNewPriority int
 
+	// Assignee toast state
+	assigneeToastVisible bool
+	assigneeToastStart   time.Time
+	assigneeToastBeadID  string
+	assigneeToastName    string
+	assigneeToastTaken   bool // Bead was claimed (assigned to me + in progress)
+
 	// Columns toast state
 	columnsToastVisible bool
 	columnsToastStart   time.Time
 	columnsToastEnabled bool
 
+	// Sort toast state
+	sortToastVisible bool
+	sortToastStart   time.Time
+
+	// Group toast state
+	groupToastVisible bool
+	groupToastStart   time.Time
+
 	// Layout state
 	layout             Layout
 	layoutToastVisible bool
@@ -320,7 +418,8 @@ func NewApp(cfg Config) (*App, error) {
 	}
 
 	repo := "abacus"
-	if wd, err := os.Getwd(); err == nil && wd != "" {
+	wd, err := os.Getwd()
+	if err == nil && wd != "" {
 		repo = filepath.Base(wd)
 	}
 
@@ -341,6 +440,7 @@ func NewApp(cfg Config) (*App, error) {
 		backend:         cfg.Backend,
 		client:          client,
 		dbPath:          dbPath,
+		gitDir:          wd,
 		lastDBModTime:   dbModTime,
 		spinner:         s,
 		keys:            DefaultKeyMap(),
@@ -353,6 +453,10 @@ func NewApp(cfg Config) (*App, error) {
 	if config.GetString(config.KeyLayoutMode) == "tall" {
 		app.layout = LayoutTall
 	}
+	app.sortMode = loadSortMode(app.viewMode)
+	if app.mutations, err = loadMutationQueue(app.pendingMutationsPath()); err != nil {
+		debug.Logf("mutation queue: %v", err)
+	}
 	app.recalcVisibleRows()
 	// Capture initial stats for session summary
 	app.initialStats = app.getStats()
@@ -370,6 +474,11 @@ func (m *App) Init() tea.Cmd {
 	}
 	// Start background comment loading after TUI is displayed (ab-fkyz)
 	cmds = append(cmds, scheduleBackgroundCommentLoad())
+	cmds = append(cmds, m.loadGitRefs())
+	// Resume writes left pending by a previous session
+	if m.mutations.Len() > 0 {
+		cmds = append(cmds, scheduleMutationRetry(0))
+	}
 	// Start waiting for update check result (ab-a4qc)
 	if m.updateChan != nil {
 		cmds = append(cmds, m.waitForUpdateCheck())
@@ -377,6 +486,14 @@ func (m *App) Init() tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+// Shutdown releases the client's open connections once the program exits.
+func (m *App) Shutdown() error {
+	if m.client == nil {
+		return nil
+	}
+	return beads.ShutdownClient(m.client)
+}
+
 func (m *App) applyViewportTheme() {
 	m.viewport.Style = baseStyle()
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"abacus/internal/beads"
+	"abacus/internal/config"
 	"abacus/internal/graph"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -646,3 +647,116 @@ func TestTreeEndKeySafeWhenNoVisibleRows(t *testing.T) {
 	app.ShowDetails = true
 	app.updateViewportContent()
 }
+
+func TestMyWorkFilterShowsOnlyMyBeads(t *testing.T) {
+	t.Setenv("USER", "tester")
+	mine := &graph.Node{Issue: beads.FullIssue{ID: "ab-mine", Title: "Mine", Status: "open", Assignee: "tester"}}
+	theirs := &graph.Node{Issue: beads.FullIssue{ID: "ab-theirs", Title: "Theirs", Status: "open", Assignee: "alice"}}
+	parent := &graph.Node{
+		Issue:    beads.FullIssue{ID: "ab-parent", Title: "Parent", Status: "open"},
+		Children: []*graph.Node{mine, theirs},
+	}
+	m := buildTreeTestApp(parent)
+	m.keys = DefaultKeyMap()
+
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	m = result.(*App)
+	if !m.myWorkOnly {
+		t.Fatal("expected my-work filter to be enabled")
+	}
+
+	ids := make([]string, 0, len(m.visibleRows))
+	for _, row := range m.visibleRows {
+		ids = append(ids, row.Node.Issue.ID)
+	}
+	if len(ids) != 2 || ids[0] != "ab-parent" || ids[1] != "ab-mine" {
+		t.Fatalf("expected parent context plus my bead, got %v", ids)
+	}
+
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	m = result.(*App)
+	if m.myWorkOnly {
+		t.Fatal("expected my-work filter to toggle off")
+	}
+}
+
+func setTestIdentity(t *testing.T, name string, aliases []string) {
+	t.Helper()
+	if err := config.Set(config.KeyIdentityName, name); err != nil {
+		t.Fatalf("set identity name: %v", err)
+	}
+	if err := config.Set(config.KeyIdentityAliases, aliases); err != nil {
+		t.Fatalf("set identity aliases: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = config.Set(config.KeyIdentityName, "")
+		_ = config.Set(config.KeyIdentityAliases, []string{})
+	})
+}
+
+func TestIdentityConfigOverridesUser(t *testing.T) {
+	t.Setenv("USER", "shell-user")
+	setTestIdentity(t, "alice", []string{"alice@example.com", "asmith"})
+
+	if got := currentUser(); got != "alice" {
+		t.Fatalf("expected configured identity, got %q", got)
+	}
+	for _, assignee := range []string{"alice", "ASmith", "alice@example.com"} {
+		node := &graph.Node{Issue: beads.FullIssue{ID: "ab-1", Assignee: assignee}}
+		if !isAssignedToMe(node) {
+			t.Fatalf("expected %q to match identity", assignee)
+		}
+	}
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-1", Assignee: "shell-user"}}
+	if isAssignedToMe(node) {
+		t.Fatal("expected $USER to be ignored when identity.name is set")
+	}
+}
+
+func TestAssigneeFilterToken(t *testing.T) {
+	setTestIdentity(t, "alice", []string{"asmith"})
+	mine := &graph.Node{Issue: beads.FullIssue{ID: "ab-mine", Title: "Fix login", Assignee: "asmith"}}
+	bobs := &graph.Node{Issue: beads.FullIssue{ID: "ab-bob", Title: "Fix logout", Assignee: "bob"}}
+
+	tests := []struct {
+		filter string
+		node   *graph.Node
+		want   bool
+	}{
+		{"assignee:me", mine, true},
+		{"assignee:me", bobs, false},
+		{"assignee:bob", bobs, true},
+		{"assignee:me login", mine, true},
+		{"assignee:me logout", mine, false},
+		{"fix assignee:bob", bobs, true},
+	}
+	for _, tt := range tests {
+		if got := nodeMatchesFilter(tt.filter, tt.node); got != tt.want {
+			t.Errorf("nodeMatchesFilter(%q, %s) = %v, want %v", tt.filter, tt.node.Issue.ID, got, tt.want)
+		}
+	}
+}
+
+func TestRefFilterToken(t *testing.T) {
+	linked := &graph.Node{Issue: beads.FullIssue{ID: "ab-gh", Title: "Port crash fix", ExternalRef: "https://github.com/acme/app/issues/7"}}
+	plain := &graph.Node{Issue: beads.FullIssue{ID: "ab-local", Title: "Port docs"}}
+
+	tests := []struct {
+		filter string
+		node   *graph.Node
+		want   bool
+	}{
+		{"ref:any", linked, true},
+		{"ref:any", plain, false},
+		{"ref:none", plain, true},
+		{"ref:none", linked, false},
+		{"ref:github.com/acme", linked, true},
+		{"ref:jira", linked, false},
+		{"port ref:none", plain, true},
+	}
+	for _, tt := range tests {
+		if got := nodeMatchesFilter(tt.filter, tt.node); got != tt.want {
+			t.Errorf("nodeMatchesFilter(%q, %s) = %v, want %v", tt.filter, tt.node.Issue.ID, got, tt.want)
+		}
+	}
+}
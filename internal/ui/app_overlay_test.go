@@ -1023,3 +1023,104 @@ func TestPriorityToastLayerRendersContent(t *testing.T) {
 		t.Errorf("expected countdown like [Ns], got:\n%s", rendered)
 	}
 }
+
+func TestAssigneeKeyOpensOverlay(t *testing.T) {
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-400", Title: "Test", Assignee: "alice"}}
+	app := &App{
+		visibleRows: nodesToRows(node),
+		roots:       []*graph.Node{node},
+		keys:        DefaultKeyMap(),
+		ready:       true,
+	}
+
+	result, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	app = result.(*App)
+
+	if app.activeOverlay != OverlayAssignee {
+		t.Errorf("expected activeOverlay OverlayAssignee, got %v", app.activeOverlay)
+	}
+	if app.assigneeOverlay == nil || app.assigneeOverlay.currentAssignee != "alice" {
+		t.Error("expected assigneeOverlay for current assignee alice")
+	}
+}
+
+func TestAssigneeChangedMsgUpdatesBackend(t *testing.T) {
+	mock := beads.NewMockClient()
+	app := &App{
+		keys:            DefaultKeyMap(),
+		client:          mock,
+		ready:           true,
+		activeOverlay:   OverlayAssignee,
+		assigneeOverlay: NewAssigneeOverlay("ab-401", "T", "", nil),
+	}
+
+	result, cmd := app.Update(AssigneeChangedMsg{IssueID: "ab-401", Assignee: "bob"})
+	app = result.(*App)
+
+	if app.activeOverlay != OverlayNone || app.assigneeOverlay != nil {
+		t.Error("expected overlay to be closed")
+	}
+	if !app.assigneeToastVisible || app.assigneeToastName != "bob" {
+		t.Errorf("expected assignee toast for bob, got visible=%v name=%q", app.assigneeToastVisible, app.assigneeToastName)
+	}
+	if cmd == nil {
+		t.Fatal("expected batch cmd, got nil")
+	}
+
+	msg := app.executeAssigneeChangeCmd("ab-401", "bob")()
+	if complete, ok := msg.(assigneeUpdateCompleteMsg); !ok || complete.err != nil {
+		t.Fatalf("expected successful assigneeUpdateCompleteMsg, got %#v", msg)
+	}
+	if len(mock.UpdateAssigneeCallArgs) != 1 || mock.UpdateAssigneeCallArgs[0][1] != "bob" {
+		t.Errorf("expected UpdateAssignee(ab-401, bob), got %v", mock.UpdateAssigneeCallArgs)
+	}
+}
+
+func TestTakeBeadAssignsMeAndStarts(t *testing.T) {
+	t.Setenv("USER", "tester")
+	mock := beads.NewMockClient()
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-402", Title: "Claim me", Status: "open"}}
+	app := &App{
+		visibleRows: nodesToRows(node),
+		keys:        DefaultKeyMap(),
+		client:      mock,
+		ready:       true,
+	}
+
+	result, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	app = result.(*App)
+	if cmd == nil {
+		t.Fatal("expected take command")
+	}
+	if !app.assigneeToastVisible || !app.assigneeToastTaken {
+		t.Error("expected take toast to be visible")
+	}
+
+	msg := app.executeTakeBeadCmd("ab-402", "tester")()
+	if complete, ok := msg.(assigneeUpdateCompleteMsg); !ok || complete.err != nil {
+		t.Fatalf("expected successful assigneeUpdateCompleteMsg, got %#v", msg)
+	}
+	if len(mock.UpdateAssigneeCallArgs) != 1 || mock.UpdateAssigneeCallArgs[0][1] != "tester" {
+		t.Errorf("expected UpdateAssignee to tester, got %v", mock.UpdateAssigneeCallArgs)
+	}
+	if len(mock.UpdateStatusCallArgs) != 1 || mock.UpdateStatusCallArgs[0][1] != "in_progress" {
+		t.Errorf("expected UpdateStatus to in_progress, got %v", mock.UpdateStatusCallArgs)
+	}
+}
+
+func TestTakeBeadWithoutUserShowsError(t *testing.T) {
+	t.Setenv("USER", "")
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-403", Title: "Claim me", Status: "open"}}
+	app := &App{
+		visibleRows: nodesToRows(node),
+		keys:        DefaultKeyMap(),
+		client:      beads.NewMockClient(),
+		ready:       true,
+	}
+
+	result, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	app = result.(*App)
+	if !app.showErrorToast {
+		t.Error("expected error toast when current user is unknown")
+	}
+}
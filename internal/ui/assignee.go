@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"abacus/internal/config"
+	"abacus/internal/graph"
+)
+
+const (
+	assigneeOptionUnassigned = "Unassigned"
+	assigneeOptionMePrefix   = "Me ("
+)
+
+// currentUser returns the name used for "Me" in assignee pickers and for
+// claiming beads: identity.name from config, falling back to $USER.
+// Empty when the user cannot be determined.
+func currentUser() string {
+	if name := strings.TrimSpace(config.GetString(config.KeyIdentityName)); name != "" {
+		return name
+	}
+	return strings.TrimSpace(os.Getenv("USER"))
+}
+
+// currentIdentities returns every name that counts as the current user:
+// the primary name followed by the configured identity.aliases.
+func currentIdentities() []string {
+	var names []string
+	if user := currentUser(); user != "" {
+		names = append(names, user)
+	}
+	for _, alias := range config.GetStringSlice(config.KeyIdentityAliases) {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			names = append(names, alias)
+		}
+	}
+	return names
+}
+
+// isMe reports whether name matches any of the current user's identities.
+func isMe(name string) bool {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false
+	}
+	for _, identity := range currentIdentities() {
+		if strings.EqualFold(name, identity) {
+			return true
+		}
+	}
+	return false
+}
+
+// assigneeOptions builds the picker list: "Unassigned", "Me (<user>)" when
+// the user is known, then the known assignees.
+func assigneeOptions(known []string) []string {
+	opts := []string{assigneeOptionUnassigned}
+	if user := currentUser(); user != "" {
+		opts = append(opts, fmt.Sprintf("Me (%s)", user))
+	}
+	return append(opts, known...)
+}
+
+// normalizeAssigneeOption converts a picker value back to an assignee name.
+func normalizeAssigneeOption(value string) string {
+	value = strings.TrimSpace(value)
+	if value == assigneeOptionUnassigned {
+		return ""
+	}
+	if strings.HasPrefix(value, assigneeOptionMePrefix) && strings.HasSuffix(value, ")") {
+		return strings.TrimSuffix(strings.TrimPrefix(value, assigneeOptionMePrefix), ")")
+	}
+	return value
+}
+
+// assigneeOptionFor returns the picker value that represents assignee.
+func assigneeOptionFor(assignee string) string {
+	if strings.TrimSpace(assignee) == "" {
+		return assigneeOptionUnassigned
+	}
+	return assignee
+}
+
+// isAssignedToMe reports whether a node is assigned to the current user
+// under their primary name or any alias.
+func isAssignedToMe(n *graph.Node) bool {
+	return isMe(n.Issue.Assignee)
+}
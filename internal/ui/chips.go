@@ -445,8 +445,10 @@ func renderPillChip(label string, state chipState) string {
 		bgColor = t.Warning() // Orange flash for duplicate
 		fgColor = t.Text()
 	default:
-		bgColor = t.Info()       // Try Info (cyan/teal) for visible labels
+		style := resolveLabelStyle(label)
+		bgColor = style.color    // Configured or hash-derived theme color
 		fgColor = t.Background() // Use background color for contrast
+		label = labelChipText(label, style)
 	}
 
 	// Left cap: foreground is the chip color (creates the curved colored edge)
@@ -50,3 +50,10 @@ func childWord(count int) string {
 	}
 	return "children"
 }
+
+func beadWord(count int) string {
+	if count == 1 {
+		return "bead"
+	}
+	return "beads"
+}
@@ -7,6 +7,7 @@ import (
 	"abacus/internal/graph"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/muesli/reflow/wordwrap"
 )
 
@@ -21,9 +22,14 @@ func (m *App) updateViewportContent() {
 	}
 	if len(m.visibleRows) == 0 || m.cursor < 0 || m.cursor >= len(m.visibleRows) {
 		m.viewport.SetContent("")
+		m.detailRelatedLines = nil
 		return
 	}
 	node := m.visibleRows[m.cursor].Node
+	if isGroupNode(node) {
+		m.updateGroupDetailContent(node)
+		return
+	}
 
 	// Comments are loaded asynchronously in background (ab-fkyz).
 	// Do NOT block navigation - show loading state if comments not ready.
@@ -77,7 +83,27 @@ func (m *App) updateViewportContent() {
 		makeRow("Priority:", stylePrio().Render(prioLabel)),
 	}
 	if iss.ExternalRef != "" {
-		col2 = append(col2, makeRow("Ext Ref:", iss.ExternalRef))
+		ref := iss.ExternalRef
+		if url, ok := externalRefURL(ref); ok {
+			ref = hyperlink(url, ref)
+		}
+		col2 = append(col2, makeRow("Ext Ref:", ref))
+	}
+	if iss.DueAt != "" {
+		due := formatScheduleDate(iss.DueAt)
+		switch state := issueDueState(iss, timeNow()); state {
+		case DueStateOverdue:
+			due += " " + styleDueIndicator(state).Render("(overdue)")
+		case DueStateSoon:
+			due += " " + styleDueIndicator(state).Render("(due soon)")
+		}
+		col2 = append(col2, makeRow("Due:", due))
+	}
+	if iss.DeferUntil != "" {
+		col2 = append(col2, makeRow("Deferred:", formatScheduleDate(iss.DeferUntil)))
+	}
+	if iss.EstimatedMinutes > 0 {
+		col2 = append(col2, makeRow("Estimate:", formatEstimate(iss.EstimatedMinutes)))
 	}
 
 	if len(iss.Labels) > 0 {
@@ -131,67 +157,48 @@ func (m *App) updateViewportContent() {
 	relSections := make([]string, 0, 6)
 
 	renderRelSection := func(title string, items []*graph.Node) string {
-		if len(items) == 0 {
-			return ""
-		}
-		const extraPadding = 2
-		rowWidth := vpWidth - detailSectionContentIndent - extraPadding
-		if rowWidth < 1 {
-			rowWidth = 1
-		}
-		rows := make([]string, 0, len(items))
-		for _, item := range items {
-			icon, iconStyle, titleStyle := relatedStatusPresentation(item)
-			row := renderRefRowWithIcon(
-				icon,
-				iconStyle,
-				item.Issue.ID,
-				item.Issue.Title,
-				rowWidth,
-				styleID(),
-				titleStyle,
-			)
-			rows = append(rows, row)
-		}
-		return renderContentSection(title, strings.Join(rows, "\n"))
+		return renderRelationshipSection(title, items, vpWidth)
 	}
 
 	// Part Of - show ALL parents (parent-child relationships)
 	if len(node.Parents) > 0 {
-		if section := renderRelSection(fmt.Sprintf("Part Of: (%d)", len(node.Parents)), node.Parents); section != "" {
+		sorted := sortNodesByMode(m.sortMode, node.Parents)
+		if section := renderRelSection(fmt.Sprintf("Part Of: (%d)", len(node.Parents)), sorted); section != "" {
 			relSections = append(relSections, section)
 		}
 	}
 	// Subtasks - children of this node (sorted: in_progress → ready → blocked → closed)
 	if len(node.Children) > 0 {
-		sorted := sortSubtasks(node.Children)
+		sorted := m.sortRelationships(node.Children, sortSubtasks)
 		if section := renderRelSection(fmt.Sprintf("Subtasks: (%d)", len(node.Children)), sorted); section != "" {
 			relSections = append(relSections, section)
 		}
 	}
 	// Must Complete First - blockers (sorted: topological order, things to do first)
 	if len(node.BlockedBy) > 0 {
-		sorted := sortBlockers(node.BlockedBy)
+		sorted := m.sortRelationships(node.BlockedBy, sortBlockers)
 		if section := renderRelSection(fmt.Sprintf("Must Complete First: (%d)", len(node.BlockedBy)), sorted); section != "" {
 			relSections = append(relSections, section)
 		}
 	}
 	// Will Unblock - what this issue blocks (sorted: items becoming ready first)
 	if len(node.Blocks) > 0 {
-		sorted := sortBlocked(node.Blocks)
+		sorted := m.sortRelationships(node.Blocks, sortBlocked)
 		if section := renderRelSection(fmt.Sprintf("Will Unblock: (%d)", len(node.Blocks)), sorted); section != "" {
 			relSections = append(relSections, section)
 		}
 	}
 	// See Also - related issues (bidirectional soft links)
 	if len(node.Related) > 0 {
-		if section := renderRelSection(fmt.Sprintf("See Also: (%d)", len(node.Related)), node.Related); section != "" {
+		sorted := sortNodesByMode(m.sortMode, node.Related)
+		if section := renderRelSection(fmt.Sprintf("See Also: (%d)", len(node.Related)), sorted); section != "" {
 			relSections = append(relSections, section)
 		}
 	}
 	// Discovered While Working On - issues that led to discovering this one
 	if len(node.DiscoveredFrom) > 0 {
-		if section := renderRelSection(fmt.Sprintf("Discovered While Working On: (%d)", len(node.DiscoveredFrom)), node.DiscoveredFrom); section != "" {
+		sorted := sortNodesByMode(m.sortMode, node.DiscoveredFrom)
+		if section := renderRelSection(fmt.Sprintf("Discovered While Working On: (%d)", len(node.DiscoveredFrom)), sorted); section != "" {
 			relSections = append(relSections, section)
 		}
 	}
@@ -208,8 +215,10 @@ func (m *App) updateViewportContent() {
 		}
 	}
 	relBlock := joinDetailSections(relSections...)
+	gitBlock := m.renderGitSection(iss.ID, vpWidth)
+	historyBlock := m.renderHistorySection(iss.ID, vpWidth)
 
-	renderMarkdown := buildMarkdownRenderer(m.outputFormat, vpWidth-2)
+	renderMarkdown := linkifyRenderer(buildMarkdownRenderer(m.outputFormat, vpWidth-2))
 	descSections := make([]string, 0, 5)
 	if strings.TrimSpace(iss.CloseReason) != "" {
 		descSections = append(descSections, renderContentSection("Close Reason:", renderMarkdown(iss.CloseReason)))
@@ -224,6 +233,7 @@ func (m *App) updateViewportContent() {
 	if strings.TrimSpace(iss.Notes) != "" {
 		descSections = append(descSections, renderContentSection("Notes:", renderMarkdown(iss.Notes)))
 	}
+	commentsSection, selectedTop := "", -1
 	if node.CommentError != "" {
 		errorBody := styleBlockedText().Render("Failed to load comments. Press 'c' to retry.") + "\n" +
 			indentBlock(wordwrap.String(node.CommentError, vpWidth-4), 2)
@@ -233,15 +243,10 @@ func (m *App) updateViewportContent() {
 		loadingBody := styleStatsDim().Render("Loading comments...")
 		descSections = append(descSections, renderContentSection("Comments:", loadingBody))
 	} else if len(iss.Comments) > 0 {
-		// Use vpWidth-4 so that after indentBlock adds 2 spaces, lines stay within vpWidth.
-		renderCommentMarkdown := buildMarkdownRenderer(m.outputFormat, vpWidth-4)
-		var commentBlocks []string
-		for _, c := range iss.Comments {
-			header := fmt.Sprintf("  %s  %s", c.Author, formatTime(c.CreatedAt))
-			body := styleCommentHeader().Render(header) + "\n" + indentBlock(renderCommentMarkdown(c.Text), 2)
-			commentBlocks = append(commentBlocks, body)
-		}
-		descSections = append(descSections, renderContentSection("Comments:", strings.Join(commentBlocks, "\n\n")))
+		// Use vpWidth-4 so that after the 2-column gutter and section indent, lines stay within vpWidth.
+		renderCommentMarkdown := linkifyRenderer(buildMarkdownRenderer(m.outputFormat, vpWidth-4))
+		commentsSection, selectedTop = renderCommentsSection(iss.Comments, m.selectedCommentIndex(iss), renderCommentMarkdown)
+		descSections = append(descSections, commentsSection)
 	}
 	descBlock := joinDetailSections(descSections...)
 
@@ -249,9 +254,20 @@ func (m *App) updateViewportContent() {
 		headerBlock,
 		metaBlock,
 		relBlock,
+		gitBlock,
+		historyBlock,
 		descBlock,
 	)
 
+	m.setDetailContent(finalContent, iss.ID)
+	m.detailRelatedLines = relatedEntryLines(relBlock, lipgloss.Height(joinDetailSections(headerBlock, metaBlock))+1)
+	m.scrollToSelectedComment(finalContent, commentsSection, selectedTop)
+}
+
+// setDetailContent pads rendered detail content to the viewport and installs it.
+func (m *App) setDetailContent(finalContent, issueID string) {
+	vpWidth := m.viewport.Width
+
 	// Fill background gaps before applying placement padding
 	finalContent = padLinesToWidth(finalContent, vpWidth)
 	finalContent = fillBackground(finalContent)
@@ -283,7 +299,106 @@ func (m *App) updateViewportContent() {
 	}
 
 	m.viewport.SetContent(finalContent)
-	m.detailIssueID = iss.ID
+	m.detailIssueID = issueID
+}
+
+// renderRelationshipSection renders a titled list of related beads with status icons.
+func renderRelationshipSection(title string, items []*graph.Node, vpWidth int) string {
+	if len(items) == 0 {
+		return ""
+	}
+	const extraPadding = 2
+	rowWidth := vpWidth - detailSectionContentIndent - extraPadding
+	if rowWidth < 1 {
+		rowWidth = 1
+	}
+	rows := make([]string, 0, len(items))
+	for _, item := range items {
+		icon, iconStyle, titleStyle := relatedStatusPresentation(item)
+		row := renderRefRowWithIcon(
+			icon,
+			iconStyle,
+			item.Issue.ID,
+			item.Issue.Title,
+			rowWidth,
+			styleID(),
+			titleStyle,
+		)
+		rows = append(rows, row)
+	}
+	return renderContentSection(title, strings.Join(rows, "\n"))
+}
+
+// relatedEntryLines maps the lines of a block of relationship sections,
+// starting at content line offset, to the bead each entry lists. Wrapped
+// title lines map to the same bead as the entry's first line.
+func relatedEntryLines(block string, offset int) map[int]string {
+	if block == "" {
+		return nil
+	}
+	entries := make(map[int]string)
+	current := ""
+	for i, line := range strings.Split(ansi.Strip(strings.Trim(block, "\n\r")), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+		if strings.TrimSpace(trimmed) == "" || indent <= detailSectionLabelIndent {
+			// Blank line or section header
+			current = ""
+			continue
+		}
+		if fields := strings.Fields(trimmed); indent == detailSectionContentIndent && len(fields) >= 2 {
+			current = fields[1]
+		}
+		if current != "" {
+			entries[offset+i] = current
+		}
+	}
+	return entries
+}
+
+// updateGroupDetailContent shows a group header's summary: a status breakdown
+// and the member list, in the current sort order.
+func (m *App) updateGroupDetailContent(node *graph.Node) {
+	if m.detailIssueID != node.Issue.ID {
+		m.viewport.GotoTop()
+	}
+	vpWidth := m.viewport.Width
+
+	headerContentWidth := vpWidth - styleDetailHeaderBlock().GetHorizontalFrameSize()
+	if headerContentWidth < 1 {
+		headerContentWidth = 1
+	}
+	headerContent := renderRefRow(
+		m.groupMode.String()+":",
+		node.Issue.Title,
+		headerContentWidth,
+		styleDetailHeaderCombined().Foreground(currentThemeWrapper().Accent()),
+		styleDetailHeaderCombined().Foreground(currentThemeWrapper().Text()),
+		currentThemeWrapper().BackgroundSecondary(),
+	)
+	headerBlock := styleDetailHeaderBlock().Width(vpWidth).Render(headerContent)
+
+	counts := make(map[string]int)
+	for _, member := range node.Children {
+		counts[groupStatus(member)]++
+	}
+	var breakdown []string
+	for _, status := range []string{"in_progress", "open", "blocked", "deferred", "closed"} {
+		if counts[status] > 0 {
+			breakdown = append(breakdown, fmt.Sprintf("%d %s", counts[status], groupStatusLabel(status)))
+		}
+	}
+	summary := styleField().Render("Beads:") + styleVal().Render(fmt.Sprintf("%d", len(node.Children)))
+	if len(breakdown) > 0 {
+		summary += styleVal().Render(" • " + strings.Join(breakdown, " • "))
+	}
+	metaBlock := baseStyle().MarginLeft(1).Render(summary)
+
+	members := m.sortRelationships(node.Children, sortSubtasks)
+	membersBlock := renderRelationshipSection(fmt.Sprintf("Members: (%d)", len(members)), members, vpWidth)
+
+	m.setDetailContent(joinDetailSections(headerBlock, metaBlock, membersBlock), node.Issue.ID)
+	m.detailRelatedLines = relatedEntryLines(membersBlock, lipgloss.Height(joinDetailSections(headerBlock, metaBlock))+1)
 }
 
 func renderContentSection(label, body string) string {
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"abacus/internal/beads"
+	"abacus/internal/graph"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// commentGroupWindow is how close consecutive comments from the same author
+// must be to share a single header in the detail pane.
+const commentGroupWindow = 30 * time.Minute
+
+// commentStartsGroup reports whether comments[i] needs its own author/time header.
+func commentStartsGroup(comments []beads.Comment, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := comments[i-1], comments[i]
+	if prev.Author != cur.Author {
+		return true
+	}
+	prevAt, ok1 := parseTimestamp(prev.CreatedAt)
+	curAt, ok2 := parseTimestamp(cur.CreatedAt)
+	if !ok1 || !ok2 {
+		return true
+	}
+	gap := curAt.Sub(prevAt)
+	return gap < 0 || gap > commentGroupWindow
+}
+
+// renderCommentsSection renders the Comments section. Consecutive comments from
+// the same author are grouped under one header, and the selected comment (or -1)
+// is marked with an accent bar in the gutter. It also returns the line offset of
+// the selected comment within the section, or -1 when nothing is selected.
+func renderCommentsSection(comments []beads.Comment, selected int, renderMarkdown func(string) string) (string, int) {
+	gutter := baseStyle().Render(strings.Repeat(" ", detailSectionContentIndent))
+	bar := styleCommentSelectBar().Render("▌") + baseStyle().Render(" ")
+
+	lines := []string{
+		baseStyle().Render(strings.Repeat(" ", detailSectionLabelIndent)) + styleSectionHeader().Render("Comments:"),
+	}
+	selectedTop := -1
+	for i, c := range comments {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		prefix := gutter
+		if i == selected {
+			prefix = bar
+			selectedTop = len(lines)
+		}
+		if commentStartsGroup(comments, i) {
+			header := c.Author + "  " + formatTime(c.CreatedAt)
+			lines = append(lines, prefix+styleCommentHeader().Render(header))
+		}
+		body := normalizeSectionBody(renderMarkdown(c.Text))
+		for _, line := range strings.Split(body, "\n") {
+			switch {
+			case i == selected:
+				lines = append(lines, prefix+line)
+			case strings.TrimSpace(stripANSI(line)) == "":
+				lines = append(lines, "")
+			default:
+				lines = append(lines, prefix+line)
+			}
+		}
+	}
+	return strings.Join(lines, "\n"), selectedTop
+}
+
+// scrollToSelectedComment brings the selected comment into view once after the
+// selection moves. sectionTop is the comment's line offset within the section,
+// which is always the last block of content.
+func (m *App) scrollToSelectedComment(content, section string, sectionTop int) {
+	if !m.commentScrollPending || sectionTop < 0 {
+		return
+	}
+	m.commentScrollPending = false
+	top := lipgloss.Height(content) - lipgloss.Height(section) + sectionTop
+	if top < m.viewport.YOffset || top >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(top)
+	}
+}
+
+// detailNode returns the bead shown in the detail pane, or nil for group headers.
+func (m *App) detailNode() *graph.Node {
+	if len(m.visibleRows) == 0 || m.cursor < 0 || m.cursor >= len(m.visibleRows) {
+		return nil
+	}
+	node := m.visibleRows[m.cursor].Node
+	if isGroupNode(node) {
+		return nil
+	}
+	return node
+}
+
+// selectedCommentIndex returns the selected comment on iss, clamped to the
+// loaded comments, or -1 when no comment of this bead is selected.
+func (m *App) selectedCommentIndex(iss beads.FullIssue) int {
+	if m.selectedCommentIssue == "" || m.selectedCommentIssue != iss.ID || len(iss.Comments) == 0 {
+		return -1
+	}
+	if m.selectedComment >= len(iss.Comments) {
+		return len(iss.Comments) - 1
+	}
+	if m.selectedComment < 0 {
+		return 0
+	}
+	return m.selectedComment
+}
+
+// selectedCommentTarget returns the bead and comment under the comment cursor.
+func (m *App) selectedCommentTarget() (*graph.Node, beads.Comment, bool) {
+	node := m.detailNode()
+	if node == nil {
+		return nil, beads.Comment{}, false
+	}
+	idx := m.selectedCommentIndex(node.Issue)
+	if idx < 0 {
+		return nil, beads.Comment{}, false
+	}
+	return node, node.Issue.Comments[idx], true
+}
+
+// moveCommentSelection steps the comment cursor. With nothing selected, moving
+// forward selects the first comment and moving back selects the last.
+func (m *App) moveCommentSelection(delta int) bool {
+	node := m.detailNode()
+	if node == nil || !node.CommentsLoaded || len(node.Issue.Comments) == 0 {
+		return false
+	}
+	last := len(node.Issue.Comments) - 1
+	idx := m.selectedCommentIndex(node.Issue)
+	switch {
+	case idx < 0 && delta > 0:
+		idx = 0
+	case idx < 0:
+		idx = last
+	default:
+		idx += delta
+		if idx < 0 {
+			idx = 0
+		}
+		if idx > last {
+			idx = last
+		}
+	}
+	m.selectedCommentIssue = node.Issue.ID
+	m.selectedComment = idx
+	m.commentScrollPending = true
+	m.updateViewportContent()
+	return true
+}
+
+// clearCommentSelection drops the comment cursor.
+func (m *App) clearCommentSelection() {
+	m.selectedCommentIssue = ""
+	m.selectedComment = 0
+	m.commentScrollPending = false
+}
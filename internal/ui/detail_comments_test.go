@@ -0,0 +1,256 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"abacus/internal/beads"
+	"abacus/internal/graph"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func commentAt(id int, author string, at time.Time, text string) beads.Comment {
+	return beads.Comment{ID: id, IssueID: "ab-cmt", Author: author, Text: text, CreatedAt: at.Format(time.RFC3339)}
+}
+
+func buildCommentTestApp(comments ...beads.Comment) *App {
+	node := &graph.Node{
+		Issue: beads.FullIssue{
+			ID: "ab-cmt", Title: "Discuss", Status: "open", IssueType: "task",
+			Description: "Body", Comments: comments,
+		},
+		CommentsLoaded: true,
+	}
+	return &App{
+		ShowDetails:  true,
+		focus:        FocusDetails,
+		keys:         DefaultKeyMap(),
+		client:       beads.NewMockClient(),
+		visibleRows:  nodesToRows(node),
+		viewport:     viewport.New(90, 12),
+		outputFormat: "plain",
+	}
+}
+
+func TestRenderCommentsSectionGroupsByAuthor(t *testing.T) {
+	base := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	comments := []beads.Comment{
+		commentAt(1, "alice", base, "first"),
+		commentAt(2, "alice", base.Add(10*time.Minute), "follow-up"),
+		commentAt(3, "bob", base.Add(15*time.Minute), "reply"),
+		commentAt(4, "bob", base.Add(3*time.Hour), "much later"),
+	}
+	identity := func(s string) string { return s }
+
+	section, top := renderCommentsSection(comments, -1, identity)
+	plain := stripANSI(section)
+	if got := strings.Count(plain, "alice  "); got != 1 {
+		t.Fatalf("expected alice's comments grouped under one header, got %d:\n%s", got, plain)
+	}
+	if got := strings.Count(plain, "bob  "); got != 2 {
+		t.Fatalf("expected bob's comments split by the time gap, got %d headers:\n%s", got, plain)
+	}
+	if top != -1 || strings.Contains(plain, "▌") {
+		t.Fatalf("expected no selection marker, top=%d:\n%s", top, plain)
+	}
+
+	section, top = renderCommentsSection(comments, 1, identity)
+	lines := strings.Split(stripANSI(section), "\n")
+	if top < 0 || !strings.HasPrefix(lines[top], "▌ follow-up") {
+		t.Fatalf("expected selected comment marked at line %d:\n%s", top, strings.Join(lines, "\n"))
+	}
+}
+
+func TestCommentSelectionKeys(t *testing.T) {
+	base := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	m := buildCommentTestApp(
+		commentAt(1, "alice", base, "first"),
+		commentAt(2, "bob", base.Add(time.Hour), "second"),
+	)
+	m.updateViewportContent()
+
+	press := func(msg tea.KeyMsg) tea.Cmd {
+		_, cmd := m.handleKeyMsg(msg)
+		return cmd
+	}
+	next := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}}
+	prev := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}}
+
+	press(next)
+	if _, c, ok := m.selectedCommentTarget(); !ok || c.ID != 1 {
+		t.Fatalf("expected first comment selected, got %+v ok=%v", c, ok)
+	}
+	press(next)
+	press(next)
+	if _, c, _ := m.selectedCommentTarget(); c.ID != 2 {
+		t.Fatalf("expected selection to stop at last comment, got %d", c.ID)
+	}
+	press(prev)
+	if _, c, _ := m.selectedCommentTarget(); c.ID != 1 {
+		t.Fatalf("expected previous comment selected, got %d", c.ID)
+	}
+
+	t.Run("editOpensPrefilledOverlay", func(t *testing.T) {
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+		if m.activeOverlay != OverlayComment || m.commentOverlay.editing == nil {
+			t.Fatalf("expected edit comment overlay, got %v", m.activeOverlay)
+		}
+		if got := m.commentOverlay.textarea.Value(); got != "first" {
+			t.Fatalf("expected textarea prefilled with comment, got %q", got)
+		}
+		m.activeOverlay, m.commentOverlay = OverlayNone, nil
+	})
+
+	t.Run("replyQuotesComment", func(t *testing.T) {
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+		if m.activeOverlay != OverlayComment || m.commentOverlay.editing != nil {
+			t.Fatalf("expected reply overlay, got %v", m.activeOverlay)
+		}
+		if got := m.commentOverlay.textarea.Value(); !strings.HasPrefix(got, "> **alice** wrote:\n> first\n") {
+			t.Fatalf("expected quoted comment, got %q", got)
+		}
+		m.activeOverlay, m.commentOverlay = OverlayNone, nil
+	})
+
+	t.Run("deleteAsksForConfirmation", func(t *testing.T) {
+		press(tea.KeyMsg{Type: tea.KeyDelete})
+		if m.activeOverlay != OverlayCommentDelete {
+			t.Fatalf("expected comment delete overlay, got %v", m.activeOverlay)
+		}
+		cmd := press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+		confirmed, ok := cmd().(CommentDeleteConfirmedMsg)
+		if !ok || confirmed.IssueID != "ab-cmt" || confirmed.CommentID != 1 {
+			t.Fatalf("unexpected confirmation: %+v", confirmed)
+		}
+		m.activeOverlay, m.commentDeleteOverlay = OverlayNone, nil
+	})
+
+	t.Run("escapeClearsSelection", func(t *testing.T) {
+		press(tea.KeyMsg{Type: tea.KeyEsc})
+		if _, _, ok := m.selectedCommentTarget(); ok {
+			t.Fatal("expected selection cleared")
+		}
+	})
+
+	t.Run("treeFocusIgnoresCommentKeys", func(t *testing.T) {
+		m.focus = FocusTree
+		press(next)
+		if _, _, ok := m.selectedCommentTarget(); ok {
+			t.Fatal("expected comment keys ignored while tree is focused")
+		}
+	})
+}
+
+func TestCommentSelectionScrollsIntoView(t *testing.T) {
+	base := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	var comments []beads.Comment
+	for i := 1; i <= 8; i++ {
+		comments = append(comments, commentAt(i, fmt.Sprintf("user%d", i), base.Add(time.Duration(i)*time.Hour), fmt.Sprintf("comment %d", i)))
+	}
+	m := buildCommentTestApp(comments...)
+	m.updateViewportContent()
+
+	m.moveCommentSelection(-1)
+	view := stripANSI(m.viewport.View())
+	if !strings.Contains(view, "▌ comment 8") {
+		t.Fatalf("expected last comment scrolled into view:\n%s", view)
+	}
+}
+
+func TestEditCommentOverlaySubmit(t *testing.T) {
+	comment := beads.Comment{ID: 9, Author: "alice", Text: "typo hre"}
+
+	m := NewEditCommentOverlay("ab-cmt", "Discuss", comment)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if _, ok := cmd().(CommentCancelledMsg); !ok {
+		t.Fatal("expected unchanged edit to cancel without writing")
+	}
+
+	m = NewEditCommentOverlay("ab-cmt", "Discuss", comment)
+	m.textarea.SetValue("typo here")
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	msg, ok := cmd().(CommentEditedMsg)
+	if !ok || msg.CommentID != 9 || msg.Comment != "typo here" {
+		t.Fatalf("unexpected edit message: %+v", msg)
+	}
+}
+
+func TestQuoteCommentCapsLength(t *testing.T) {
+	quoted := quoteComment(beads.Comment{Author: "bob", Text: strings.Repeat("x", commentCharLimit)})
+	if len([]rune(quoted)) > commentCharLimit/2+40 {
+		t.Fatalf("expected quote capped near half the limit, got %d runes", len([]rune(quoted)))
+	}
+	if !strings.HasSuffix(quoted, "…\n\n") {
+		t.Fatalf("expected truncated quote to end with ellipsis, got %q", quoted[len(quoted)-10:])
+	}
+}
+
+func TestCommentEditUnsupportedSurfacesError(t *testing.T) {
+	client := beads.NewMockClient()
+	client.UpdateCommentFn = func(context.Context, string, int, string) error {
+		return fmt.Errorf("br cannot edit comments: %w", beads.ErrNotSupported)
+	}
+	m := &App{client: client}
+
+	msg := m.executeUpdateComment(CommentEditedMsg{IssueID: "ab-cmt", CommentID: 3, Comment: "new"})()
+	complete, ok := msg.(commentCompleteMsg)
+	if !ok || !errors.Is(complete.err, beads.ErrNotSupported) {
+		t.Fatalf("expected unsupported error, got %+v", msg)
+	}
+	m.Update(complete)
+	if !m.showErrorToast || !strings.Contains(m.lastError, "cannot edit comments") {
+		t.Fatalf("expected error toast, got lastError=%q", m.lastError)
+	}
+}
+
+// noCommentEditClient is a client that knows it cannot edit comments.
+type noCommentEditClient struct {
+	*beads.MockClient
+}
+
+func (noCommentEditClient) CanEditComments() bool { return false }
+
+func TestCommentEditUnsupportedBlocksOverlay(t *testing.T) {
+	base := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	m := buildCommentTestApp(commentAt(1, "alice", base, "first"))
+	m.client = noCommentEditClient{beads.NewMockClient()}
+	m.backend = "br"
+	m.updateViewportContent()
+	m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+
+	for _, k := range []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'e'}}, {Type: tea.KeyDelete}} {
+		m.showErrorToast = false
+		m.handleKeyMsg(k)
+		if m.activeOverlay != OverlayNone {
+			t.Fatalf("%s: expected no overlay, got %v", k, m.activeOverlay)
+		}
+		if !m.showErrorToast || !strings.HasPrefix(m.lastError, "br cannot") {
+			t.Fatalf("%s: expected an unsupported toast, got %q", k, m.lastError)
+		}
+	}
+
+	// Replying still works.
+	m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	if m.activeOverlay != OverlayComment {
+		t.Fatalf("expected reply overlay, got %v", m.activeOverlay)
+	}
+}
+
+func TestCommentEditRefusedOnceIsNotOfferedAgain(t *testing.T) {
+	base := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	m := buildCommentTestApp(commentAt(1, "alice", base, "first"))
+	m.updateViewportContent()
+	m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+
+	m.Update(commentCompleteMsg{issueID: "ab-cmt", action: "deleted", err: fmt.Errorf("plugin: %w", beads.ErrNotSupported)})
+	m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if m.activeOverlay != OverlayNone || !strings.Contains(m.lastError, "cannot edit comments") {
+		t.Fatalf("expected the edit to be refused up front, overlay=%v error=%q", m.activeOverlay, m.lastError)
+	}
+}
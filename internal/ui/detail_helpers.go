@@ -293,7 +293,14 @@ func formatTime(isoStr string) string {
 	if isoStr == "" {
 		return "-"
 	}
+	if t, ok := parseTimestamp(isoStr); ok {
+		return t.Local().Format("Jan 02, 3:04 PM")
+	}
+	return isoStr
+}
 
+// parseTimestamp parses the timestamp formats emitted by the beads backends.
+func parseTimestamp(isoStr string) (time.Time, bool) {
 	layouts := []string{
 		time.RFC3339Nano,
 		time.RFC3339,
@@ -301,10 +308,9 @@ func formatTime(isoStr string) string {
 		"2006-01-02 15:04:05",
 	}
 	for _, layout := range layouts {
-		t, err := time.Parse(layout, isoStr)
-		if err == nil {
-			return t.Local().Format("Jan 02, 3:04 PM")
+		if t, err := time.Parse(layout, isoStr); err == nil {
+			return t, true
 		}
 	}
-	return isoStr
+	return time.Time{}, false
 }
@@ -0,0 +1,363 @@
+package ui
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"abacus/internal/beads"
+)
+
+// conflictChoice is the value kept for one field of a conflicting edit.
+type conflictChoice int
+
+const (
+	choiceMine   conflictChoice = iota // the value submitted from the edit form
+	choiceTheirs                       // the value someone else saved meanwhile
+	choiceBase                         // the value when the edit form was opened
+	choiceBoth                         // description and labels only: keep both
+)
+
+func (c conflictChoice) String() string {
+	switch c {
+	case choiceTheirs:
+		return "theirs"
+	case choiceBase:
+		return "base"
+	case choiceBoth:
+		return "both"
+	default:
+		return "mine"
+	}
+}
+
+// Editable fields compared when an edit races with another writer.
+const (
+	conflictTitle       = "Title"
+	conflictDescription = "Description"
+	conflictType        = "Type"
+	conflictPriority    = "Priority"
+	conflictLabels      = "Labels"
+	conflictAssignee    = "Assignee"
+)
+
+// fieldConflict is one field someone else changed while it was being edited.
+// Values are kept as display strings, except labels, which are kept as
+// sorted sets so a label containing a comma survives the merge.
+type fieldConflict struct {
+	Name               string
+	Base, Mine, Theirs string
+	// BaseLabels, MineLabels and TheirsLabels are set for the Labels field
+	// only; Base, Mine and Theirs then hold them joined for display.
+	BaseLabels, MineLabels, TheirsLabels []string
+	Conflicting                          bool // both sides changed the field to different values
+	Choice                               conflictChoice
+}
+
+// Choices returns the merge choices offered for the field.
+func (f fieldConflict) Choices() []conflictChoice {
+	if f.Name == conflictDescription || f.Name == conflictLabels {
+		return []conflictChoice{choiceMine, choiceTheirs, choiceBase, choiceBoth}
+	}
+	return []conflictChoice{choiceMine, choiceTheirs, choiceBase}
+}
+
+// Value returns the display value for a choice. Keeping both merges each
+// side's changes against the base, so neither side's edit is lost.
+func (f fieldConflict) Value(c conflictChoice) string {
+	if f.Name == conflictLabels {
+		return strings.Join(f.Labels(c), ", ")
+	}
+	switch c {
+	case choiceTheirs:
+		return f.Theirs
+	case choiceBase:
+		return f.Base
+	case choiceBoth:
+		return mergeLines(f.Base, f.Mine, f.Theirs)
+	default:
+		return f.Mine
+	}
+}
+
+// Labels returns the label set for a choice on the Labels field. Keeping
+// both applies the labels each side added and removed to the base.
+func (f fieldConflict) Labels(c conflictChoice) []string {
+	switch c {
+	case choiceTheirs:
+		return f.TheirsLabels
+	case choiceBase:
+		return f.BaseLabels
+	case choiceBoth:
+		var merged []string
+		for _, l := range f.BaseLabels {
+			if slices.Contains(f.MineLabels, l) && slices.Contains(f.TheirsLabels, l) {
+				merged = append(merged, l)
+			}
+		}
+		for _, l := range append(slices.Clone(f.MineLabels), f.TheirsLabels...) {
+			if !slices.Contains(f.BaseLabels, l) {
+				merged = append(merged, l)
+			}
+		}
+		return labelSet(merged)
+	default:
+		return f.MineLabels
+	}
+}
+
+// editConflict is an edit whose bead changed after the edit form opened.
+type editConflict struct {
+	update BeadUpdatedMsg
+	theirs beads.FullIssue
+	fields []fieldConflict
+}
+
+// editConflictMsg reports that an edit needs merging before it is saved.
+type editConflictMsg struct {
+	conflict editConflict
+}
+
+// newEditConflict compares an edit against the bead as stored now, using the
+// snapshot taken when the form opened as the common base. Only fields the
+// other writer changed are listed: those the edit left alone default to
+// theirs, and those both sides changed default to mine.
+func newEditConflict(update BeadUpdatedMsg, theirs beads.FullIssue) editConflict {
+	base := editFieldValues(update.Base)
+	other := editFieldValues(theirs)
+	mine := map[string]string{
+		conflictTitle:       update.Title,
+		conflictDescription: update.Description,
+		conflictType:        update.IssueType,
+		conflictPriority:    strconv.Itoa(update.Priority),
+		conflictAssignee:    update.Assignee,
+	}
+
+	c := editConflict{update: update, theirs: theirs}
+	for _, name := range []string{conflictTitle, conflictDescription, conflictType, conflictPriority, conflictLabels, conflictAssignee} {
+		f := fieldConflict{Name: name, Base: base[name], Mine: mine[name], Theirs: other[name]}
+		if name == conflictLabels {
+			f.BaseLabels, f.MineLabels, f.TheirsLabels = labelSet(update.Base.Labels), labelSet(update.Labels), labelSet(theirs.Labels)
+			f.Base, f.Mine, f.Theirs = f.Value(choiceBase), f.Value(choiceMine), f.Value(choiceTheirs)
+		}
+		if f.Theirs == f.Base || f.Theirs == f.Mine {
+			continue
+		}
+		if f.Mine == f.Base {
+			f.Choice = choiceTheirs
+		} else {
+			f.Conflicting = true
+		}
+		c.fields = append(c.fields, f)
+	}
+	return c
+}
+
+// editFieldValues returns an issue's editable fields, other than labels, as
+// they would be submitted from the edit form.
+func editFieldValues(issue beads.FullIssue) map[string]string {
+	return map[string]string{
+		conflictTitle:       strings.TrimSpace(issue.Title),
+		conflictDescription: strings.TrimSpace(issue.Description),
+		conflictType:        issue.IssueType,
+		conflictPriority:    strconv.Itoa(issue.Priority),
+		conflictAssignee:    issue.Assignee,
+	}
+}
+
+// resolved returns the edit with every field set to its chosen value. Its
+// base becomes the stored bead the choices were made against, so another
+// change arriving meanwhile is caught by the next check.
+func (c editConflict) resolved() BeadUpdatedMsg {
+	msg := c.update
+	msg.Base = c.theirs
+	msg.Labels = slices.Clone(msg.Labels)
+	for _, f := range c.fields {
+		if f.Name == conflictLabels {
+			msg.Labels = slices.Clone(f.Labels(f.Choice))
+			continue
+		}
+		v := f.Value(f.Choice)
+		switch f.Name {
+		case conflictTitle:
+			msg.Title = v
+		case conflictDescription:
+			msg.Description = v
+		case conflictType:
+			msg.IssueType = v
+		case conflictPriority:
+			if p, err := strconv.Atoi(v); err == nil {
+				msg.Priority = p
+			}
+		case conflictAssignee:
+			msg.Assignee = v
+		}
+	}
+	return msg
+}
+
+// labelSet returns labels sorted and de-duplicated.
+func labelSet(labels []string) []string {
+	sorted := slices.Clone(labels)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
+}
+
+// mergeLines merges two edits of base line by line. Lines only one side
+// changed take that side's version; where both changed the same lines
+// differently, mine is kept followed by theirs, separated by a blank line.
+func mergeLines(base, mine, theirs string) string {
+	baseLines := splitLines(base)
+	mineHunks := lineHunks(baseLines, splitLines(mine))
+	theirHunks := lineHunks(baseLines, splitLines(theirs))
+
+	var out []string
+	pos, i, j := 0, 0, 0
+	for i < len(mineHunks) || j < len(theirHunks) {
+		// Start a region at the earlier hunk and grow it while hunks from
+		// either side overlap it.
+		start, end := len(baseLines), 0
+		var mineRegion, theirRegion []lineHunk
+		take := func(h lineHunk) {
+			start, end = min(start, h.start), max(end, h.end)
+		}
+		if j == len(theirHunks) || (i < len(mineHunks) && mineHunks[i].before(theirHunks[j])) {
+			take(mineHunks[i])
+			mineRegion = append(mineRegion, mineHunks[i])
+			i++
+		} else {
+			take(theirHunks[j])
+			theirRegion = append(theirRegion, theirHunks[j])
+			j++
+		}
+		for grew := true; grew; {
+			grew = false
+			if i < len(mineHunks) && mineHunks[i].overlaps(start, end) {
+				take(mineHunks[i])
+				mineRegion = append(mineRegion, mineHunks[i])
+				i++
+				grew = true
+			}
+			if j < len(theirHunks) && theirHunks[j].overlaps(start, end) {
+				take(theirHunks[j])
+				theirRegion = append(theirRegion, theirHunks[j])
+				j++
+				grew = true
+			}
+		}
+
+		out = append(out, baseLines[pos:start]...)
+		mineVersion := applyHunks(baseLines, start, end, mineRegion)
+		theirVersion := applyHunks(baseLines, start, end, theirRegion)
+		switch {
+		case len(theirRegion) == 0 || slices.Equal(mineVersion, theirVersion):
+			out = append(out, mineVersion...)
+		case len(mineRegion) == 0:
+			out = append(out, theirVersion...)
+		case len(mineVersion) == 0 || len(theirVersion) == 0:
+			out = append(append(out, mineVersion...), theirVersion...)
+		default:
+			out = append(append(append(out, mineVersion...), ""), theirVersion...)
+		}
+		pos = end
+	}
+	out = append(out, baseLines[pos:]...)
+	return strings.Join(out, "\n")
+}
+
+// lineHunk replaces base lines [start, end) with lines.
+type lineHunk struct {
+	start, end int
+	lines      []string
+}
+
+// before orders hunks by position, insertions ahead of changes starting at
+// the same line.
+func (h lineHunk) before(o lineHunk) bool {
+	return h.start < o.start || (h.start == o.start && h.end <= o.end)
+}
+
+// overlaps reports whether the hunk and base lines [start, end) change the
+// same text. Insertions overlap a change only strictly inside it, and
+// another insertion only at the same place; at a change's edges both apply.
+func (h lineHunk) overlaps(start, end int) bool {
+	switch {
+	case h.start == h.end && start == end:
+		return h.start == start
+	case h.start == h.end:
+		return h.start > start && h.start < end
+	case start == end:
+		return start > h.start && start < h.end
+	}
+	return h.start < end && h.end > start
+}
+
+// applyHunks returns base lines [start, end) with hunks applied.
+func applyHunks(base []string, start, end int, hunks []lineHunk) []string {
+	var out []string
+	pos := start
+	for _, h := range hunks {
+		out = append(out, base[pos:h.start]...)
+		out = append(out, h.lines...)
+		pos = h.end
+	}
+	return append(out, base[pos:end]...)
+}
+
+// lineHunks lists the changes from base to edited, using the longest common
+// subsequence of lines.
+func lineHunks(base, edited []string) []lineHunk {
+	// lcs[i][j] is the common subsequence length of base[i:] and edited[j:].
+	lcs := make([][]int, len(base)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(edited)+1)
+	}
+	for i := len(base) - 1; i >= 0; i-- {
+		for j := len(edited) - 1; j >= 0; j-- {
+			if base[i] == edited[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var hunks []lineHunk
+	var cur *lineHunk
+	flush := func() {
+		if cur != nil {
+			hunks = append(hunks, *cur)
+			cur = nil
+		}
+	}
+	i, j := 0, 0
+	for i < len(base) || j < len(edited) {
+		switch {
+		case i < len(base) && j < len(edited) && base[i] == edited[j]:
+			flush()
+			i++
+			j++
+		case j < len(edited) && (i == len(base) || lcs[i][j+1] >= lcs[i+1][j]):
+			if cur == nil {
+				cur = &lineHunk{start: i, end: i}
+			}
+			cur.lines = append(cur.lines, edited[j])
+			j++
+		default:
+			if cur == nil {
+				cur = &lineHunk{start: i, end: i}
+			}
+			i++
+			cur.end = i
+		}
+	}
+	flush()
+	return hunks
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"abacus/internal/beads"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func conflictTestBase() beads.FullIssue {
+	return beads.FullIssue{
+		ID:          "ab-1",
+		Title:       "Fix login",
+		Description: "Original notes",
+		IssueType:   "bug",
+		Priority:    2,
+		Labels:      []string{"auth"},
+		Assignee:    "alice",
+		UpdatedAt:   "2026-10-01T10:00:00Z",
+	}
+}
+
+// conflictTestEdit is an edit of conflictTestBase that changes the title and description.
+func conflictTestEdit() BeadUpdatedMsg {
+	base := conflictTestBase()
+	return BeadUpdatedMsg{
+		ID:          base.ID,
+		Title:       "Fix login redirect",
+		Description: "My notes",
+		IssueType:   base.IssueType,
+		Priority:    base.Priority,
+		Labels:      []string{"auth"},
+		Assignee:    base.Assignee,
+		Base:        base,
+	}
+}
+
+func TestNewEditConflict(t *testing.T) {
+	theirs := conflictTestBase()
+	theirs.UpdatedAt = "2026-10-01T11:00:00Z"
+	theirs.Description = "Their notes"     // changed by both
+	theirs.Labels = []string{"ui", "auth"} // changed by them only
+	theirs.Title = "Fix login redirect"    // same change on both sides
+	theirs.Assignee = "alice"              // unchanged
+
+	c := newEditConflict(conflictTestEdit(), theirs)
+	if len(c.fields) != 2 {
+		t.Fatalf("expected description and labels, got %+v", c.fields)
+	}
+	desc, labels := c.fields[0], c.fields[1]
+	if desc.Name != conflictDescription || !desc.Conflicting || desc.Choice != choiceMine {
+		t.Errorf("unexpected description conflict %+v", desc)
+	}
+	if labels.Name != conflictLabels || labels.Conflicting || labels.Choice != choiceTheirs || labels.Theirs != "auth, ui" {
+		t.Errorf("unexpected labels conflict %+v", labels)
+	}
+
+	merged := c.resolved()
+	if merged.Description != "My notes" || !slices.Equal(merged.Labels, []string{"auth", "ui"}) || merged.Title != "Fix login redirect" {
+		t.Errorf("unexpected default merge %+v", merged)
+	}
+	if merged.Base.UpdatedAt != theirs.UpdatedAt {
+		t.Errorf("expected merged edit to be based on their version, got %q", merged.Base.UpdatedAt)
+	}
+
+	c.fields[0].Choice = choiceBoth
+	if got := c.resolved().Description; got != "My notes\n\nTheir notes" {
+		t.Errorf("both = %q", got)
+	}
+	c.fields[0].Choice = choiceBase
+	if got := c.resolved().Description; got != "Original notes" {
+		t.Errorf("base = %q", got)
+	}
+}
+
+func TestFieldConflictBothDescriptions(t *testing.T) {
+	tests := []struct {
+		name, base, mine, theirs, want string
+	}{
+		{"same line changed", "Original notes", "My notes", "Their notes", "My notes\n\nTheir notes"},
+		{"different lines changed", "a\nb\nc", "A\nb\nc", "a\nb\nC", "A\nb\nC"},
+		{"same change", "a\nb", "a\nB", "a\nB", "a\nB"},
+		{
+			// Their fix is kept even though my text quotes it.
+			name:   "theirs quoted in mine",
+			base:   "Crash on save",
+			mine:   "Crash on save\nStill happens after \"retry the write\"",
+			theirs: "Crash on save\nretry the write",
+			want:   "Crash on save\nStill happens after \"retry the write\"\n\nretry the write",
+		},
+		{"only theirs changed", "a\nb", "a\nb", "a\nb\nc", "a\nb\nc"},
+		{"insertions either side of a change", "a\nb\nc", "a\nB\nc", "x\na\nb\nc\ny", "x\na\nB\nc\ny"},
+		{"mine cleared", "a", "", "b", "b"},
+		{"both added to empty", "", "My notes", "Their notes", "My notes\n\nTheir notes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := fieldConflict{Name: conflictDescription, Base: tt.base, Mine: tt.mine, Theirs: tt.theirs}
+			if got := f.Value(choiceBoth); got != tt.want {
+				t.Errorf("Value(both) = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldConflictLabels(t *testing.T) {
+	base := conflictTestBase()
+	base.Labels = []string{"ui", "needs review, urgent"}
+	edit := conflictTestEdit()
+	edit.Base = base
+	edit.Labels = []string{"ui", "needs review, urgent", "auth"} // mine adds auth
+	theirs := base
+	theirs.UpdatedAt = "2026-10-01T11:00:00Z"
+	theirs.Labels = []string{"needs review, urgent", "backend"} // theirs drops ui, adds backend
+
+	c := newEditConflict(edit, theirs)
+	i := slices.IndexFunc(c.fields, func(f fieldConflict) bool { return f.Name == conflictLabels })
+	if i < 0 || !c.fields[i].Conflicting {
+		t.Fatalf("expected a labels conflict, got %+v", c.fields)
+	}
+	c.fields[i].Choice = choiceBoth
+	if got, want := c.resolved().Labels, []string{"auth", "backend", "needs review, urgent"}; !slices.Equal(got, want) {
+		t.Errorf("both = %q, want %q", got, want)
+	}
+	c.fields[i].Choice = choiceTheirs
+	if got := c.resolved().Labels; !slices.Equal(got, []string{"backend", "needs review, urgent"}) {
+		t.Errorf("theirs = %q", got)
+	}
+}
+
+func TestExecuteUpdateCmdDetectsConcurrentEdit(t *testing.T) {
+	theirs := conflictTestBase()
+	theirs.UpdatedAt = "2026-10-01T11:00:00Z"
+	theirs.Description = "Their notes"
+
+	mockClient := beads.NewMockClient()
+	mockClient.ShowFn = func(context.Context, []string) ([]beads.FullIssue, error) {
+		return []beads.FullIssue{theirs}, nil
+	}
+	app := &App{client: mockClient}
+
+	res := app.executeUpdateCmd(conflictTestEdit())()
+	conflictMsg, ok := res.(editConflictMsg)
+	if !ok {
+		t.Fatalf("expected editConflictMsg, got %T", res)
+	}
+	if len(conflictMsg.conflict.fields) != 1 || conflictMsg.conflict.fields[0].Name != conflictDescription {
+		t.Errorf("unexpected conflict fields %+v", conflictMsg.conflict.fields)
+	}
+	if mockClient.UpdateFullCallCount != 0 {
+		t.Errorf("expected nothing saved before the conflict is resolved, got %d UpdateFull calls", mockClient.UpdateFullCallCount)
+	}
+}
+
+func TestExecuteUpdateCmdSavesWhenOnlyOtherFieldsChanged(t *testing.T) {
+	current := conflictTestBase()
+	current.UpdatedAt = "2026-10-01T11:00:00Z"
+	current.Status = "in_progress" // not part of the edit form
+
+	mockClient := beads.NewMockClient()
+	mockClient.ShowFn = func(context.Context, []string) ([]beads.FullIssue, error) {
+		return []beads.FullIssue{current}, nil
+	}
+	app := &App{client: mockClient}
+
+	res := app.executeUpdateCmd(conflictTestEdit())()
+	if updateMsg, ok := res.(updateCompleteMsg); !ok || updateMsg.Err != nil {
+		t.Fatalf("expected a clean save, got %#v", res)
+	}
+	if got := mockClient.UpdateFullCallArgs; len(got) != 1 || got[0].Description != "My notes" {
+		t.Errorf("unexpected UpdateFull calls %+v", got)
+	}
+}
+
+func TestExecuteUpdateCmdReportsDeletedBead(t *testing.T) {
+	mockClient := beads.NewMockClient()
+	mockClient.ShowFn = func(context.Context, []string) ([]beads.FullIssue, error) {
+		return nil, nil
+	}
+	app := &App{client: mockClient}
+
+	res := app.executeUpdateCmd(conflictTestEdit())()
+	updateMsg, ok := res.(updateCompleteMsg)
+	if !ok || updateMsg.Err == nil || !strings.Contains(updateMsg.Err.Error(), "ab-1") {
+		t.Fatalf("expected a not found error, got %#v", res)
+	}
+	if mockClient.UpdateFullCallCount != 0 {
+		t.Error("expected nothing to be saved")
+	}
+}
+
+func TestEditConflictOverlayFlow(t *testing.T) {
+	theirs := conflictTestBase()
+	theirs.UpdatedAt = "2026-10-01T11:00:00Z"
+	theirs.Title = "Fix SSO login"
+	theirs.Description = "Their notes"
+
+	createOverlay := NewEditOverlay(&beads.FullIssue{ID: "ab-1", Title: "Fix login"}, CreateOverlayOptions{})
+	createOverlay.isCreating = true
+	app := &App{client: beads.NewMockClient(), activeOverlay: OverlayCreate, createOverlay: createOverlay}
+
+	app.Update(editConflictMsg{conflict: newEditConflict(conflictTestEdit(), theirs)})
+	if app.activeOverlay != OverlayEditConflict || app.editConflictOverlay == nil {
+		t.Fatalf("expected conflict overlay, got overlay %v", app.activeOverlay)
+	}
+	if view := app.editConflictOverlay.View(); !strings.Contains(view, "Fix SSO login") || !strings.Contains(view, "changed by both") {
+		t.Errorf("conflict view missing their value:\n%s", view)
+	}
+
+	// Take their title, keep both descriptions.
+	overlay := app.editConflictOverlay
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("t")},
+		{Type: tea.KeyDown},
+		{Type: tea.KeyRunes, Runes: []rune("+")},
+	} {
+		overlay, _ = overlay.Update(k)
+	}
+	_, cmd := overlay.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	resolved, ok := cmd().(EditConflictResolvedMsg)
+	if !ok {
+		t.Fatal("expected EditConflictResolvedMsg")
+	}
+	if resolved.Update.Title != "Fix SSO login" || resolved.Update.Description != "My notes\n\nTheir notes" {
+		t.Errorf("unexpected merged edit %+v", resolved.Update)
+	}
+
+	// Esc returns to the edit form so it can be submitted again.
+	_, cmd = overlay.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app.Update(cmd())
+	if app.activeOverlay != OverlayCreate || app.editConflictOverlay != nil || app.createOverlay.isCreating {
+		t.Errorf("expected to be back in the edit form, overlay=%v creating=%v", app.activeOverlay, app.createOverlay.isCreating)
+	}
+}
+
+func TestNewEditOverlayCapturesBase(t *testing.T) {
+	bead := conflictTestBase()
+	overlay := NewEditOverlay(&bead, CreateOverlayOptions{})
+	bead.UpdatedAt = "changed later"
+	bead.Labels[0] = "changed"
+
+	msg := overlay.submitEdit()().(BeadUpdatedMsg)
+	if msg.Base.UpdatedAt != "2026-10-01T10:00:00Z" || msg.Base.Labels[0] != "auth" {
+		t.Errorf("expected the base snapshot from when the form opened, got %+v", msg.Base)
+	}
+}
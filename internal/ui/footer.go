@@ -40,6 +40,15 @@ var treeFooterHints = []footerHint{
 
 var detailsFooterHints = []footerHint{
 	{"↑↓", "Scroll"},
+	{"[]", "Comments"},
+}
+
+var commentSelectedFooterHints = []footerHint{
+	{"[]", "Select"},
+	{"e", "Edit"},
+	{"R", "Reply"},
+	{"Del", "Delete"},
+	{"esc", "Deselect"},
 }
 
 var statusOverlayFooterHints = []footerHint{
@@ -56,6 +65,12 @@ var labelsOverlayFooterHints = []footerHint{
 	{"esc", "Cancel"},
 }
 
+var assigneeOverlayFooterHints = []footerHint{
+	{"↓", "Browse"},
+	{"⏎", "Save"},
+	{"esc", "Cancel"},
+}
+
 var priorityOverlayFooterHints = []footerHint{
 	{"0", "Crit"},
 	{"1", "High"},
@@ -84,6 +99,8 @@ func (m *App) renderFooter() string {
 		hints = priorityOverlayFooterHints
 	case OverlayLabels:
 		hints = labelsOverlayFooterHints
+	case OverlayAssignee:
+		hints = assigneeOverlayFooterHints
 	case OverlayCreate:
 		hints = createOverlayFooterHints
 	default:
@@ -92,7 +109,11 @@ func (m *App) renderFooter() string {
 		case FocusTree:
 			hints = append(hints, treeFooterHints...)
 		case FocusDetails:
-			hints = append(hints, detailsFooterHints...)
+			if _, _, ok := m.selectedCommentTarget(); ok {
+				hints = append(hints, commentSelectedFooterHints...)
+			} else {
+				hints = append(hints, detailsFooterHints...)
+			}
 		}
 
 		// Global keys
@@ -103,6 +124,9 @@ func (m *App) renderFooter() string {
 	// Right side shows: backend indicator + status (error/refresh/update)
 	backendIndicator := m.renderBackendIndicator()
 	statusContent := m.renderRefreshStatus()
+	if pending := m.renderPendingIndicator(); pending != "" {
+		statusContent = pending + baseStyle().Render("  ") + statusContent
+	}
 	var rightContent string
 	if backendIndicator != "" && statusContent != " " && statusContent != "" {
 		// Both present: "  [bd]  status"
@@ -154,8 +154,8 @@ func TestFooterHintSlices(t *testing.T) {
 	})
 
 	t.Run("DetailsHintsCount", func(t *testing.T) {
-		if len(detailsFooterHints) != 1 {
-			t.Errorf("expected 1 details hint, got %d", len(detailsFooterHints))
+		if len(detailsFooterHints) != 2 {
+			t.Errorf("expected 2 details hints, got %d", len(detailsFooterHints))
 		}
 	})
 }
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"abacus/internal/beads"
 )
 
 // FindBeadsDB locates the beads database file.
@@ -35,7 +37,7 @@ func FindBeadsDB() (string, time.Time, error) {
 		return "", time.Time{}, fmt.Errorf("default beads db is a directory: %s", fallback)
 	}
 	modTime := info.ModTime()
-	if latest, err := latestModTimeForDB(fallback); err == nil {
+	if latest, err := beads.LatestModTime(fallback); err == nil {
 		modTime = latest
 	}
 	return fallback, modTime, nil
@@ -51,7 +53,7 @@ func findBeadsDBFromDir(startDir string) (string, time.Time, error) {
 		info, err := os.Stat(candidate)
 		if err == nil && !info.IsDir() {
 			modTime := info.ModTime()
-			if latest, err := latestModTimeForDB(candidate); err == nil {
+			if latest, err := beads.LatestModTime(candidate); err == nil {
 				modTime = latest
 			}
 			return candidate, modTime, nil
@@ -64,3 +66,13 @@ func findBeadsDBFromDir(startDir string) (string, time.Time, error) {
 	}
 	return "", time.Time{}, fmt.Errorf("no beads db found from %s", startDir)
 }
+
+// projectRoot returns the directory containing the loaded .beads folder,
+// which is where project files such as .abacus/templates live. For the
+// ~/.beads/default.db fallback this is the home directory.
+func (m *App) projectRoot() string {
+	if strings.TrimSpace(m.dbPath) == "" {
+		return ""
+	}
+	return filepath.Dir(filepath.Dir(m.dbPath))
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"abacus/internal/gitinfo"
+	"abacus/internal/graph"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxGitCommitsShown caps the commits listed per bead in the detail pane.
+const maxGitCommitsShown = 5
+
+// loadGitRefs scans the repository for commits and branches mentioning any
+// bead, without blocking the UI. Returns nil when git is unavailable or a
+// scan is already running.
+func (m *App) loadGitRefs() tea.Cmd {
+	if m.gitDir == "" || m.gitUnavailable || m.gitRefsLoading {
+		return nil
+	}
+	m.gitRefsLoading = true
+	dir := m.gitDir
+	ids := collectIssueIDs(m.roots)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		repo, err := gitinfo.Open(ctx, dir)
+		if err != nil {
+			return gitRefsLoadedMsg{err: err}
+		}
+		refs, err := repo.Scan(ctx, ids)
+		return gitRefsLoadedMsg{refs: refs, err: err}
+	}
+}
+
+// applyGitRefs stores a scan result. Outside a git repository the detail
+// section is hidden instead of showing an error.
+func (m *App) applyGitRefs(msg gitRefsLoadedMsg) {
+	m.gitRefsLoading = false
+	if errors.Is(msg.err, gitinfo.ErrNotRepository) {
+		m.gitUnavailable = true
+		return
+	}
+	m.gitRefsLoaded = true
+	if msg.err != nil {
+		m.gitRefsError = msg.err.Error()
+		return
+	}
+	m.gitRefsError = ""
+	m.gitRefs = msg.refs
+}
+
+func collectIssueIDs(roots []*graph.Node) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	var walk func(nodes []*graph.Node)
+	walk = func(nodes []*graph.Node) {
+		for _, n := range nodes {
+			if !seen[n.Issue.ID] {
+				seen[n.Issue.ID] = true
+				ids = append(ids, n.Issue.ID)
+			}
+			walk(n.Children)
+		}
+	}
+	walk(roots)
+	return ids
+}
+
+// renderGitSection lists the branches and latest commits mentioning the
+// bead, or "" when git is unavailable.
+func (m *App) renderGitSection(issueID string, vpWidth int) string {
+	if m.gitUnavailable || (m.gitDir == "" && !m.gitRefsLoaded) {
+		return ""
+	}
+	switch {
+	case !m.gitRefsLoaded:
+		return renderContentSection("Git:", styleStatsDim().Render("Loading git history..."))
+	case m.gitRefsError != "":
+		return renderContentSection("Git:", styleBlockedText().Render("Failed to read git history: "+m.gitRefsError))
+	}
+
+	refs := m.gitRefs[issueID]
+	if refs.Empty() {
+		return renderContentSection("Git:", styleStatsDim().Render("No commits or branches mention this bead"))
+	}
+
+	width := vpWidth - detailSectionContentIndent - 2
+	lines := make([]string, 0, len(refs.Branches)+maxGitCommitsShown+1)
+	for _, branch := range refs.Branches {
+		lines = append(lines, styleStatsDim().Render("⎇ ")+styleVal().Render(truncateWithEllipsis(branch, width-2)))
+	}
+	for i, c := range refs.Commits {
+		if i == maxGitCommitsShown {
+			lines = append(lines, styleStatsDim().Render(fmt.Sprintf("… %d more commits", len(refs.Commits)-maxGitCommitsShown)))
+			break
+		}
+		when := FormatRelativeTime(c.Date)
+		subject := truncateWithEllipsis(c.Subject, width-len(c.Hash)-len(when)-4)
+		lines = append(lines, styleID().Render(c.Hash)+baseStyle().Render("  ")+styleVal().Render(subject)+
+			baseStyle().Render("  ")+styleStatsDim().Render(when))
+	}
+
+	title := fmt.Sprintf("Git: (%d %s, %d %s)",
+		len(refs.Commits), pluralize(len(refs.Commits), "commit", "commits"),
+		len(refs.Branches), pluralize(len(refs.Branches), "branch", "branches"))
+	return renderContentSection(title, strings.Join(lines, "\n"))
+}
+
+func pluralize(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// handleCreateBranchKey creates a git branch named after the selected bead.
+// The branch is created at HEAD and not checked out.
+func (m *App) handleCreateBranchKey() (tea.Model, tea.Cmd) {
+	if len(m.visibleRows) == 0 || m.cursorOnGroupHeader() {
+		return m, nil
+	}
+	if m.gitDir == "" || m.gitUnavailable {
+		m.lastError = "Not in a git repository"
+		m.lastErrorSource = errorSourceOperation
+		m.showErrorToast = true
+		m.errorToastStart = time.Now()
+		return m, scheduleErrorToastTick()
+	}
+	issue := m.visibleRows[m.cursor].Node.Issue
+	name := gitinfo.BranchName(issue.ID, issue.Title)
+	dir := m.gitDir
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		repo, err := gitinfo.Open(ctx, dir)
+		if err == nil {
+			err = repo.CreateBranch(ctx, name)
+		}
+		return branchCreatedMsg{issueID: issue.ID, name: name, err: err}
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"abacus/internal/beads"
+	"abacus/internal/gitinfo"
+	"abacus/internal/graph"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func gitDetailApp(node *graph.Node) *App {
+	return &App{
+		ShowDetails:  true,
+		visibleRows:  []graph.TreeRow{{Node: node}},
+		viewport:     viewport.New(90, 40),
+		outputFormat: "plain",
+		keys:         DefaultKeyMap(),
+		ready:        true,
+	}
+}
+
+func TestDetailGitSection(t *testing.T) {
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-g1", Title: "Git bead"}, CommentsLoaded: true}
+	app := gitDetailApp(node)
+	app.gitDir = t.TempDir()
+
+	app.updateViewportContent()
+	if !strings.Contains(stripANSI(app.viewport.View()), "Loading git history...") {
+		t.Error("expected loading state before the scan finishes")
+	}
+
+	app.Update(gitRefsLoadedMsg{refs: map[string]gitinfo.Refs{
+		"ab-g1": {
+			Branches: []string{"ab-g1-git-bead"},
+			Commits:  []gitinfo.Commit{{Hash: "abc1234", Subject: "Wire up ab-g1", Date: time.Now()}},
+		},
+	}})
+	content := stripANSI(app.viewport.View())
+	for _, want := range []string{"Git: (1 commit, 1 branch)", "ab-g1-git-bead", "abc1234", "Wire up ab-g1"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in detail pane:\n%s", want, content)
+		}
+	}
+
+	app.gitRefs = nil
+	app.updateViewportContent()
+	if !strings.Contains(stripANSI(app.viewport.View()), "No commits or branches mention this bead") {
+		t.Error("expected empty state when nothing mentions the bead")
+	}
+}
+
+func TestDetailGitSectionHiddenOutsideRepository(t *testing.T) {
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-g2", Title: "No repo"}, CommentsLoaded: true}
+	app := gitDetailApp(node)
+	app.gitDir = t.TempDir()
+
+	app.Update(gitRefsLoadedMsg{err: gitinfo.ErrNotRepository})
+	if !app.gitUnavailable {
+		t.Fatal("expected git to be marked unavailable")
+	}
+	if strings.Contains(stripANSI(app.viewport.View()), "Git") {
+		t.Error("expected no git section outside a repository")
+	}
+	if cmd := app.loadGitRefs(); cmd != nil {
+		t.Error("expected no further scans once git is unavailable")
+	}
+}
+
+func TestCreateBranchKey(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-g3", Title: "Add export"}}
+	app := &App{visibleRows: nodesToRows(node), roots: []*graph.Node{node}, keys: DefaultKeyMap(), ready: true, gitDir: dir}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})
+	if cmd == nil {
+		t.Fatal("expected create branch command")
+	}
+	msg, ok := cmd().(branchCreatedMsg)
+	if !ok || msg.err != nil || msg.name != "ab-g3-add-export" {
+		t.Fatalf("unexpected result %#v", msg)
+	}
+	app.Update(msg)
+	if !app.branchToastVisible || app.branchToastName != "ab-g3-add-export" {
+		t.Error("expected branch toast after creating the branch")
+	}
+
+	out, err := exec.Command("git", "-C", dir, "branch", "--list", "ab-g3-add-export").Output()
+	if err != nil || !strings.Contains(string(out), "ab-g3-add-export") {
+		t.Errorf("expected branch to exist, got %q (%v)", out, err)
+	}
+}
+
+func TestCreateBranchKeyOutsideRepository(t *testing.T) {
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-g4", Title: "No repo"}}
+	app := &App{visibleRows: nodesToRows(node), keys: DefaultKeyMap(), ready: true, gitUnavailable: true, gitDir: t.TempDir()}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})
+	if !app.showErrorToast || app.lastError != "Not in a git repository" {
+		t.Errorf("expected error toast, got %q", app.lastError)
+	}
+}
@@ -0,0 +1,282 @@
+package ui
+
+// Group-by mode replaces the parent-child hierarchy with a flat, one-level
+// grouping of the filtered beads. Each group is represented by a synthetic
+// header node whose Children are the member beads; members are rendered as
+// leaves underneath it. A bead that belongs to several groups (e.g. multiple
+// labels) gets one TreeRow per group, so the usual multi-parent
+// cross-highlighting applies to its duplicate rows.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"abacus/internal/beads"
+	"abacus/internal/domain"
+	"abacus/internal/graph"
+)
+
+// GroupMode selects how beads are grouped in the tree.
+type GroupMode int
+
+const (
+	GroupModeNone     GroupMode = iota // Parent-child hierarchy (default)
+	GroupModeAssignee                  // One group per assignee, unassigned last
+	GroupModeLabel                     // One group per label, unlabeled last
+	GroupModeType                      // One group per issue type
+	GroupModePriority                  // P0 through P4
+	GroupModeStatus                    // In progress, open, blocked, deferred, closed
+	groupModeCount                     // internal: number of modes for cycling
+)
+
+// groupNodePrefix marks the synthetic IDs of group header nodes.
+const groupNodePrefix = "group:"
+
+// String returns the display name of the group mode.
+func (g GroupMode) String() string {
+	switch g {
+	case GroupModeAssignee:
+		return "Assignee"
+	case GroupModeLabel:
+		return "Label"
+	case GroupModeType:
+		return "Type"
+	case GroupModePriority:
+		return "Priority"
+	case GroupModeStatus:
+		return "Status"
+	default:
+		return "None"
+	}
+}
+
+// Next returns the next group mode in the cycle.
+func (g GroupMode) Next() GroupMode {
+	return GroupMode((int(g) + 1) % int(groupModeCount))
+}
+
+// isGroupNode reports whether n is a synthetic group header.
+func isGroupNode(n *graph.Node) bool {
+	return n != nil && strings.HasPrefix(n.Issue.ID, groupNodePrefix)
+}
+
+// nodeGroup is one bucket produced by groupNodes.
+type nodeGroup struct {
+	key     string
+	label   string
+	rank    int // primary ordering; ties break on label
+	members []*graph.Node
+}
+
+// groupKeys returns the group keys, display labels and ranks a node belongs to.
+func groupKeys(mode GroupMode, n *graph.Node) []nodeGroup {
+	switch mode {
+	case GroupModeAssignee:
+		assignee := strings.TrimSpace(n.Issue.Assignee)
+		if assignee == "" {
+			return []nodeGroup{{key: "", label: "Unassigned", rank: 1}}
+		}
+		return []nodeGroup{{key: strings.ToLower(assignee), label: assignee}}
+	case GroupModeLabel:
+		if len(n.Issue.Labels) == 0 {
+			return []nodeGroup{{key: "", label: "No labels", rank: 1}}
+		}
+		groups := make([]nodeGroup, 0, len(n.Issue.Labels))
+		seen := make(map[string]bool, len(n.Issue.Labels))
+		for _, l := range n.Issue.Labels {
+			if seen[l] {
+				continue
+			}
+			seen[l] = true
+			groups = append(groups, nodeGroup{key: l, label: l})
+		}
+		return groups
+	case GroupModeType:
+		issueType := strings.TrimSpace(n.Issue.IssueType)
+		if issueType == "" {
+			return []nodeGroup{{key: "", label: "No type", rank: 1}}
+		}
+		return []nodeGroup{{key: issueType, label: issueType}}
+	case GroupModePriority:
+		return []nodeGroup{{
+			key:   fmt.Sprintf("%d", n.Issue.Priority),
+			label: fmt.Sprintf("P%d", n.Issue.Priority),
+			rank:  n.Issue.Priority,
+		}}
+	case GroupModeStatus:
+		status := groupStatus(n)
+		return []nodeGroup{{key: status, label: groupStatusLabel(status), rank: groupStatusRank(status)}}
+	default:
+		return nil
+	}
+}
+
+// groupStatus returns the effective status of a node, treating open beads
+// with open blockers as blocked, matching the tree's status icons.
+func groupStatus(n *graph.Node) string {
+	status := n.Issue.Status
+	if domainIssue, err := domain.NewIssueFromFull(n.Issue, n.IsBlocked); err == nil {
+		status = string(domainIssue.Status())
+	}
+	if status == "open" && n.IsBlocked {
+		return "blocked"
+	}
+	return status
+}
+
+func groupStatusLabel(status string) string {
+	switch status {
+	case "in_progress":
+		return "In Progress"
+	case "":
+		return "No status"
+	default:
+		return strings.ToUpper(status[:1]) + status[1:]
+	}
+}
+
+func groupStatusRank(status string) int {
+	switch status {
+	case "in_progress":
+		return 0
+	case "open":
+		return 1
+	case "blocked":
+		return 2
+	case "deferred":
+		return 3
+	case "closed":
+		return 5
+	default:
+		return 4
+	}
+}
+
+// groupNodes buckets nodes by mode, returning groups in display order.
+// Member order within a group follows the input order.
+func groupNodes(mode GroupMode, nodes []*graph.Node) []*nodeGroup {
+	byKey := make(map[string]*nodeGroup)
+	var groups []*nodeGroup
+	for _, n := range nodes {
+		for _, g := range groupKeys(mode, n) {
+			existing, ok := byKey[g.key]
+			if !ok {
+				existing = &nodeGroup{key: g.key, label: g.label, rank: g.rank}
+				byKey[g.key] = existing
+				groups = append(groups, existing)
+			}
+			existing.members = append(existing.members, n)
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].rank != groups[j].rank {
+			return groups[i].rank < groups[j].rank
+		}
+		return strings.ToLower(groups[i].label) < strings.ToLower(groups[j].label)
+	})
+	return groups
+}
+
+// newGroupNode builds the synthetic header node for a group. Its Children are
+// the group's members so the detail pane and expand markers work unchanged.
+func newGroupNode(mode GroupMode, g *nodeGroup) *graph.Node {
+	return &graph.Node{
+		Issue: beads.FullIssue{
+			ID:    groupNodePrefix + strings.ToLower(mode.String()) + ":" + g.key,
+			Title: g.label,
+		},
+		Children: g.members,
+	}
+}
+
+// collectGroupableNodes returns every bead in the tree that passes the
+// current view mode, my-work and search filters, each exactly once.
+func (m *App) collectGroupableNodes(filterLower string) []*graph.Node {
+	var result []*graph.Node
+	seen := make(map[string]bool)
+	var walk func(nodes []*graph.Node)
+	walk = func(nodes []*graph.Node) {
+		for _, n := range nodes {
+			if seen[n.Issue.ID] {
+				continue
+			}
+			seen[n.Issue.ID] = true
+			if nodeMatchesViewMode(m.viewMode, n) && nodeMatchesFilter(filterLower, n) &&
+				(!m.myWorkOnly || isAssignedToMe(n)) {
+				result = append(result, n)
+			}
+			walk(n.Children)
+		}
+	}
+	walk(m.roots)
+	return result
+}
+
+// recalcGroupedRows rebuilds visibleRows for the active group mode: one header
+// row per non-empty group followed, when expanded, by its members.
+func (m *App) recalcGroupedRows(filterLower string) {
+	nodes := m.collectGroupableNodes(filterLower)
+	groups := groupNodes(m.groupMode, nodes)
+
+	m.groupMemberships = make(map[string]int, len(nodes))
+	for _, g := range groups {
+		for _, member := range g.members {
+			m.groupMemberships[member.Issue.ID]++
+		}
+	}
+
+	for _, g := range groups {
+		header := newGroupNode(m.groupMode, g)
+		m.visibleRows = append(m.visibleRows, graph.TreeRow{Node: header})
+		if m.collapsedGroups[header.Issue.ID] {
+			continue
+		}
+		for _, member := range m.sortRelationships(g.members, sortSubtasks) {
+			m.visibleRows = append(m.visibleRows, graph.TreeRow{
+				Node:   member,
+				Parent: header,
+				Depth:  1,
+			})
+		}
+	}
+}
+
+// rowHasExpandableChildren reports whether a row shows an expand marker.
+// In group mode only group headers expand; member beads are leaves.
+func (m *App) rowHasExpandableChildren(row graph.TreeRow) bool {
+	if m.groupMode != GroupModeNone && !isGroupNode(row.Node) {
+		return false
+	}
+	return len(row.Node.Children) > 0
+}
+
+// rowIsDuplicated reports whether the row's bead appears in more than one
+// place in the tree (multiple parents, or multiple groups in group mode).
+func (m *App) rowIsDuplicated(row graph.TreeRow) bool {
+	if m.groupMode != GroupModeNone {
+		return m.groupMemberships[row.Node.Issue.ID] > 1
+	}
+	return row.HasMultipleParents()
+}
+
+// setGroupExpanded records a group header's expansion state.
+func (m *App) setGroupExpanded(id string, expanded bool) {
+	if expanded {
+		delete(m.collapsedGroups, id)
+		return
+	}
+	if m.collapsedGroups == nil {
+		m.collapsedGroups = make(map[string]bool)
+	}
+	m.collapsedGroups[id] = true
+}
+
+// cursorOnGroupHeader reports whether the selected row is a group header,
+// on which bead actions (status, edit, delete, …) do not apply.
+func (m *App) cursorOnGroupHeader() bool {
+	if m.cursor < 0 || m.cursor >= len(m.visibleRows) {
+		return false
+	}
+	return isGroupNode(m.visibleRows[m.cursor].Node)
+}
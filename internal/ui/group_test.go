@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"testing"
+
+	"abacus/internal/beads"
+	"abacus/internal/graph"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func buildGroupTestNodes() []*graph.Node {
+	child := &graph.Node{Issue: beads.FullIssue{
+		ID: "ab-child", Title: "Child", Status: "in_progress", Priority: 1,
+		Assignee: "alice", Labels: []string{"ui", "backend"}, IssueType: "task",
+	}}
+	epic := &graph.Node{
+		Issue: beads.FullIssue{
+			ID: "ab-epic", Title: "Epic", Status: "open", Priority: 0,
+			Assignee: "bob", Labels: []string{"ui"}, IssueType: "epic",
+		},
+		Children: []*graph.Node{child},
+	}
+	child.Parent = epic
+	child.Parents = []*graph.Node{epic}
+	loose := &graph.Node{Issue: beads.FullIssue{
+		ID: "ab-loose", Title: "Loose", Status: "closed", Priority: 2, IssueType: "bug",
+	}}
+	return []*graph.Node{epic, loose}
+}
+
+func rowIDs(rows []graph.TreeRow) []string {
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.Node.Issue.ID
+	}
+	return ids
+}
+
+func TestGroupNodes(t *testing.T) {
+	nodes := buildGroupTestNodes()
+	all := []*graph.Node{nodes[0], nodes[0].Children[0], nodes[1]}
+
+	t.Run("assigneeUnassignedLast", func(t *testing.T) {
+		groups := groupNodes(GroupModeAssignee, all)
+		expected := []string{"alice", "bob", "Unassigned"}
+		if len(groups) != len(expected) {
+			t.Fatalf("expected %d groups, got %d", len(expected), len(groups))
+		}
+		for i, label := range expected {
+			if groups[i].label != label {
+				t.Fatalf("group %d: expected %q, got %q", i, label, groups[i].label)
+			}
+		}
+	})
+
+	t.Run("labelDuplicatesMultiLabelBeads", func(t *testing.T) {
+		groups := groupNodes(GroupModeLabel, all)
+		counts := map[string]int{}
+		for _, g := range groups {
+			counts[g.label] = len(g.members)
+		}
+		if counts["ui"] != 2 || counts["backend"] != 1 || counts["No labels"] != 1 {
+			t.Fatalf("unexpected label group sizes: %v", counts)
+		}
+	})
+
+	t.Run("statusOrder", func(t *testing.T) {
+		groups := groupNodes(GroupModeStatus, all)
+		expected := []string{"In Progress", "Open", "Closed"}
+		for i, label := range expected {
+			if groups[i].label != label {
+				t.Fatalf("group %d: expected %q, got %q", i, label, groups[i].label)
+			}
+		}
+	})
+
+	t.Run("priorityOrder", func(t *testing.T) {
+		groups := groupNodes(GroupModePriority, all)
+		expected := []string{"P0", "P1", "P2"}
+		for i, label := range expected {
+			if groups[i].label != label {
+				t.Fatalf("group %d: expected %q, got %q", i, label, groups[i].label)
+			}
+		}
+	})
+}
+
+func TestGroupModeVisibleRows(t *testing.T) {
+	m := buildTreeTestApp(buildGroupTestNodes()...)
+	m.groupMode = GroupModeLabel
+	m.recalcVisibleRows()
+
+	expected := []string{
+		"group:label:backend", "ab-child",
+		"group:label:ui", "ab-child", "ab-epic",
+		"group:label:", "ab-loose",
+	}
+	got := rowIDs(m.visibleRows)
+	if len(got) != len(expected) {
+		t.Fatalf("expected rows %v, got %v", expected, got)
+	}
+	for i, id := range expected {
+		if got[i] != id {
+			t.Fatalf("row %d: expected %s, got %s (rows %v)", i, id, got[i], got)
+		}
+	}
+
+	t.Run("multiLabelBeadMarkedDuplicate", func(t *testing.T) {
+		if !m.rowIsDuplicated(m.visibleRows[1]) {
+			t.Fatal("expected bead in two label groups to be marked duplicated")
+		}
+		if m.rowIsDuplicated(m.visibleRows[4]) {
+			t.Fatal("expected single-label bead not to be marked duplicated")
+		}
+	})
+
+	t.Run("membersAreLeaves", func(t *testing.T) {
+		epicRow := m.visibleRows[4]
+		if m.rowHasExpandableChildren(epicRow) {
+			t.Fatal("expected members to be leaves in group mode")
+		}
+	})
+
+	t.Run("collapseGroupHeader", func(t *testing.T) {
+		m.cursor = 2
+		m.handleTreeCollapse()
+		got := rowIDs(m.visibleRows)
+		if len(got) != 5 {
+			t.Fatalf("expected collapsed ui group to hide 2 rows, got %v", got)
+		}
+		m.handleTreeExpand()
+		if len(m.visibleRows) != 7 {
+			t.Fatalf("expected expanding ui group to restore rows, got %v", rowIDs(m.visibleRows))
+		}
+	})
+
+	t.Run("viewModeFiltersMembers", func(t *testing.T) {
+		m.viewMode = ViewModeActive
+		m.recalcVisibleRows()
+		for _, id := range rowIDs(m.visibleRows) {
+			if id == "ab-loose" || id == "group:label:" {
+				t.Fatalf("expected closed bead and its empty group to be hidden, got %v", rowIDs(m.visibleRows))
+			}
+		}
+	})
+}
+
+func TestGroupHeaderBlocksBeadActions(t *testing.T) {
+	m := buildTreeTestApp(buildGroupTestNodes()...)
+	m.groupMode = GroupModeAssignee
+	m.recalcVisibleRows()
+	m.cursor = 0
+
+	if !m.cursorOnGroupHeader() {
+		t.Fatal("expected cursor on group header")
+	}
+	m.handleStatusKey()
+	if m.activeOverlay != OverlayNone {
+		t.Fatalf("expected no overlay on group header, got %v", m.activeOverlay)
+	}
+	m.handleDeleteKey()
+	if m.activeOverlay != OverlayNone {
+		t.Fatalf("expected delete to be ignored on group header, got %v", m.activeOverlay)
+	}
+}
+
+func TestCycleGroupKeyKeepsSelection(t *testing.T) {
+	m := buildTreeTestApp(buildGroupTestNodes()...)
+	m.keys = DefaultKeyMap()
+	m.cursor = 1 // ab-loose at root level
+	if m.visibleRows[m.cursor].Node.Issue.ID != "ab-loose" {
+		t.Fatalf("unexpected starting row %s", m.visibleRows[m.cursor].Node.Issue.ID)
+	}
+
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	m = model.(*App)
+	if cmd == nil {
+		t.Fatal("expected toast tick command")
+	}
+	if m.groupMode != GroupModeAssignee {
+		t.Fatalf("expected assignee grouping, got %v", m.groupMode)
+	}
+	if got := m.visibleRows[m.cursor].Node.Issue.ID; got != "ab-loose" {
+		t.Fatalf("expected selection to stay on ab-loose, got %s", got)
+	}
+}
@@ -35,6 +35,10 @@ func getHelpSections(keys KeyMap) []helpSection {
 				{keys.Enter.Help().Key, keys.Enter.Help().Desc},
 				{keys.Tab.Help().Key, keys.Tab.Help().Desc},
 				{keys.CycleViewMode.Help().Key, keys.CycleViewMode.Help().Desc},
+				{keys.CycleSort.Help().Key, keys.CycleSort.Help().Desc},
+				{keys.CycleGroup.Help().Key, keys.CycleGroup.Help().Desc},
+				{keys.MyWork.Help().Key, keys.MyWork.Help().Desc},
+				{keys.LabelManager.Help().Key, keys.LabelManager.Help().Desc},
 				{keys.Refresh.Help().Key, keys.Refresh.Help().Desc},
 				{keys.Error.Help().Key, keys.Error.Help().Desc},
 				{keys.Theme.Help().Key, keys.Theme.Help().Desc},
@@ -46,13 +50,21 @@ func getHelpSections(keys KeyMap) []helpSection {
 			title: "BEAD ACTIONS",
 			rows: [][]string{
 				{keys.Copy.Help().Key, keys.Copy.Help().Desc},
+				{keys.OpenLink.Help().Key, keys.OpenLink.Help().Desc},
+				{keys.CreateBranch.Help().Key, keys.CreateBranch.Help().Desc},
+				{keys.History.Help().Key, keys.History.Help().Desc},
 				{keys.Status.Help().Key, keys.Status.Help().Desc},
 				{keys.Priority.Help().Key, keys.Priority.Help().Desc},
 				{keys.Labels.Help().Key, keys.Labels.Help().Desc},
+				{keys.Assignee.Help().Key, keys.Assignee.Help().Desc},
+				{keys.TakeBead.Help().Key, keys.TakeBead.Help().Desc},
 				{keys.NewBead.Help().Key, keys.NewBead.Help().Desc},
 				{keys.NewRootBead.Help().Key, keys.NewRootBead.Help().Desc},
+				{keys.ImportOutline.Help().Key, keys.ImportOutline.Help().Desc},
 				{keys.Edit.Help().Key, keys.Edit.Help().Desc},
 				{keys.Comment.Help().Key, keys.Comment.Help().Desc},
+				{keys.NextComment.Help().Key, keys.NextComment.Help().Desc},
+				{keys.ReplyComment.Help().Key, keys.ReplyComment.Help().Desc},
 				{keys.Delete.Help().Key, keys.Delete.Help().Desc},
 			},
 		},
@@ -72,15 +72,15 @@ func TestGetHelpSections(t *testing.T) {
 		}
 	})
 
-	t.Run("ActionsHas8Rows", func(t *testing.T) {
-		if len(sections[1].rows) != 8 {
-			t.Errorf("Actions section: expected 8 rows, got %d", len(sections[1].rows))
+	t.Run("ActionsHas12Rows", func(t *testing.T) {
+		if len(sections[1].rows) != 12 {
+			t.Errorf("Actions section: expected 12 rows, got %d", len(sections[1].rows))
 		}
 	})
 
-	t.Run("BeadActionsHas9Rows", func(t *testing.T) {
-		if len(sections[2].rows) != 9 {
-			t.Errorf("Bead Actions section: expected 9 rows, got %d", len(sections[2].rows))
+	t.Run("BeadActionsHas17Rows", func(t *testing.T) {
+		if len(sections[2].rows) != 17 {
+			t.Errorf("Bead Actions section: expected 17 rows, got %d", len(sections[2].rows))
 		}
 	})
 
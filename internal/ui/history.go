@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"abacus/internal/beads"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// historyState is the loaded (or loading) history of one bead.
+type historyState struct {
+	events  []beads.HistoryEvent
+	err     string
+	loading bool
+}
+
+// loadSelectedHistory fetches the selected bead's history when the history
+// section is showing and it is not already loaded or loading.
+func (m *App) loadSelectedHistory() tea.Cmd {
+	if !m.showHistory || !m.ShowDetails || m.client == nil {
+		return nil
+	}
+	if len(m.visibleRows) == 0 || m.cursor < 0 || m.cursor >= len(m.visibleRows) || m.cursorOnGroupHeader() {
+		return nil
+	}
+	id := m.visibleRows[m.cursor].Node.Issue.ID
+	if _, ok := m.history[id]; ok {
+		return nil
+	}
+	if m.history == nil {
+		m.history = make(map[string]historyState)
+	}
+	m.history[id] = historyState{loading: true}
+	client := m.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		events, err := client.History(ctx, id)
+		return historyLoadedMsg{issueID: id, events: events, err: err}
+	}
+}
+
+func (m *App) applyHistory(msg historyLoadedMsg) {
+	if m.history == nil {
+		// A refresh cleared the cache while this load was running.
+		return
+	}
+	state := historyState{events: msg.events}
+	switch {
+	case errors.Is(msg.err, beads.ErrNotSupported):
+		state.err = "History is not available for this backend"
+	case msg.err != nil:
+		state.err = "Failed to load history: " + msg.err.Error()
+	}
+	m.history[msg.issueID] = state
+}
+
+// renderHistorySection lists who changed the bead and when, oldest first, or
+// "" while history is hidden.
+func (m *App) renderHistorySection(issueID string, vpWidth int) string {
+	if !m.showHistory {
+		return ""
+	}
+	state, ok := m.history[issueID]
+	switch {
+	case !ok || state.loading:
+		return renderContentSection("History:", styleStatsDim().Render("Loading history..."))
+	case state.err != "":
+		return renderContentSection("History:", styleStatsDim().Render(state.err))
+	case len(state.events) == 0:
+		return renderContentSection("History:", styleStatsDim().Render("No recorded changes"))
+	}
+
+	width := vpWidth - detailSectionContentIndent - 2
+	lines := make([]string, 0, len(state.events))
+	for _, ev := range state.events {
+		when := formatTime(ev.CreatedAt)
+		actor := ev.Actor
+		if actor == "" {
+			actor = "unknown"
+		}
+		change := truncateWithEllipsis(describeHistoryEvent(ev), width-len(when)-len(actor)-4)
+		lines = append(lines, styleStatsDim().Render(when)+baseStyle().Render("  ")+styleID().Render(actor)+
+			baseStyle().Render("  ")+styleVal().Render(change))
+	}
+	title := fmt.Sprintf("History: (%d %s)", len(state.events), pluralize(len(state.events), "change", "changes"))
+	return renderContentSection(title, strings.Join(lines, "\n"))
+}
+
+// describeHistoryEvent renders a change as a short phrase such as
+// "priority P2 → P0" or "added label bug".
+func describeHistoryEvent(ev beads.HistoryEvent) string {
+	var text string
+	switch ev.Field {
+	case beads.HistoryCreated:
+		text = "created"
+	case beads.HistoryLabels:
+		text = addedOrRemoved("label", ev)
+	case beads.HistoryDependencies:
+		text = addedOrRemoved("dependency", ev)
+	case beads.HistoryPriority:
+		text = "priority " + historyPriority(ev.OldValue) + " → " + historyPriority(ev.NewValue)
+	case beads.HistoryAssignee:
+		switch {
+		case ev.NewValue == "":
+			text = "unassigned " + ev.OldValue
+		case ev.OldValue == "":
+			text = "assigned to " + ev.NewValue
+		default:
+			text = "assignee " + ev.OldValue + " → " + ev.NewValue
+		}
+	default:
+		text = ev.Field
+		if ev.OldValue != "" || ev.NewValue != "" {
+			text += " " + historyValue(ev.OldValue) + " → " + historyValue(ev.NewValue)
+		}
+	}
+	if ev.Comment != "" {
+		text += " (" + ev.Comment + ")"
+	}
+	return text
+}
+
+func addedOrRemoved(noun string, ev beads.HistoryEvent) string {
+	if ev.NewValue == "" {
+		return "removed " + noun + " " + ev.OldValue
+	}
+	return "added " + noun + " " + ev.NewValue
+}
+
+func historyPriority(value string) string {
+	if _, err := strconv.Atoi(value); err == nil {
+		return "P" + value
+	}
+	return historyValue(value)
+}
+
+func historyValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"abacus/internal/beads"
+	"abacus/internal/graph"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDetailHistorySection(t *testing.T) {
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-h1", Title: "Audit bead"}, CommentsLoaded: true}
+	app := gitDetailApp(node)
+	client := beads.NewMockClient()
+	client.HistoryFn = func(_ context.Context, id string) ([]beads.HistoryEvent, error) {
+		return []beads.HistoryEvent{
+			{IssueID: id, Field: beads.HistoryCreated, Actor: "alice", CreatedAt: "2026-01-01T10:00:00Z"},
+			{IssueID: id, Field: beads.HistoryPriority, Actor: "bob", OldValue: "2", NewValue: "0", CreatedAt: "2026-01-02T10:00:00Z"},
+			{IssueID: id, Field: beads.HistoryLabels, Actor: "bob", OldValue: "triage", CreatedAt: "2026-01-03T10:00:00Z"},
+		}, nil
+	}
+	app.client = client
+
+	app.updateViewportContent()
+	if strings.Contains(stripANSI(app.viewport.View()), "History:") {
+		t.Fatal("history should be hidden until toggled")
+	}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	if cmd == nil {
+		t.Fatal("expected toggling history to load it")
+	}
+	if !strings.Contains(stripANSI(app.viewport.View()), "Loading history...") {
+		t.Error("expected loading state while history is fetched")
+	}
+	app.Update(cmd())
+
+	content := stripANSI(app.viewport.View())
+	for _, want := range []string{"History: (3 changes)", "alice", "created", "priority P2 → P0", "removed label triage"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in detail pane:\n%s", want, content)
+		}
+	}
+
+	// Loaded history is cached until the next refresh.
+	if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyDown}); cmd != nil {
+		t.Error("expected no reload for a bead whose history is loaded")
+	}
+	if client.HistoryCallCount != 1 {
+		t.Errorf("expected one history load, got %d", client.HistoryCallCount)
+	}
+}
+
+func TestDetailHistoryNotSupported(t *testing.T) {
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-h2", Title: "bd bead"}, CommentsLoaded: true}
+	app := gitDetailApp(node)
+	app.showHistory = true
+	app.history = map[string]historyState{"ab-h2": {loading: true}}
+	app.Update(historyLoadedMsg{issueID: "ab-h2", err: beads.ErrNotSupported})
+	if !strings.Contains(stripANSI(app.viewport.View()), "History is not available for this backend") {
+		t.Errorf("expected unsupported message:\n%s", stripANSI(app.viewport.View()))
+	}
+}
@@ -19,20 +19,33 @@ type KeyMap struct {
 	PageDown key.Binding
 
 	// Actions
-	Enter       key.Binding
-	Tab         key.Binding
-	Refresh     key.Binding
-	Error       key.Binding
-	Help        key.Binding
-	Quit        key.Binding
-	Copy        key.Binding
-	Status      key.Binding
-	Labels      key.Binding
-	Priority    key.Binding
-	NewBead     key.Binding
-	NewRootBead key.Binding
-	Edit        key.Binding
-	Comment     key.Binding
+	Enter         key.Binding
+	Tab           key.Binding
+	Refresh       key.Binding
+	Error         key.Binding
+	Help          key.Binding
+	Quit          key.Binding
+	Copy          key.Binding
+	OpenLink      key.Binding
+	CreateBranch  key.Binding
+	History       key.Binding
+	Status        key.Binding
+	Labels        key.Binding
+	LabelManager  key.Binding
+	Priority      key.Binding
+	Assignee      key.Binding
+	TakeBead      key.Binding
+	MyWork        key.Binding
+	NewBead       key.Binding
+	NewRootBead   key.Binding
+	ImportOutline key.Binding
+	Edit          key.Binding
+	Comment       key.Binding
+
+	// Comment selection (detail pane) - Next/Prev share help text
+	NextComment  key.Binding
+	PrevComment  key.Binding
+	ReplyComment key.Binding
 
 	// Search
 	Search    key.Binding
@@ -51,6 +64,12 @@ type KeyMap struct {
 	CycleViewMode     key.Binding
 	CycleViewModeBack key.Binding
 
+	// Sort
+	CycleSort key.Binding
+
+	// Group
+	CycleGroup key.Binding
+
 	// Columns
 	ToggleColumns key.Binding
 
@@ -132,6 +151,18 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("c"),
 			key.WithHelp("c", "Copy ID"),
 		),
+		OpenLink: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "Open external ref link"),
+		),
+		CreateBranch: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "Create git branch for bead"),
+		),
+		History: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "Toggle bead history"),
+		),
 		Status: key.NewBinding(
 			key.WithKeys("s"),
 			key.WithHelp("s", "Change status"),
@@ -144,6 +175,22 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("p"),
 			key.WithHelp("p", "Change priority"),
 		),
+		Assignee: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "Change assignee"),
+		),
+		TakeBead: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "Take bead (assign me + start)"),
+		),
+		LabelManager: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "Manage project labels"),
+		),
+		MyWork: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "Toggle my work"),
+		),
 		NewBead: key.NewBinding(
 			key.WithKeys("N"),
 			key.WithHelp("N", "New child bead"),
@@ -152,6 +199,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("n"),
 			key.WithHelp("n", "New bead"),
 		),
+		ImportOutline: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "Import outline as children"),
+		),
 		Edit: key.NewBinding(
 			key.WithKeys("e"),
 			key.WithHelp("e", "Edit bead"),
@@ -160,6 +211,18 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("m"),
 			key.WithHelp("m", "Add comment"),
 		),
+		NextComment: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("[ / ]", "Select comment (in details)"),
+		),
+		PrevComment: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[ / ]", "Select comment (in details)"),
+		),
+		ReplyComment: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "Quote-reply to comment"),
+		),
 
 		// Search
 		Search: key.NewBinding(
@@ -205,6 +268,18 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("v/V", "Cycle view"),
 		),
 
+		// Sort
+		CycleSort: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "Cycle sort"),
+		),
+
+		// Group
+		CycleGroup: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "Group by"),
+		),
+
 		// Columns
 		ToggleColumns: key.NewBinding(
 			key.WithKeys("C"),
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"abacus/internal/graph"
+)
+
+// labelUsage is a label together with the beads that carry it.
+type labelUsage struct {
+	Name     string
+	IssueIDs []string
+}
+
+// LabelChangeOp is a project-wide label operation.
+type LabelChangeOp int
+
+const (
+	LabelChangeRename LabelChangeOp = iota // move every bead to a label that does not exist yet
+	LabelChangeMerge                       // fold a label into another existing label
+	LabelChangeDelete                      // remove a label from every bead
+)
+
+// labelChangeStep is the work needed on one bead. Add is empty when the bead
+// already carries the target label (or for deletes).
+type labelChangeStep struct {
+	IssueID string
+	Add     string
+	Remove  string
+}
+
+// LabelChangePlan is a previewed project-wide label change, applied one bead
+// at a time so progress can be reported and partial failures retried.
+type LabelChangePlan struct {
+	Op    LabelChangeOp
+	From  string
+	To    string
+	Steps []labelChangeStep
+}
+
+// collectLabelUsage gathers every label in the tree with the beads using it,
+// plus each bead's labels. Beads reachable through several parents are counted once.
+func collectLabelUsage(roots []*graph.Node) ([]labelUsage, map[string][]string) {
+	labelsByIssue := make(map[string][]string)
+	var walk func([]*graph.Node)
+	walk = func(nodes []*graph.Node) {
+		for _, n := range nodes {
+			if _, seen := labelsByIssue[n.Issue.ID]; seen {
+				continue
+			}
+			labelsByIssue[n.Issue.ID] = n.Issue.Labels
+			walk(n.Children)
+		}
+	}
+	walk(roots)
+
+	byLabel := make(map[string][]string)
+	for id, labels := range labelsByIssue {
+		for _, l := range labels {
+			byLabel[l] = append(byLabel[l], id)
+		}
+	}
+	usage := make([]labelUsage, 0, len(byLabel))
+	for name, ids := range byLabel {
+		sort.Strings(ids)
+		usage = append(usage, labelUsage{Name: name, IssueIDs: ids})
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Name < usage[j].Name })
+	return usage, labelsByIssue
+}
+
+// planLabelChange builds the per-bead steps for renaming, merging or deleting
+// the label from. Renaming onto an existing label becomes a merge.
+func planLabelChange(op LabelChangeOp, from, to string, usage []labelUsage, labelsByIssue map[string][]string) LabelChangePlan {
+	plan := LabelChangePlan{Op: op, From: from, To: to}
+	if op == LabelChangeDelete {
+		plan.To = ""
+	} else {
+		plan.Op = LabelChangeRename
+		for _, u := range usage {
+			if u.Name == to {
+				plan.Op = LabelChangeMerge
+				break
+			}
+		}
+	}
+
+	for _, u := range usage {
+		if u.Name != from {
+			continue
+		}
+		for _, id := range u.IssueIDs {
+			step := labelChangeStep{IssueID: id, Remove: from}
+			if plan.To != "" && !containsString(labelsByIssue[id], plan.To) {
+				step.Add = plan.To
+			}
+			plan.Steps = append(plan.Steps, step)
+		}
+	}
+	return plan
+}
+
+// Verb returns the present participle shown while the plan is applied.
+func (p LabelChangePlan) Verb() string {
+	switch p.Op {
+	case LabelChangeMerge:
+		return "Merging"
+	case LabelChangeDelete:
+		return "Deleting"
+	default:
+		return "Renaming"
+	}
+}
+
+// Summary describes the change, e.g. "back-end → backend".
+func (p LabelChangePlan) Summary() string {
+	if p.Op == LabelChangeDelete {
+		return p.From
+	}
+	return fmt.Sprintf("%s → %s", p.From, p.To)
+}
+
+// labelJob tracks a LabelChangePlan while it is applied bead by bead.
+type labelJob struct {
+	plan   LabelChangePlan
+	done   int
+	failed []string
+	err    error // first failure, surfaced in the error toast
+}
+
+func (j *labelJob) finished() bool {
+	return j.done >= len(j.plan.Steps)
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"abacus/internal/beads"
+	"abacus/internal/graph"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func labelledNode(id string, labels ...string) *graph.Node {
+	return &graph.Node{Issue: beads.FullIssue{ID: id, Title: id, Status: "open", Labels: labels}}
+}
+
+func buildLabelTree() []*graph.Node {
+	shared := labelledNode("ab-3", "back-end", "backend")
+	parentA := labelledNode("ab-1", "back-end", "ui")
+	parentB := labelledNode("ab-2", "backend")
+	parentA.Children = []*graph.Node{shared}
+	parentB.Children = []*graph.Node{shared}
+	return []*graph.Node{parentA, parentB}
+}
+
+func TestCollectLabelUsage(t *testing.T) {
+	usage, labelsByIssue := collectLabelUsage(buildLabelTree())
+
+	var got []string
+	for _, u := range usage {
+		got = append(got, u.Name+"="+strings.Join(u.IssueIDs, ","))
+	}
+	want := []string{"back-end=ab-1,ab-3", "backend=ab-2,ab-3", "ui=ab-1"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("unexpected usage (multi-parent beads must be counted once):\n got %v\nwant %v", got, want)
+	}
+	if len(labelsByIssue) != 3 {
+		t.Fatalf("expected 3 beads, got %d", len(labelsByIssue))
+	}
+}
+
+func TestPlanLabelChange(t *testing.T) {
+	usage, labelsByIssue := collectLabelUsage(buildLabelTree())
+
+	t.Run("renameToNewLabel", func(t *testing.T) {
+		plan := planLabelChange(LabelChangeRename, "ui", "frontend", usage, labelsByIssue)
+		if plan.Op != LabelChangeRename || len(plan.Steps) != 1 {
+			t.Fatalf("unexpected plan: %+v", plan)
+		}
+		if plan.Steps[0] != (labelChangeStep{IssueID: "ab-1", Add: "frontend", Remove: "ui"}) {
+			t.Fatalf("unexpected step: %+v", plan.Steps[0])
+		}
+	})
+
+	t.Run("renameOntoExistingBecomesMerge", func(t *testing.T) {
+		plan := planLabelChange(LabelChangeRename, "back-end", "backend", usage, labelsByIssue)
+		if plan.Op != LabelChangeMerge {
+			t.Fatalf("expected merge, got %v", plan.Op)
+		}
+		want := []labelChangeStep{
+			{IssueID: "ab-1", Add: "backend", Remove: "back-end"},
+			{IssueID: "ab-3", Remove: "back-end"}, // already labelled backend
+		}
+		if len(plan.Steps) != 2 || plan.Steps[0] != want[0] || plan.Steps[1] != want[1] {
+			t.Fatalf("unexpected steps: %+v", plan.Steps)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		plan := planLabelChange(LabelChangeDelete, "backend", "ignored", usage, labelsByIssue)
+		if plan.Op != LabelChangeDelete || plan.To != "" || len(plan.Steps) != 2 {
+			t.Fatalf("unexpected plan: %+v", plan)
+		}
+		for _, s := range plan.Steps {
+			if s.Add != "" || s.Remove != "backend" {
+				t.Fatalf("unexpected delete step: %+v", s)
+			}
+		}
+	})
+}
+
+func TestLabelManagerOverlayFlow(t *testing.T) {
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	confirm := func(t *testing.T, m *LabelManagerOverlay) LabelChangePlan {
+		t.Helper()
+		if m.mode != labelManagerPreview {
+			t.Fatalf("expected preview mode, got %v", m.mode)
+		}
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		msg, ok := cmd().(LabelChangeConfirmedMsg)
+		if !ok {
+			t.Fatal("expected LabelChangeConfirmedMsg")
+		}
+		return msg.Plan
+	}
+
+	t.Run("rename", func(t *testing.T) {
+		m := NewLabelManagerOverlay(collectLabelUsage(buildLabelTree()))
+		m.Update(runes("G"))
+		m.Update(runes("r"))
+		if m.renameInput.Value() != "ui" {
+			t.Fatalf("expected rename input prefilled, got %q", m.renameInput.Value())
+		}
+		m.renameInput.SetValue("frontend")
+		m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if !strings.Contains(stripANSI(m.View()), "ui → frontend") {
+			t.Fatalf("expected preview summary:\n%s", stripANSI(m.View()))
+		}
+		if plan := confirm(t, m); plan.From != "ui" || plan.To != "frontend" {
+			t.Fatalf("unexpected plan: %+v", plan)
+		}
+	})
+
+	t.Run("renameRejectsSameName", func(t *testing.T) {
+		m := NewLabelManagerOverlay(collectLabelUsage(buildLabelTree()))
+		m.Update(runes("r"))
+		m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if m.mode != labelManagerRename || m.errorMsg == "" {
+			t.Fatalf("expected validation error, mode=%v", m.mode)
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		m := NewLabelManagerOverlay(collectLabelUsage(buildLabelTree()))
+		m.Update(runes("m"))
+		if m.mode != labelManagerMerge {
+			t.Fatalf("expected merge mode, got %v", m.mode)
+		}
+		m.Update(ComboBoxEnterSelectedMsg{Value: "backend"})
+		plan := confirm(t, m)
+		if plan.Op != LabelChangeMerge || plan.From != "back-end" || len(plan.Steps) != 2 {
+			t.Fatalf("unexpected plan: %+v", plan)
+		}
+	})
+
+	t.Run("deletePreviewThenBack", func(t *testing.T) {
+		m := NewLabelManagerOverlay(collectLabelUsage(buildLabelTree()))
+		m.Update(runes("d"))
+		if m.mode != labelManagerPreview || m.plan.Op != LabelChangeDelete {
+			t.Fatalf("expected delete preview, got mode=%v op=%v", m.mode, m.plan.Op)
+		}
+		m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if m.mode != labelManagerList {
+			t.Fatalf("expected esc to return to list, got %v", m.mode)
+		}
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if _, ok := cmd().(LabelManagerClosedMsg); !ok {
+			t.Fatal("expected esc on list to close the manager")
+		}
+	})
+}
+
+func TestLabelJobAppliesStepsWithProgress(t *testing.T) {
+	client := beads.NewMockClient()
+	client.RemoveLabelFn = func(_ context.Context, issueID, _ string) error {
+		if issueID == "ab-3" {
+			return errors.New("locked")
+		}
+		return nil
+	}
+	m := &App{client: client, roots: buildLabelTree()}
+	usage, labelsByIssue := collectLabelUsage(m.roots)
+	plan := planLabelChange(LabelChangeMerge, "back-end", "backend", usage, labelsByIssue)
+
+	_, cmd := m.Update(LabelChangeConfirmedMsg{Plan: plan})
+	if m.labelJob == nil || !m.labelJobToastVisible {
+		t.Fatal("expected label job to start with a progress toast")
+	}
+	for !m.labelJob.finished() {
+		msg, ok := cmd().(labelStepCompleteMsg)
+		if !ok {
+			t.Fatalf("expected labelStepCompleteMsg, got %T", msg)
+		}
+		_, cmd = m.Update(msg)
+	}
+
+	if client.AddLabelCallCount != 1 || client.RemoveLabelCallCount != 2 {
+		t.Fatalf("expected 1 add and 2 removes, got %d/%d", client.AddLabelCallCount, client.RemoveLabelCallCount)
+	}
+	if len(m.labelJob.failed) != 1 || m.labelJob.failed[0] != "ab-3" {
+		t.Fatalf("expected ab-3 recorded as failed, got %v", m.labelJob.failed)
+	}
+	if !m.showErrorToast || !strings.Contains(m.lastError, "1 of 2 beads failed") {
+		t.Fatalf("expected partial failure surfaced, got %q", m.lastError)
+	}
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"hash/fnv"
+	"path"
+	"strings"
+
+	"abacus/internal/config"
+	"abacus/internal/ui/theme"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// labelStyle is the resolved chip color and icon for a label.
+type labelStyle struct {
+	color lipgloss.TerminalColor
+	icon  string
+	// configured is true when a labels.styles rule matched, as opposed to the
+	// hash-derived default. Only configured labels are marked in tree rows.
+	configured bool
+}
+
+// labelDefaultPalette returns the theme colors hash-derived label colors are
+// drawn from. Error is left out so it stays meaningful for configured labels.
+func labelDefaultPalette() []lipgloss.TerminalColor {
+	t := theme.Current()
+	return []lipgloss.TerminalColor{t.Info(), t.Primary(), t.Secondary(), t.Accent(), t.Success(), t.Warning()}
+}
+
+// resolveLabelStyle looks up the style for a label. Exact matches win over
+// globs; globs are tried in config order. Unmatched labels get a stable
+// theme color picked from a hash of the name.
+func resolveLabelStyle(label string) labelStyle {
+	return matchLabelStyle(label, config.GetLabelStyles())
+}
+
+func matchLabelStyle(label string, rules []config.LabelStyle) labelStyle {
+	for _, r := range rules {
+		if r.Match == label {
+			return styleFromRule(label, r)
+		}
+	}
+	for _, r := range rules {
+		if !strings.ContainsAny(r.Match, "*?[") {
+			continue
+		}
+		if ok, err := path.Match(r.Match, label); err == nil && ok {
+			return styleFromRule(label, r)
+		}
+	}
+	return labelStyle{color: hashedLabelColor(label)}
+}
+
+func styleFromRule(label string, r config.LabelStyle) labelStyle {
+	color := parseLabelColor(r.Color)
+	if color == nil {
+		color = hashedLabelColor(label)
+	}
+	return labelStyle{color: color, icon: strings.TrimSpace(r.Icon), configured: true}
+}
+
+// parseLabelColor resolves a theme color name, hex value or ANSI number.
+// Returns nil for an empty value.
+func parseLabelColor(value string) lipgloss.TerminalColor {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	t := theme.Current()
+	switch strings.ToLower(value) {
+	case "error":
+		return t.Error()
+	case "warning":
+		return t.Warning()
+	case "success":
+		return t.Success()
+	case "info":
+		return t.Info()
+	case "primary":
+		return t.Primary()
+	case "secondary":
+		return t.Secondary()
+	case "accent":
+		return t.Accent()
+	}
+	return lipgloss.Color(value)
+}
+
+func hashedLabelColor(label string) lipgloss.TerminalColor {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(label))
+	palette := labelDefaultPalette()
+	return palette[h.Sum32()%uint32(len(palette))]
+}
+
+// labelChipText prefixes the label with its configured icon, if any.
+func labelChipText(label string, style labelStyle) string {
+	if style.icon == "" {
+		return label
+	}
+	return style.icon + " " + label
+}
+
+// labelMark is a tree-row marker for a configured label.
+type labelMark struct {
+	text  string
+	color lipgloss.TerminalColor
+}
+
+// treeLabelMarks returns markers for the labels that match a configured
+// style: the rule's icon, or a colored dot when the rule has no icon.
+func treeLabelMarks(labels []string) []labelMark {
+	if len(labels) == 0 {
+		return nil
+	}
+	rules := config.GetLabelStyles()
+	if len(rules) == 0 {
+		return nil
+	}
+	var marks []labelMark
+	for _, l := range labels {
+		style := matchLabelStyle(l, rules)
+		if !style.configured {
+			continue
+		}
+		text := style.icon
+		if text == "" {
+			text = "●"
+		}
+		marks = append(marks, labelMark{text: text, color: style.color})
+	}
+	return marks
+}
+
+// plainLabelMarks joins markers without styling, for rows that apply their
+// own full-row style (selected and cross-highlighted rows).
+func plainLabelMarks(marks []labelMark) string {
+	parts := make([]string, len(marks))
+	for i, mk := range marks {
+		parts[i] = mk.text
+	}
+	return strings.Join(parts, "")
+}
+
+// renderLabelMarks renders markers in their label colors.
+func renderLabelMarks(marks []labelMark) string {
+	var sb strings.Builder
+	for _, mk := range marks {
+		sb.WriteString(styleNormalText().Foreground(mk.color).Render(mk.text))
+	}
+	return sb.String()
+}
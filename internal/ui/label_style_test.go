@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"abacus/internal/config"
+	"abacus/internal/ui/theme"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func setTestLabelStyles(t *testing.T, rules []map[string]any) {
+	t.Helper()
+	if err := config.Set(config.KeyLabelStyles, rules); err != nil {
+		t.Fatalf("set label styles: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = config.Set(config.KeyLabelStyles, []map[string]any{})
+	})
+}
+
+func TestResolveLabelStyle(t *testing.T) {
+	setTestLabelStyles(t, []map[string]any{
+		{"match": "area/*", "color": "#7aa2f7"},
+		{"match": "area/ui", "color": "success", "icon": "◆"},
+		{"match": "security", "color": "error", "icon": "!"},
+	})
+
+	t.Run("exactBeatsEarlierGlob", func(t *testing.T) {
+		style := resolveLabelStyle("area/ui")
+		if !style.configured || style.icon != "◆" || style.color != theme.Current().Success() {
+			t.Fatalf("unexpected style: %+v", style)
+		}
+	})
+
+	t.Run("glob", func(t *testing.T) {
+		style := resolveLabelStyle("area/backend")
+		if !style.configured || style.color != lipgloss.Color("#7aa2f7") {
+			t.Fatalf("unexpected style: %+v", style)
+		}
+	})
+
+	t.Run("unconfiguredIsStableThemeColor", func(t *testing.T) {
+		a, b := resolveLabelStyle("misc"), resolveLabelStyle("misc")
+		if a.configured || a.color != b.color {
+			t.Fatalf("expected stable default color, got %+v / %+v", a, b)
+		}
+		found := false
+		for _, c := range labelDefaultPalette() {
+			if c == a.color {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected default color from theme palette, got %v", a.color)
+		}
+	})
+}
+
+func TestTreeLabelMarks(t *testing.T) {
+	if marks := treeLabelMarks([]string{"security"}); marks != nil {
+		t.Fatalf("expected no marks without config, got %+v", marks)
+	}
+
+	setTestLabelStyles(t, []map[string]any{
+		{"match": "security", "color": "error", "icon": "!"},
+		{"match": "area/*", "color": "info"},
+	})
+	marks := treeLabelMarks([]string{"misc", "security", "area/ui"})
+	if got := plainLabelMarks(marks); got != "!●" {
+		t.Fatalf("expected icon then dot for configured labels only, got %q", got)
+	}
+}
+
+func TestLabelChipIncludesIcon(t *testing.T) {
+	setTestLabelStyles(t, []map[string]any{
+		{"match": "security", "color": "error", "icon": "!"},
+	})
+	chip := stripANSI(renderPillChip("security", chipStateNormal))
+	if !strings.Contains(chip, "! security") {
+		t.Fatalf("expected icon inside chip, got %q", chip)
+	}
+}
@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"abacus/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OSC 8 hyperlink delimiters. Terminals without hyperlink support ignore them.
+const (
+	hyperlinkOpen  = "\x1b]8;;"
+	hyperlinkClose = "\x1b\\"
+)
+
+// linkRule is a compiled links.patterns entry.
+type linkRule struct {
+	re  *regexp.Regexp
+	url string
+}
+
+// linkRules compiles the configured link patterns, skipping invalid regexes.
+func linkRules() []linkRule {
+	patterns := config.GetLinkPatterns()
+	if len(patterns) == 0 {
+		return nil
+	}
+	rules := make([]linkRule, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p.Match)
+		if err != nil {
+			continue
+		}
+		rules = append(rules, linkRule{re: re, url: p.URL})
+	}
+	return rules
+}
+
+// expand builds the link for a match of r.re in s at loc.
+func (r linkRule) expand(s string, loc []int) string {
+	return string(r.re.ExpandString(nil, r.url, s, loc))
+}
+
+// externalRefURL returns the link for a bead's external ref: the ref itself
+// when it is already a URL, otherwise the first pattern matching the whole ref.
+func externalRefURL(ref string) (string, bool) {
+	return matchRefURL(ref, linkRules())
+}
+
+func matchRefURL(ref string, rules []linkRule) (string, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", false
+	}
+	if isWebURL(ref) {
+		return ref, true
+	}
+	for _, r := range rules {
+		if loc := r.re.FindStringSubmatchIndex(ref); loc != nil && loc[0] == 0 && loc[1] == len(ref) {
+			return r.expand(ref, loc), true
+		}
+	}
+	return "", false
+}
+
+func isWebURL(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+}
+
+// hyperlink wraps text in an OSC 8 hyperlink to url.
+func hyperlink(url, text string) string {
+	return hyperlinkOpen + url + hyperlinkClose + text + hyperlinkOpen + hyperlinkClose
+}
+
+// linkifyRenderer wraps a markdown renderer so pattern matches in its output
+// become hyperlinks.
+func linkifyRenderer(render func(string) string) func(string) string {
+	rules := linkRules()
+	if len(rules) == 0 {
+		return render
+	}
+	return func(s string) string {
+		return linkifyWithRules(render(s), rules)
+	}
+}
+
+// linkifyWithRules turns pattern matches in already-rendered (ANSI-styled)
+// text into hyperlinks. Escape sequences are left untouched, so a match
+// must fall within a single run of plain text; text already inside a
+// hyperlink is skipped.
+func linkifyWithRules(s string, rules []linkRule) string {
+	if len(rules) == 0 || s == "" {
+		return s
+	}
+	var sb strings.Builder
+	inLink := false
+	for len(s) > 0 {
+		esc := strings.IndexByte(s, '\x1b')
+		if esc < 0 {
+			esc = len(s)
+		}
+		if esc > 0 {
+			if inLink {
+				sb.WriteString(s[:esc])
+			} else {
+				sb.WriteString(linkifyPlain(s[:esc], rules))
+			}
+			s = s[esc:]
+			continue
+		}
+		n := escapeSequenceLen(s)
+		seq := s[:n]
+		if strings.HasPrefix(seq, hyperlinkOpen) {
+			// An OSC 8 with an empty URL closes the current link.
+			inLink = strings.TrimSuffix(strings.TrimSuffix(seq[len(hyperlinkOpen):], hyperlinkClose), "\a") != ""
+		}
+		sb.WriteString(seq)
+		s = s[n:]
+	}
+	return sb.String()
+}
+
+// linkifyPlain links every non-overlapping match in plain text, taking the
+// earliest match across all rules (the first rule wins ties).
+func linkifyPlain(text string, rules []linkRule) string {
+	var sb strings.Builder
+	for {
+		best, bestLoc := -1, []int(nil)
+		for i, r := range rules {
+			loc := r.re.FindStringSubmatchIndex(text)
+			if loc == nil || loc[0] == loc[1] {
+				continue
+			}
+			if bestLoc == nil || loc[0] < bestLoc[0] {
+				best, bestLoc = i, loc
+			}
+		}
+		if best < 0 {
+			sb.WriteString(text)
+			return sb.String()
+		}
+		sb.WriteString(text[:bestLoc[0]])
+		sb.WriteString(hyperlink(rules[best].expand(text, bestLoc), text[bestLoc[0]:bestLoc[1]]))
+		text = text[bestLoc[1]:]
+	}
+}
+
+// escapeSequenceLen returns the length of the escape sequence at the start
+// of s: CSI sequences end at a final byte, OSC sequences at BEL or ST.
+func escapeSequenceLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+		return len(s)
+	case ']':
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	}
+	return 2
+}
+
+// openURLCommand returns the command that opens url with the system opener.
+// Tests replace it to avoid launching a browser.
+var openURLCommand = func(url string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return exec.Command("xdg-open", url)
+	}
+}
+
+// openURL starts the system opener without waiting for it to exit.
+func openURL(url string) tea.Cmd {
+	return func() tea.Msg {
+		cmd := openURLCommand(url)
+		err := cmd.Start()
+		if err == nil {
+			go func() { _ = cmd.Wait() }()
+		}
+		return linkOpenedMsg{url: url, err: err}
+	}
+}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"abacus/internal/beads"
+	"abacus/internal/config"
+	"abacus/internal/graph"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func setTestLinkPatterns(t *testing.T, patterns []map[string]any) {
+	t.Helper()
+	if err := config.Set(config.KeyLinkPatterns, patterns); err != nil {
+		t.Fatalf("set link patterns: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = config.Set(config.KeyLinkPatterns, []map[string]any{})
+	})
+}
+
+func TestExternalRefURL(t *testing.T) {
+	setTestLinkPatterns(t, []map[string]any{
+		{"match": `gh-(\d+)`, "url": "https://github.com/acme/app/issues/$1"},
+		{"match": `JIRA-\d+`, "url": "https://acme.atlassian.net/browse/$0"},
+		{"match": `bad(`, "url": "https://example.com"},
+	})
+
+	tests := []struct {
+		ref    string
+		want   string
+		wantOK bool
+	}{
+		{"gh-42", "https://github.com/acme/app/issues/42", true},
+		{"JIRA-7", "https://acme.atlassian.net/browse/JIRA-7", true},
+		{"https://gitlab.com/acme/app/-/issues/3", "https://gitlab.com/acme/app/-/issues/3", true},
+		{"see gh-42", "", false}, // refs must match a pattern in full
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := externalRefURL(tt.ref)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("externalRefURL(%q) = %q, %v; want %q, %v", tt.ref, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestLinkifyRenderedText(t *testing.T) {
+	setTestLinkPatterns(t, []map[string]any{
+		{"match": `gh-(\d+)`, "url": "https://github.com/acme/app/issues/$1"},
+		{"match": `JIRA-\d+`, "url": "https://acme.atlassian.net/browse/$0"},
+	})
+	rules := linkRules()
+
+	styled := "\x1b[1mFixes gh-12\x1b[0m and JIRA-9."
+	got := linkifyWithRules(styled, rules)
+	want := "\x1b[1mFixes " + hyperlink("https://github.com/acme/app/issues/12", "gh-12") + "\x1b[0m and " +
+		hyperlink("https://acme.atlassian.net/browse/JIRA-9", "JIRA-9") + "."
+	if got != want {
+		t.Errorf("linkify:\n got %q\nwant %q", got, want)
+	}
+	if ansi.Strip(got) != ansi.Strip(styled) {
+		t.Errorf("linkify should not change visible text: %q", ansi.Strip(got))
+	}
+
+	existing := hyperlink("https://example.com", "gh-1") + " gh-2"
+	if got := linkifyWithRules(existing, rules); strings.Count(got, hyperlinkOpen+"https://github.com") != 1 {
+		t.Errorf("expected text inside an existing link to be left alone, got %q", got)
+	}
+}
+
+func TestDetailPaneLinksRefsAndDescription(t *testing.T) {
+	setTestLinkPatterns(t, []map[string]any{
+		{"match": `gh-(\d+)`, "url": "https://github.com/acme/app/issues/$1"},
+	})
+	node := &graph.Node{
+		Issue: beads.FullIssue{
+			ID:          "ab-503",
+			Title:       "Linked bead",
+			ExternalRef: "gh-42",
+			Description: "Follow-up to gh-7",
+			Comments:    []beads.Comment{{Author: "qa", Text: "Also see gh-8"}},
+		},
+		CommentsLoaded: true,
+	}
+	for _, format := range []string{"plain", "rich"} {
+		app := &App{
+			ShowDetails:  true,
+			visibleRows:  []graph.TreeRow{{Node: node}},
+			viewport:     viewport.New(80, 30),
+			outputFormat: format,
+		}
+		app.updateViewportContent()
+		content := app.viewport.View()
+		for _, n := range []string{"42", "7", "8"} {
+			if !strings.Contains(content, hyperlinkOpen+"https://github.com/acme/app/issues/"+n+hyperlinkClose) {
+				t.Errorf("%s: expected hyperlink to issue %s in detail pane", format, n)
+			}
+		}
+	}
+}
+
+func TestCanvasKeepsHyperlinks(t *testing.T) {
+	canvas := NewCanvas(20, 1)
+	canvas.DrawStringAt(0, 0, hyperlink("https://example.com/1", "gh-1"))
+	if out := canvas.Render(); !strings.Contains(out, "https://example.com/1") {
+		t.Errorf("expected hyperlink to survive canvas composition, got %q", out)
+	}
+}
+
+func TestOpenLinkKeyOpensResolvedRef(t *testing.T) {
+	setTestLinkPatterns(t, []map[string]any{
+		{"match": `gh-(\d+)`, "url": "https://github.com/acme/app/issues/$1"},
+	})
+	var opened string
+	orig := openURLCommand
+	openURLCommand = func(url string) *exec.Cmd {
+		opened = url
+		return exec.Command("true")
+	}
+	t.Cleanup(func() { openURLCommand = orig })
+
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-501", Title: "Linked", ExternalRef: "gh-42"}}
+	app := &App{visibleRows: nodesToRows(node), keys: DefaultKeyMap(), ready: true}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd == nil {
+		t.Fatal("expected open command")
+	}
+	msg, ok := cmd().(linkOpenedMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("expected successful linkOpenedMsg, got %#v", msg)
+	}
+	if opened != "https://github.com/acme/app/issues/42" {
+		t.Errorf("opened %q", opened)
+	}
+
+	app.Update(msg)
+	if !app.linkToastVisible || app.linkToastURL != opened {
+		t.Error("expected link toast after opening")
+	}
+}
+
+func TestOpenLinkKeyWithoutLinkShowsError(t *testing.T) {
+	setTestLinkPatterns(t, []map[string]any{})
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-502", Title: "Unlinked", ExternalRef: "legacy-9"}}
+	app := &App{visibleRows: nodesToRows(node), keys: DefaultKeyMap(), ready: true}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if !app.showErrorToast || !strings.Contains(app.lastError, "legacy-9") {
+		t.Errorf("expected error toast naming the ref, got %q", app.lastError)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"abacus/internal/beads"
 	"abacus/internal/config"
+	"abacus/internal/gitinfo"
 	"abacus/internal/graph"
 	"abacus/internal/update"
 
@@ -43,6 +44,46 @@ func scheduleCopyToastTick() tea.Cmd {
 	})
 }
 
+type linkOpenedMsg struct {
+	url string
+	err error
+}
+
+type linkToastTickMsg struct{}
+
+func scheduleLinkToastTick() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+		return linkToastTickMsg{}
+	})
+}
+
+// gitRefsLoadedMsg carries the commits and branches mentioning each bead.
+type gitRefsLoadedMsg struct {
+	refs map[string]gitinfo.Refs
+	err  error
+}
+
+// historyLoadedMsg carries the change history of one bead.
+type historyLoadedMsg struct {
+	issueID string
+	events  []beads.HistoryEvent
+	err     error
+}
+
+type branchCreatedMsg struct {
+	issueID string
+	name    string
+	err     error
+}
+
+type branchToastTickMsg struct{}
+
+func scheduleBranchToastTick() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+		return branchToastTickMsg{}
+	})
+}
+
 type newLabelToastTickMsg struct{}
 
 func scheduleNewLabelToastTick() tea.Cmd {
@@ -67,6 +108,14 @@ func scheduleThemeToastTick() tea.Cmd {
 	})
 }
 
+type queuedToastTickMsg struct{}
+
+func scheduleQueuedToastTick() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+		return queuedToastTickMsg{}
+	})
+}
+
 type columnsToastTickMsg struct{}
 
 func scheduleColumnsToastTick() tea.Cmd {
@@ -143,6 +192,22 @@ func scheduleUpdateFailureToastTick() tea.Cmd {
 	})
 }
 
+type sortToastTickMsg struct{}
+
+func scheduleSortToastTick() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+		return sortToastTickMsg{}
+	})
+}
+
+type groupToastTickMsg struct{}
+
+func scheduleGroupToastTick() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+		return groupToastTickMsg{}
+	})
+}
+
 type layoutToastTickMsg struct{}
 
 func scheduleLayoutToastTick() tea.Cmd {
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// mouseWheelLines is how far one wheel notch scrolls the detail pane.
+const mouseWheelLines = 3
+
+// mousePane identifies the pane under the pointer.
+type mousePane int
+
+const (
+	paneNone mousePane = iota
+	paneTree
+	paneDetails
+)
+
+// handleMouseMsg routes mouse events. Overlays take the event when open;
+// otherwise clicks and the wheel act on the tree or detail pane under the
+// pointer. Only presses are handled, so drags and releases are ignored.
+func (m *App) handleMouseMsg(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress || m.showHelp || m.searching {
+		return m, nil
+	}
+	if m.activeOverlay != OverlayNone {
+		if msg.Button == tea.MouseButtonLeft {
+			return m, m.handleOverlayClick(msg.X, msg.Y)
+		}
+		return m, nil
+	}
+
+	pane, col, row := m.paneAt(msg.X, msg.Y)
+	switch msg.Button {
+	case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+		up := msg.Button == tea.MouseButtonWheelUp
+		switch pane {
+		case paneTree:
+			if up {
+				m.cursor--
+			} else {
+				m.cursor++
+			}
+			m.clampCursor()
+			m.updateViewportContent()
+		case paneDetails:
+			if up {
+				m.viewport.ScrollUp(mouseWheelLines)
+			} else {
+				m.viewport.ScrollDown(mouseWheelLines)
+			}
+		}
+	case tea.MouseButtonLeft:
+		switch pane {
+		case paneTree:
+			return m.handleTreeClick(col, row)
+		case paneDetails:
+			return m.handleDetailClick(row)
+		}
+	}
+	return m, nil
+}
+
+// paneAt maps a screen position to the pane under it and the position
+// within that pane's content (inside the border).
+func (m *App) paneAt(x, y int) (mousePane, int, int) {
+	top := m.mainBodyTop + 1 // pane top border
+	inside := func(x0, y0, w, h int) bool {
+		return x >= x0 && x < x0+w && y >= y0 && y < y0+h
+	}
+
+	treeHeight := m.treePaneHeight()
+	treeWidth := m.width - 2
+	if m.ShowDetails && m.layout != LayoutTall {
+		treeWidth = m.width - m.viewport.Width - 4
+	}
+	if inside(1, top, treeWidth, treeHeight) {
+		return paneTree, x - 1, y - top
+	}
+	if !m.ShowDetails {
+		return paneNone, 0, 0
+	}
+
+	detailX, detailY := treeWidth+3, top
+	if m.layout == LayoutTall {
+		detailX, detailY = 1, top+treeHeight+2
+	}
+	if inside(detailX, detailY, m.viewport.Width, m.viewport.Height) {
+		return paneDetails, x - detailX, y - detailY
+	}
+	return paneNone, 0, 0
+}
+
+// handleTreeClick selects the clicked row; a click on its expand marker
+// also toggles it, like Space.
+func (m *App) handleTreeClick(col, row int) (tea.Model, tea.Cmd) {
+	idx := m.treeTopLine + row
+	if idx < 0 || idx >= len(m.visibleRows) {
+		return m, nil
+	}
+	m.focus = FocusTree
+	m.cursor = idx
+	m.updateViewportContent()
+
+	// Rows start with " " + two columns per depth level + " ▶".
+	markerStart := 2*m.visibleRows[idx].Depth + 1
+	if col >= markerStart && col <= markerStart+1 {
+		return m.handleTreeExpand()
+	}
+	return m, nil
+}
+
+// handleDetailClick focuses the detail pane and, when the click lands on a
+// relationship entry, jumps to that bead.
+func (m *App) handleDetailClick(row int) (tea.Model, tea.Cmd) {
+	m.focus = FocusDetails
+	id, ok := m.detailRelatedLines[m.viewport.YOffset+row]
+	if !ok {
+		return m, nil
+	}
+	if !m.revealBead(id) {
+		m.lastError = fmt.Sprintf("%s is not visible in the current view", id)
+		m.lastErrorSource = errorSourceOperation
+		m.showErrorToast = true
+		m.errorToastStart = time.Now()
+		return m, scheduleErrorToastTick()
+	}
+	m.updateViewportContent()
+	return m, nil
+}
+
+// revealBead moves the cursor to the bead, expanding its ancestors if it is
+// hidden under a collapsed parent. Returns false if the bead is not shown
+// in the current view (e.g. filtered out).
+func (m *App) revealBead(id string) bool {
+	find := func() bool {
+		for idx, row := range m.visibleRows {
+			if row.Node.Issue.ID == id {
+				m.cursor = idx
+				return true
+			}
+		}
+		return false
+	}
+	if find() {
+		return true
+	}
+	if m.findNodeByID(id) == nil {
+		return false
+	}
+	m.expandAncestorsForRow(id, "")
+	m.recalcVisibleRows()
+	return find()
+}
+
+// handleOverlayClick picks the clicked option in the status and priority
+// overlays. Clicks elsewhere are ignored.
+func (m *App) handleOverlayClick(x, y int) tea.Cmd {
+	switch {
+	case m.activeOverlay == OverlayStatus && m.statusOverlay != nil:
+		if line, ok := m.overlayLineAt(m.statusOverlay.View(), x, y); ok {
+			return m.statusOverlay.clickOption(line)
+		}
+	case m.activeOverlay == OverlayPriority && m.priorityOverlay != nil:
+		if line, ok := m.overlayLineAt(m.priorityOverlay.View(), x, y); ok {
+			return m.priorityOverlay.clickOption(line)
+		}
+	}
+	return nil
+}
+
+// overlayLineAt returns the plain text of the overlay line under (x, y),
+// placing the overlay the same way BaseOverlayLayer does.
+func (m *App) overlayLineAt(view string, x, y int) (string, bool) {
+	width, height := lipgloss.Width(view), lipgloss.Height(view)
+	ox, oy := centeredOffsets(m.width, m.height, width, height, m.mainBodyTop, m.footerHeight)
+	if x < ox || x >= ox+width || y < oy || y >= oy+height {
+		return "", false
+	}
+	lines := strings.Split(view, "\n")
+	if y-oy >= len(lines) {
+		return "", false
+	}
+	return ansi.Strip(lines[y-oy]), true
+}
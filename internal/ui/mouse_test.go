@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"abacus/internal/beads"
+	"abacus/internal/graph"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func buildMouseTestApp(showDetails bool) (*App, *graph.Node, *graph.Node) {
+	parent := &graph.Node{Issue: beads.FullIssue{ID: "ab-m1", Title: "Parent", Status: "open", Priority: 2}}
+	child := &graph.Node{Issue: beads.FullIssue{ID: "ab-m2", Title: "Child", Status: "open", Priority: 2}, Parent: parent, Parents: []*graph.Node{parent}}
+	parent.Children = []*graph.Node{child}
+	other := &graph.Node{Issue: beads.FullIssue{ID: "ab-m3", Title: "Other", Status: "open", Priority: 2}}
+	app := &App{
+		roots:        []*graph.Node{parent, other},
+		keys:         DefaultKeyMap(),
+		ready:        true,
+		width:        100,
+		height:       30,
+		ShowDetails:  showDetails,
+		viewport:     viewport.New(40, 20),
+		outputFormat: "plain",
+	}
+	app.recalcVisibleRows()
+	app.updateViewportContent()
+	return app, parent, child
+}
+
+// screenPos renders the app and returns the screen cell where text first
+// appears at or after column minX.
+func screenPos(t *testing.T, app *App, text string, minX int) (int, int) {
+	t.Helper()
+	for y, line := range strings.Split(ansi.Strip(app.View()), "\n") {
+		offset := 0
+		for {
+			idx := strings.Index(line[offset:], text)
+			if idx < 0 {
+				break
+			}
+			x := ansi.StringWidth(line[:offset+idx])
+			if x >= minX {
+				return x, y
+			}
+			offset += idx + len(text)
+		}
+	}
+	t.Fatalf("%q not found on screen", text)
+	return 0, 0
+}
+
+func click(app *App, x, y int) tea.Cmd {
+	_, cmd := app.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	return cmd
+}
+
+func TestMouseClickSelectsTreeRowAndTogglesMarker(t *testing.T) {
+	app, _, _ := buildMouseTestApp(false)
+
+	x, y := screenPos(t, app, "ab-m3", 0)
+	click(app, x, y)
+	if app.cursor != 1 {
+		t.Fatalf("expected click to select ab-m3, cursor=%d", app.cursor)
+	}
+
+	x, y = screenPos(t, app, "▶", 0)
+	click(app, x, y)
+	if app.cursor != 0 || len(app.visibleRows) != 3 {
+		t.Fatalf("expected marker click to select and expand ab-m1, cursor=%d rows=%d", app.cursor, len(app.visibleRows))
+	}
+
+	// Clicking the title selects without toggling.
+	x, y = screenPos(t, app, "Parent", 0)
+	click(app, x, y)
+	if len(app.visibleRows) != 3 {
+		t.Errorf("expected title click to leave expansion alone, rows=%d", len(app.visibleRows))
+	}
+}
+
+func TestMouseWheelScrollsTreeAndDetail(t *testing.T) {
+	app, _, _ := buildMouseTestApp(true)
+
+	x, y := screenPos(t, app, "ab-m1", 0)
+	app.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	if app.cursor != 1 {
+		t.Fatalf("expected wheel down in tree to move the cursor, cursor=%d", app.cursor)
+	}
+
+	app.visibleRows[1].Node.Issue.Description = strings.Repeat("A long description.\n\n", 40)
+	app.updateViewportContent()
+	detailX := app.width - app.viewport.Width
+	app.Update(tea.MouseMsg{X: detailX, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown})
+	if app.viewport.YOffset != mouseWheelLines {
+		t.Errorf("expected wheel down in detail to scroll %d lines, offset=%d", mouseWheelLines, app.viewport.YOffset)
+	}
+	if app.cursor != 1 {
+		t.Errorf("detail scrolling should not move the tree cursor, cursor=%d", app.cursor)
+	}
+}
+
+func TestMouseClickRelationshipJumpsToBead(t *testing.T) {
+	app, _, child := buildMouseTestApp(true)
+	detailX := app.width - app.viewport.Width - 1
+
+	// The child is collapsed under ab-m1, so jumping must reveal it.
+	x, y := screenPos(t, app, child.Issue.ID, detailX)
+	click(app, x, y)
+	if got := app.visibleRows[app.cursor].Node.Issue.ID; got != child.Issue.ID {
+		t.Fatalf("expected jump to %s, cursor on %s", child.Issue.ID, got)
+	}
+	if app.focus != FocusDetails {
+		t.Error("expected detail click to focus the detail pane")
+	}
+
+	// Child's detail lists its parent under "Part Of".
+	x, y = screenPos(t, app, "ab-m1", detailX)
+	click(app, x, y)
+	if got := app.visibleRows[app.cursor].Node.Issue.ID; got != "ab-m1" {
+		t.Errorf("expected jump back to ab-m1, cursor on %s", got)
+	}
+}
+
+func TestMouseClickStatusOverlayOption(t *testing.T) {
+	app, parent, _ := buildMouseTestApp(false)
+	app.activeOverlay = OverlayStatus
+	app.statusOverlay = NewStatusOverlay(parent.Issue.ID, parent.Issue.Title, "open")
+
+	x, y := screenPos(t, app, "In Progress", 0)
+	cmd := click(app, x, y)
+	if cmd == nil {
+		t.Fatal("expected status change command")
+	}
+	msg, ok := cmd().(StatusChangedMsg)
+	if !ok || msg.NewStatus != "in_progress" {
+		t.Errorf("expected in_progress, got %#v", msg)
+	}
+
+	// Clicks outside the overlay do nothing.
+	if cmd := click(app, 0, app.height-1); cmd != nil {
+		t.Error("expected click outside the overlay to be ignored")
+	}
+}
+
+func TestMouseClickPriorityOverlayOption(t *testing.T) {
+	app, parent, _ := buildMouseTestApp(false)
+	app.activeOverlay = OverlayPriority
+	app.priorityOverlay = NewPriorityOverlay(parent.Issue.ID, parent.Issue.Title, 2)
+
+	x, y := screenPos(t, app, "Critical", 0)
+	cmd := click(app, x, y)
+	if cmd == nil {
+		t.Fatal("expected priority change command")
+	}
+	if msg, ok := cmd().(PriorityChangedMsg); !ok || msg.NewPriority != 0 {
+		t.Errorf("expected priority 0, got %#v", msg)
+	}
+}
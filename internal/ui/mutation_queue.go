@@ -0,0 +1,426 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"abacus/internal/beads"
+	"abacus/internal/debug"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PendingMutationsFile is the project-relative file holding writes that are
+// waiting to be retried, so they survive a restart.
+const PendingMutationsFile = ".abacus/pending-mutations.json"
+
+const (
+	mutationRetryBase = 2 * time.Second
+	mutationRetryMax  = 2 * time.Minute
+
+	// mutationMaxAttempts bounds how often a write is tried before it is
+	// dropped as failed, about ten minutes of backoff.
+	mutationMaxAttempts = 10
+)
+
+// mutationKind identifies a queued write. The values are persisted.
+type mutationKind string
+
+const (
+	mutationStatus      mutationKind = "status"
+	mutationReopen      mutationKind = "reopen"
+	mutationPriority    mutationKind = "priority"
+	mutationAssignee    mutationKind = "assignee"
+	mutationAddLabel    mutationKind = "label_add"
+	mutationRemoveLabel mutationKind = "label_remove"
+	mutationAddComment  mutationKind = "comment_add"
+	mutationDelete      mutationKind = "delete"
+)
+
+// pendingMutation is one write that failed with a retryable error and is
+// waiting to be applied again.
+type pendingMutation struct {
+	ID       int          `json:"id"`
+	Kind     mutationKind `json:"kind"`
+	IssueID  string       `json:"issue_id"`
+	Value    string       `json:"value,omitempty"` // status, assignee, label or comment text
+	Priority int          `json:"priority,omitempty"`
+	Cascade  bool         `json:"cascade,omitempty"`
+
+	QueuedAt    time.Time `json:"queued_at"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// apply performs the write.
+func (p pendingMutation) apply(ctx context.Context, w beads.Writer) error {
+	switch p.Kind {
+	case mutationStatus:
+		return w.UpdateStatus(ctx, p.IssueID, p.Value)
+	case mutationReopen:
+		return w.Reopen(ctx, p.IssueID)
+	case mutationPriority:
+		return w.UpdatePriority(ctx, p.IssueID, p.Priority)
+	case mutationAssignee:
+		return w.UpdateAssignee(ctx, p.IssueID, p.Value)
+	case mutationAddLabel:
+		return w.AddLabel(ctx, p.IssueID, p.Value)
+	case mutationRemoveLabel:
+		return w.RemoveLabel(ctx, p.IssueID, p.Value)
+	case mutationAddComment:
+		return w.AddComment(ctx, p.IssueID, p.Value)
+	case mutationDelete:
+		// A delete that timed out may have gone through already.
+		if err := w.Delete(ctx, p.IssueID, p.Cascade); !errors.Is(err, beads.ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown queued write %q", p.Kind)
+}
+
+// context returns the context the write runs under. Comments and deletes
+// get no deadline: a large cascade delete can outlast statusCommandTimeout,
+// and a comment that times out after the backend stored it cannot be told
+// apart from one that failed.
+func (p pendingMutation) context() (context.Context, context.CancelFunc) {
+	if p.Kind == mutationAddComment || p.Kind == mutationDelete {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), statusCommandTimeout)
+}
+
+// idempotent reports whether applying the write twice has the same effect
+// as applying it once.
+func (p pendingMutation) idempotent() bool {
+	return p.Kind != mutationAddComment
+}
+
+// retryable reports whether the write should be queued after failing with err.
+// A timed-out write may have been applied, so it is only retried when doing
+// it again is harmless.
+func (p pendingMutation) retryable(err error) bool {
+	if !beads.IsRetryable(err) {
+		return false
+	}
+	return p.idempotent() || !beads.IsTimeout(err)
+}
+
+// String describes the write for toasts, e.g. "status of ab-1 → closed".
+func (p pendingMutation) String() string {
+	switch p.Kind {
+	case mutationStatus:
+		return fmt.Sprintf("status of %s → %s", p.IssueID, p.Value)
+	case mutationReopen:
+		return fmt.Sprintf("reopen %s", p.IssueID)
+	case mutationPriority:
+		return fmt.Sprintf("priority of %s → P%d", p.IssueID, p.Priority)
+	case mutationAssignee:
+		return fmt.Sprintf("assignee of %s → %s", p.IssueID, displayAssignee(p.Value))
+	case mutationAddLabel:
+		return fmt.Sprintf("add label %s to %s", p.Value, p.IssueID)
+	case mutationRemoveLabel:
+		return fmt.Sprintf("remove label %s from %s", p.Value, p.IssueID)
+	case mutationAddComment:
+		return fmt.Sprintf("comment on %s", p.IssueID)
+	case mutationDelete:
+		return fmt.Sprintf("delete %s", p.IssueID)
+	}
+	return string(p.Kind)
+}
+
+func displayAssignee(assignee string) string {
+	if assignee == "" {
+		return "unassigned"
+	}
+	return assignee
+}
+
+// mutationRetryDelay is the wait before the given attempt: exponential from
+// mutationRetryBase, capped at mutationRetryMax.
+func mutationRetryDelay(attempts int) time.Duration {
+	delay := mutationRetryBase
+	for i := 1; i < attempts && delay < mutationRetryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, mutationRetryMax)
+}
+
+// mutationQueue holds writes waiting to be retried, oldest first. Writes
+// are retried one at a time in order, so a later change to the same bead
+// is never overtaken by an earlier one.
+type mutationQueue struct {
+	path   string // empty keeps the queue in memory only
+	items  []pendingMutation
+	nextID int
+}
+
+// loadMutationQueue reads the pending writes saved at path. A missing file
+// is an empty queue.
+func loadMutationQueue(path string) (*mutationQueue, error) {
+	q := &mutationQueue{path: path, nextID: 1}
+	if path == "" {
+		return q, nil
+	}
+	//nolint:gosec // G304: The queue lives in the project's .abacus directory
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return q, nil
+	}
+	if err != nil {
+		return q, fmt.Errorf("read pending writes: %w", err)
+	}
+	if err := json.Unmarshal(data, &q.items); err != nil {
+		return q, fmt.Errorf("parse pending writes %s: %w", path, err)
+	}
+	for _, p := range q.items {
+		q.nextID = max(q.nextID, p.ID+1)
+	}
+	return q, nil
+}
+
+// Len returns the number of pending writes.
+func (q *mutationQueue) Len() int {
+	if q == nil {
+		return 0
+	}
+	return len(q.items)
+}
+
+// hasIssue reports whether a write for issueID is pending.
+func (q *mutationQueue) hasIssue(issueID string) bool {
+	if q == nil {
+		return false
+	}
+	for _, p := range q.items {
+		if p.IssueID == issueID {
+			return true
+		}
+	}
+	return false
+}
+
+// push appends writes, due after the first backoff when they failed with
+// cause or straight away when they only wait behind earlier writes.
+func (q *mutationQueue) push(now time.Time, cause error, writes ...pendingMutation) {
+	for _, p := range writes {
+		p.ID = q.nextID
+		q.nextID++
+		p.QueuedAt = now
+		p.NextAttempt = now
+		if cause != nil {
+			p.Attempts = 1
+			p.LastError = cause.Error()
+			p.NextAttempt = now.Add(mutationRetryDelay(1))
+		}
+		q.items = append(q.items, p)
+	}
+}
+
+// head returns the oldest pending write.
+func (q *mutationQueue) head() (pendingMutation, bool) {
+	if q.Len() == 0 {
+		return pendingMutation{}, false
+	}
+	return q.items[0], true
+}
+
+// remove drops the write with the given ID.
+func (q *mutationQueue) remove(id int) {
+	for i, p := range q.items {
+		if p.ID == id {
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			return
+		}
+	}
+}
+
+// failed records another failed attempt and pushes the next one back.
+func (q *mutationQueue) failed(id int, err error, now time.Time) {
+	for i := range q.items {
+		if q.items[i].ID == id {
+			q.items[i].Attempts++
+			q.items[i].LastError = err.Error()
+			q.items[i].NextAttempt = now.Add(mutationRetryDelay(q.items[i].Attempts))
+			return
+		}
+	}
+}
+
+// save writes the queue to disk, removing the file once the queue is empty.
+func (q *mutationQueue) save() error {
+	if q.path == "" {
+		return nil
+	}
+	if len(q.items) == 0 {
+		if err := os.Remove(q.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("remove pending writes: %w", err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(q.items, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode pending writes: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(q.path), 0o750); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(q.path), err)
+	}
+	tmp := q.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write pending writes: %w", err)
+	}
+	if err := os.Rename(tmp, q.path); err != nil {
+		return fmt.Errorf("save pending writes: %w", err)
+	}
+	return nil
+}
+
+// mutationQueuedMsg reports writes that could not be applied now and were
+// handed to the retry queue. cause is nil when they only wait behind
+// earlier queued writes for the same bead.
+type mutationQueuedMsg struct {
+	writes []pendingMutation
+	cause  error
+}
+
+// mutationRetryTickMsg wakes the queue to retry the oldest write when due.
+type mutationRetryTickMsg struct{}
+
+// mutationRetryResultMsg is the outcome of retrying a queued write.
+type mutationRetryResultMsg struct {
+	write pendingMutation
+	err   error
+}
+
+func scheduleMutationRetry(delay time.Duration) tea.Cmd {
+	return tea.Tick(max(delay, 0), func(time.Time) tea.Msg {
+		return mutationRetryTickMsg{}
+	})
+}
+
+// pendingMutationsPath returns where the retry queue is saved, or "" when
+// the project root is unknown.
+func (m *App) pendingMutationsPath() string {
+	root := m.projectRoot()
+	if root == "" {
+		return ""
+	}
+	return filepath.Join(root, PendingMutationsFile)
+}
+
+// executeMutations applies writes in order, each under its own context, and
+// reports the result with done.
+// If the bead already has queued writes, the new ones are queued behind them
+// so they are not overtaken. If a write fails with a retryable error, it and
+// the writes after it are queued for retry instead of being reported as
+// failed; done is then not called and a toast says the writes were queued.
+func (m *App) executeMutations(done func(error) tea.Msg, writes ...pendingMutation) tea.Cmd {
+	if len(writes) > 0 && m.mutations.hasIssue(writes[0].IssueID) {
+		return func() tea.Msg { return mutationQueuedMsg{writes: writes} }
+	}
+	return func() tea.Msg {
+		for i, p := range writes {
+			ctx, cancel := p.context()
+			err := p.apply(ctx, m.client)
+			cancel()
+			if err != nil {
+				if p.retryable(err) {
+					return mutationQueuedMsg{writes: writes[i:], cause: err}
+				}
+				return done(err)
+			}
+		}
+		return done(nil)
+	}
+}
+
+// retryNextMutation applies the oldest queued write if it is due, otherwise
+// waits until it is.
+func (m *App) retryNextMutation() tea.Cmd {
+	p, ok := m.mutations.head()
+	if !ok || m.mutationInFlight {
+		return nil
+	}
+	if wait := time.Until(p.NextAttempt); wait > 0 {
+		return scheduleMutationRetry(wait)
+	}
+	m.mutationInFlight = true
+	return func() tea.Msg {
+		ctx, cancel := p.context()
+		defer cancel()
+		return mutationRetryResultMsg{write: p, err: p.apply(ctx, m.client)}
+	}
+}
+
+// handleMutationMsg processes retry queue messages.
+func (m *App) handleMutationMsg(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case mutationQueuedMsg:
+		if m.mutations == nil {
+			m.mutations = &mutationQueue{nextID: 1}
+		}
+		m.mutations.push(time.Now(), msg.cause, msg.writes...)
+		m.saveMutations()
+		m.queuedToastVisible = true
+		m.queuedToastStart = time.Now()
+		m.queuedToastWrites = msg.writes
+		m.queuedToastBehind = msg.cause == nil
+		return m, tea.Batch(m.retryNextMutation(), scheduleQueuedToastTick()), true
+
+	case mutationRetryTickMsg:
+		return m, m.retryNextMutation(), true
+
+	case mutationRetryResultMsg:
+		m.mutationInFlight = false
+		p := msg.write
+		switch {
+		case msg.err == nil:
+			m.mutations.remove(p.ID)
+			m.saveMutations()
+			return m, tea.Batch(m.retryNextMutation(), m.forceRefresh()), true
+		case p.retryable(msg.err) && p.Attempts+1 < mutationMaxAttempts:
+			m.mutations.failed(p.ID, msg.err, time.Now())
+			m.saveMutations()
+			return m, m.retryNextMutation(), true
+		default:
+			// Retrying cannot help or has been tried long enough; drop it
+			// and say so.
+			m.mutations.remove(p.ID)
+			m.saveMutations()
+			if p.retryable(msg.err) {
+				m.lastError = fmt.Sprintf("Queued %s failed after %d attempts: %v", p, p.Attempts+1, msg.err)
+			} else {
+				m.lastError = fmt.Sprintf("Queued %s failed: %v", p, msg.err)
+			}
+			m.lastErrorSource = errorSourceOperation
+			m.showErrorToast = true
+			m.errorToastStart = time.Now()
+			return m, tea.Batch(m.retryNextMutation(), scheduleErrorToastTick(), m.forceRefresh()), true
+		}
+	}
+	return m, nil, false
+}
+
+// saveMutations persists the retry queue; failures are logged, not shown,
+// since the queue keeps working in memory.
+func (m *App) saveMutations() {
+	if err := m.mutations.save(); err != nil {
+		debug.Logf("mutation queue: %v", err)
+	}
+}
+
+// renderPendingIndicator shows how many writes are waiting to be retried.
+func (m *App) renderPendingIndicator() string {
+	n := m.mutations.Len()
+	if n == 0 {
+		return ""
+	}
+	return styleUpdateIndicator().Render(fmt.Sprintf("⟳ %d pending", n))
+}
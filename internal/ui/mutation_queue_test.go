@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"abacus/internal/beads"
+	appErrors "abacus/internal/errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func errBusy() error {
+	return appErrors.New(appErrors.CodeBusy, "database is locked", errors.New("exit status 1"))
+}
+
+func newMutationTestApp(t *testing.T, client beads.Client) *App {
+	t.Helper()
+	root := t.TempDir()
+	app := &App{client: client, dbPath: filepath.Join(root, ".beads", "beads.db")}
+	queue, err := loadMutationQueue(app.pendingMutationsPath())
+	if err != nil {
+		t.Fatalf("loadMutationQueue: %v", err)
+	}
+	app.mutations = queue
+	return app
+}
+
+// findRetryResult runs cmd and returns the mutationRetryResultMsg it or one
+// of its batched commands produces.
+func findRetryResult(cmd tea.Cmd) tea.Msg {
+	switch msg := cmd().(type) {
+	case mutationRetryResultMsg:
+		return msg
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if c == nil {
+				continue
+			}
+			if result := findRetryResult(c); result != nil {
+				return result
+			}
+		}
+	}
+	return nil
+}
+
+func TestMutationRetryDelay(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{
+		1:  2 * time.Second,
+		2:  4 * time.Second,
+		4:  16 * time.Second,
+		7:  2 * time.Minute,
+		50: 2 * time.Minute,
+	} {
+		if got := mutationRetryDelay(attempts); got != want {
+			t.Errorf("mutationRetryDelay(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestMutationQueuePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), PendingMutationsFile)
+	q, err := loadMutationQueue(path)
+	if err != nil || q.Len() != 0 {
+		t.Fatalf("expected an empty queue without a file, got %d items, err %v", q.Len(), err)
+	}
+
+	now := time.Now()
+	q.push(now, errBusy(),
+		pendingMutation{Kind: mutationStatus, IssueID: "ab-1", Value: "closed"},
+		pendingMutation{Kind: mutationAddComment, IssueID: "ab-2", Value: "done"})
+	if err := q.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded, err := loadMutationQueue(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	head, ok := loaded.head()
+	if loaded.Len() != 2 || !ok || head.Kind != mutationStatus || head.Value != "closed" || head.Attempts != 1 {
+		t.Fatalf("unexpected queue after reload: %+v", loaded.items)
+	}
+	if !head.NextAttempt.After(now) || head.LastError == "" {
+		t.Errorf("expected backoff and the cause to be recorded, got %+v", head)
+	}
+
+	loaded.failed(head.ID, errBusy(), now)
+	if got := loaded.items[0].NextAttempt.Sub(now); got != mutationRetryDelay(2) {
+		t.Errorf("expected second backoff %v, got %v", mutationRetryDelay(2), got)
+	}
+
+	// New IDs continue after the saved ones.
+	loaded.push(now, nil, pendingMutation{Kind: mutationReopen, IssueID: "ab-3"})
+	if ids := []int{loaded.items[0].ID, loaded.items[1].ID, loaded.items[2].ID}; ids[2] <= ids[1] || ids[1] <= ids[0] {
+		t.Errorf("expected increasing IDs, got %v", ids)
+	}
+
+	for loaded.Len() > 0 {
+		head, _ := loaded.head()
+		loaded.remove(head.ID)
+	}
+	if err := loaded.save(); err != nil {
+		t.Fatalf("save empty: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the file to be removed once the queue is empty, got %v", err)
+	}
+}
+
+func TestBusyStatusChangeIsQueuedAndRetried(t *testing.T) {
+	mockClient := beads.NewMockClient()
+	busy := true
+	mockClient.UpdateStatusFn = func(context.Context, string, string) error {
+		if busy {
+			return errBusy()
+		}
+		return nil
+	}
+	app := newMutationTestApp(t, mockClient)
+
+	msg := app.executeStatusChangeCmd("ab-1", "closed")()
+	queued, ok := msg.(mutationQueuedMsg)
+	if !ok {
+		t.Fatalf("expected mutationQueuedMsg, got %T", msg)
+	}
+	app.Update(queued)
+	if app.mutations.Len() != 1 {
+		t.Fatalf("expected one pending write, got %d", app.mutations.Len())
+	}
+	if !app.queuedToastVisible || app.queuedToastBehind || app.queuedToastLayer(80, 24, 1, 20) == nil {
+		t.Error("expected a toast saying the write was queued")
+	}
+	if _, err := os.Stat(app.pendingMutationsPath()); err != nil {
+		t.Fatalf("expected the queue to be saved: %v", err)
+	}
+	if app.renderPendingIndicator() == "" {
+		t.Error("expected a pending indicator in the footer")
+	}
+
+	// A later write for the same bead waits behind the queued one.
+	app.Update(app.executePriorityChangeCmd("ab-1", 0)())
+	if app.mutations.Len() != 2 || mockClient.UpdatePriorityCallCount != 0 {
+		t.Fatalf("expected priority change to be queued behind, got %d pending and %d calls", app.mutations.Len(), mockClient.UpdatePriorityCallCount)
+	}
+
+	// Still busy: the attempt is counted and pushed back.
+	app.mutations.items[0].NextAttempt = time.Time{}
+	app.Update(app.retryNextMutation()())
+	if head, _ := app.mutations.head(); head.Attempts != 2 || app.mutations.Len() != 2 {
+		t.Fatalf("expected a second failed attempt, got %+v", app.mutations.items)
+	}
+
+	// The database frees up: both writes go through in order, each success
+	// starting the next retry.
+	busy = false
+	app.mutations.items[0].NextAttempt = time.Time{}
+	cmd := app.retryNextMutation()
+	for cmd != nil {
+		result := findRetryResult(cmd)
+		if result == nil {
+			break
+		}
+		_, cmd = app.Update(result)
+	}
+	if app.mutations.Len() != 0 {
+		t.Fatalf("expected the queue to drain, got %+v", app.mutations.items)
+	}
+	if got := mockClient.UpdateStatusCallArgs; len(got) != 3 || got[2][1] != "closed" {
+		t.Errorf("unexpected UpdateStatus calls %+v", got)
+	}
+	if got := mockClient.UpdatePriorityCallArgs; len(got) != 1 || got[0].Priority != 0 {
+		t.Errorf("unexpected UpdatePriority calls %+v", got)
+	}
+	if _, err := os.Stat(app.pendingMutationsPath()); !os.IsNotExist(err) {
+		t.Errorf("expected the queue file to be removed, got %v", err)
+	}
+}
+
+func TestQueuedWriteDroppedOnPermanentFailure(t *testing.T) {
+	mockClient := beads.NewMockClient()
+	mockClient.UpdateAssigneeFn = func(context.Context, string, string) error {
+		return appErrors.New(appErrors.CodeCLIFailed, "unknown user", nil)
+	}
+	app := newMutationTestApp(t, mockClient)
+	app.mutations.push(time.Time{}, errBusy(), pendingMutation{Kind: mutationAssignee, IssueID: "ab-1", Value: "bob"})
+
+	app.Update(app.retryNextMutation()())
+	if app.mutations.Len() != 0 || !app.showErrorToast {
+		t.Fatalf("expected the write to be dropped with an error toast, pending=%d toast=%v", app.mutations.Len(), app.showErrorToast)
+	}
+}
+
+func TestTimedOutCommentIsNotQueued(t *testing.T) {
+	mockClient := beads.NewMockClient()
+	mockClient.AddCommentFn = func(context.Context, string, string) error {
+		return appErrors.New(appErrors.CodeTimeout, "timed out", context.DeadlineExceeded)
+	}
+	app := newMutationTestApp(t, mockClient)
+
+	msg := app.executeAddComment(CommentAddedMsg{IssueID: "ab-1", Comment: "hello"})()
+	if done, ok := msg.(commentCompleteMsg); !ok || done.err == nil {
+		t.Fatalf("expected the timeout to be reported, got %#v", msg)
+	}
+}
+
+func TestQueuedWriteDroppedAfterMaxAttempts(t *testing.T) {
+	mockClient := beads.NewMockClient()
+	mockClient.UpdateStatusFn = func(context.Context, string, string) error { return errBusy() }
+	app := newMutationTestApp(t, mockClient)
+	app.mutations.push(time.Time{}, errBusy(), pendingMutation{Kind: mutationStatus, IssueID: "ab-1", Value: "closed"})
+
+	for attempt := 2; attempt <= mutationMaxAttempts; attempt++ {
+		if app.mutations.Len() != 1 {
+			t.Fatalf("attempt %d: expected the write to stay queued", attempt)
+		}
+		app.mutations.items[0].NextAttempt = time.Time{}
+		app.Update(app.retryNextMutation()())
+	}
+	if app.mutations.Len() != 0 || !app.showErrorToast || !strings.Contains(app.lastError, "after 10 attempts") {
+		t.Fatalf("expected the write to be dropped after %d attempts, pending=%d error=%q", mutationMaxAttempts, app.mutations.Len(), app.lastError)
+	}
+}
+
+func TestMissingBinaryIsQueued(t *testing.T) {
+	mockClient := beads.NewMockClient()
+	mockClient.UpdatePriorityFn = func(context.Context, string, int) error {
+		return appErrors.New(appErrors.CodeCLINotFound, "br not found", nil)
+	}
+	app := newMutationTestApp(t, mockClient)
+
+	if _, queued := app.executePriorityChangeCmd("ab-1", 0)().(mutationQueuedMsg); !queued {
+		t.Fatal("expected a write to be queued while the binary is unavailable")
+	}
+}
+
+func TestCommentsAndDeletesHaveNoDeadline(t *testing.T) {
+	mockClient := beads.NewMockClient()
+	var deadlines []bool
+	mockClient.AddCommentFn = func(ctx context.Context, _, _ string) error {
+		_, ok := ctx.Deadline()
+		deadlines = append(deadlines, ok)
+		return nil
+	}
+	mockClient.DeleteFn = func(ctx context.Context, _ string, _ bool) error {
+		_, ok := ctx.Deadline()
+		deadlines = append(deadlines, ok)
+		return nil
+	}
+	mockClient.UpdateStatusFn = func(ctx context.Context, _, _ string) error {
+		_, ok := ctx.Deadline()
+		deadlines = append(deadlines, ok)
+		return nil
+	}
+	app := newMutationTestApp(t, mockClient)
+
+	app.executeAddComment(CommentAddedMsg{IssueID: "ab-1", Comment: "hello"})()
+	app.executeDelete("ab-2", true, []string{"ab-3"})()
+	app.executeStatusChangeCmd("ab-4", "closed")()
+	if want := []bool{false, false, true}; !slices.Equal(deadlines, want) {
+		t.Errorf("deadlines for comment, delete, status = %v, want %v", deadlines, want)
+	}
+}
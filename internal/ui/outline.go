@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// outlineTabWidth is how many spaces a tab counts for when measuring indent.
+const outlineTabWidth = 4
+
+// outlineItem is one bead parsed from a pasted markdown outline.
+type outlineItem struct {
+	Title     string
+	IssueType string // explicit [type] marker, else inferred from the title
+	Priority  int
+	Labels    []string
+	Assignee  string
+	Line      int // 1-based source line, for error messages
+	Children  []*outlineItem
+}
+
+// outlineStep is an outline item flattened for creation. Parents always come
+// before their children; Parent is the index of the parent step, or -1 for
+// items created directly under the import target.
+type outlineStep struct {
+	Item   *outlineItem
+	Parent int
+	Depth  int
+}
+
+// parseOutline turns an indented markdown list into a tree of items. Each
+// non-blank line must be a "-", "*", "+" or "1." list item; deeper indent
+// nests under the previous item. Inline markers set fields and are removed
+// from the title: [bug] for the type, P0-P4 for priority, #label and @assignee.
+func parseOutline(text string) ([]*outlineItem, error) {
+	type level struct {
+		indent int
+		item   *outlineItem
+	}
+	var roots []*outlineItem
+	var stack []level
+
+	for i, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		lineNo := i + 1
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		indent, rest := outlineIndent(raw)
+		content, ok := stripListMarker(rest)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected a list item (\"- title\")", lineNo)
+		}
+		item, err := parseOutlineItem(content)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		item.Line = lineNo
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, item)
+		} else {
+			parent := stack[len(stack)-1].item
+			parent.Children = append(parent.Children, item)
+		}
+		stack = append(stack, level{indent: indent, item: item})
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("outline is empty")
+	}
+	return roots, nil
+}
+
+// outlineIndent returns the indent width of a line and the text after it.
+func outlineIndent(line string) (int, string) {
+	width := 0
+	for i, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += outlineTabWidth
+		default:
+			return width, line[i:]
+		}
+	}
+	return width, ""
+}
+
+// stripListMarker removes a leading bullet or "1." / "1)" marker, plus an
+// optional "[ ]" / "[x]" task checkbox.
+func stripListMarker(s string) (string, bool) {
+	var rest string
+	switch {
+	case strings.HasPrefix(s, "- "), strings.HasPrefix(s, "* "), strings.HasPrefix(s, "+ "):
+		rest = s[2:]
+	default:
+		digits := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+		if digits <= 0 || digits+1 >= len(s) || (s[digits] != '.' && s[digits] != ')') || s[digits+1] != ' ' {
+			return "", false
+		}
+		rest = s[digits+2:]
+	}
+	rest = strings.TrimSpace(rest)
+	for _, box := range []string{"[ ] ", "[x] ", "[X] "} {
+		rest = strings.TrimPrefix(rest, box)
+	}
+	return rest, true
+}
+
+// parseOutlineItem extracts inline markers from an item's text.
+func parseOutlineItem(content string) (*outlineItem, error) {
+	item := &outlineItem{Priority: -1}
+	var words []string
+	for _, word := range strings.Fields(content) {
+		switch {
+		case len(word) > 2 && word[0] == '[' && word[len(word)-1] == ']' && isIssueType(strings.ToLower(word[1:len(word)-1])):
+			item.IssueType = strings.ToLower(word[1 : len(word)-1])
+		case len(word) == 2 && (word[0] == 'P' || word[0] == 'p') && word[1] >= '0' && word[1] <= '4':
+			item.Priority = int(word[1] - '0')
+		case len(word) > 1 && word[0] == '#':
+			if !containsString(item.Labels, word[1:]) {
+				item.Labels = append(item.Labels, word[1:])
+			}
+		case len(word) > 1 && word[0] == '@':
+			item.Assignee = word[1:]
+		default:
+			words = append(words, word)
+		}
+	}
+	item.Title = strings.Join(words, " ")
+	if item.Title == "" {
+		return nil, fmt.Errorf("item has no title")
+	}
+	if item.IssueType == "" {
+		item.IssueType = typeOptions[0]
+		if idx := inferTypeFromTitle(item.Title); idx >= 0 {
+			item.IssueType = typeOptions[idx]
+		}
+	}
+	if item.Priority < 0 {
+		item.Priority = 2 // Medium, the create overlay default
+	}
+	return item, nil
+}
+
+func isIssueType(s string) bool {
+	return containsString(typeOptions, s)
+}
+
+// flattenOutline lists items depth-first so each parent is created before its children.
+func flattenOutline(roots []*outlineItem) []outlineStep {
+	var steps []outlineStep
+	var walk func(items []*outlineItem, parent, depth int)
+	walk = func(items []*outlineItem, parent, depth int) {
+		for _, item := range items {
+			steps = append(steps, outlineStep{Item: item, Parent: parent, Depth: depth})
+			walk(item.Children, len(steps)-1, depth+1)
+		}
+	}
+	walk(roots, -1, 0)
+	return steps
+}
+
+// outlineJob tracks an outline import while beads are created one at a time.
+// If a create fails, the beads already created are deleted again.
+type outlineJob struct {
+	parentID    string // import target; empty for root beads
+	steps       []outlineStep
+	created     []string // bead IDs by step index, filled as steps complete
+	err         error    // create failure that triggered the rollback
+	failedTitle string
+	rollingBack bool
+	rollbackErr error
+	done        bool
+}
+
+// parentFor returns the bead ID a step should be created under.
+func (j *outlineJob) parentFor(index int) string {
+	if p := j.steps[index].Parent; p >= 0 {
+		return j.created[p]
+	}
+	return j.parentID
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"abacus/internal/beads"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const testOutline = `
+- Checkout redesign #ux P1
+  - [bug] Cart total wrong on refresh @alice
+  - Payment step
+    * Add Apple Pay #payments #payments
+1. Write release notes
+`
+
+func TestParseOutline(t *testing.T) {
+	roots, err := parseOutline(testOutline)
+	if err != nil {
+		t.Fatalf("parseOutline returned error: %v", err)
+	}
+	if len(roots) != 2 || len(roots[0].Children) != 2 || len(roots[0].Children[1].Children) != 1 {
+		t.Fatalf("unexpected tree shape: %+v", roots)
+	}
+
+	epic := roots[0]
+	if epic.Title != "Checkout redesign" || epic.Priority != 1 || strings.Join(epic.Labels, ",") != "ux" {
+		t.Fatalf("unexpected markers on first item: %+v", epic)
+	}
+	bug := epic.Children[0]
+	if bug.Title != "Cart total wrong on refresh" || bug.IssueType != "bug" || bug.Assignee != "alice" || bug.Priority != 2 {
+		t.Fatalf("unexpected child item: %+v", bug)
+	}
+	if pay := epic.Children[1].Children[0]; pay.IssueType != "feature" || strings.Join(pay.Labels, ",") != "payments" {
+		t.Fatalf("expected inferred feature type and deduped labels, got %+v", pay)
+	}
+	if notes := roots[1]; notes.Title != "Write release notes" || notes.IssueType != "task" {
+		t.Fatalf("unexpected numbered item: %+v", notes)
+	}
+
+	steps := flattenOutline(roots)
+	var got []string
+	for _, s := range steps {
+		got = append(got, fmt.Sprintf("%d:%d", s.Parent, s.Depth))
+	}
+	if strings.Join(got, " ") != "-1:0 0:1 0:1 2:2 -1:0" {
+		t.Fatalf("unexpected flattened steps: %v", got)
+	}
+}
+
+func TestParseOutlineErrors(t *testing.T) {
+	for name, text := range map[string]string{
+		"empty":       "\n  \n",
+		"notAList":    "- ok\nplain paragraph",
+		"markersOnly": "- #label P1",
+	} {
+		if _, err := parseOutline(text); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := parseOutline("- ok\nplain paragraph"); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected error to name the line, got %v", err)
+	}
+}
+
+func TestOutlineImportOverlayPreview(t *testing.T) {
+	m := NewOutlineImportOverlay("ab-1", "Parent")
+	m.textarea.SetValue("- Parent task\n  - Child task")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if !m.previewing || len(m.steps) != 2 {
+		t.Fatalf("expected preview of 2 beads, previewing=%v steps=%d", m.previewing, len(m.steps))
+	}
+	view := stripANSI(m.View())
+	if !strings.Contains(view, "  • Child task") || !strings.Contains(view, "Create 2 beads") {
+		t.Fatalf("expected nested preview:\n%s", view)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msg, ok := cmd().(OutlineImportConfirmedMsg)
+	if !ok || msg.ParentID != "ab-1" || len(msg.Steps) != 2 {
+		t.Fatalf("unexpected confirmation: %+v", msg)
+	}
+
+	m = NewOutlineImportOverlay("", "")
+	m.textarea.SetValue("not a list")
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.previewing || m.errorMsg == "" {
+		t.Fatal("expected parse error to keep the editor open")
+	}
+}
+
+// runOutlineJob confirms an outline import and feeds each command's message
+// back into the app until the job finishes.
+func runOutlineJob(t *testing.T, m *App, parentID, outline string) {
+	t.Helper()
+	roots, err := parseOutline(outline)
+	if err != nil {
+		t.Fatalf("parseOutline: %v", err)
+	}
+	_, cmd := m.Update(OutlineImportConfirmedMsg{ParentID: parentID, Steps: flattenOutline(roots)})
+	for m.outlineJob != nil && !m.outlineJob.done {
+		msg := cmd()
+		switch msg.(type) {
+		case outlineStepCompleteMsg, outlineRollbackCompleteMsg:
+		default:
+			t.Fatalf("unexpected message %T", msg)
+		}
+		_, cmd = m.Update(msg)
+	}
+}
+
+func TestOutlineJobCreatesWithParentLinks(t *testing.T) {
+	client := beads.NewMockClient()
+	var parents []string
+	client.CreateFullFn = func(_ context.Context, title, _ string, _ int, _ []string, _, _, parentID string) (beads.FullIssue, error) {
+		parents = append(parents, title+"<"+parentID)
+		return beads.FullIssue{ID: fmt.Sprintf("ab-n%d", len(parents))}, nil
+	}
+	m := &App{client: client}
+
+	runOutlineJob(t, m, "ab-1", "- A\n  - B\n    - C\n  - D\n- E")
+
+	want := "A<ab-1 B<ab-n1 C<ab-n2 D<ab-n1 E<ab-1"
+	if strings.Join(parents, " ") != want {
+		t.Fatalf("unexpected parent links:\n got %v\nwant %v", parents, want)
+	}
+	if m.showErrorToast || !m.outlineJobToastVisible {
+		t.Fatalf("expected success toast, error=%q", m.lastError)
+	}
+}
+
+func TestOutlineJobRollsBackOnFailure(t *testing.T) {
+	client := beads.NewMockClient()
+	created := 0
+	client.CreateFullFn = func(_ context.Context, title, _ string, _ int, _ []string, _, _, _ string) (beads.FullIssue, error) {
+		if title == "C" {
+			return beads.FullIssue{}, errors.New("db locked")
+		}
+		created++
+		return beads.FullIssue{ID: fmt.Sprintf("ab-n%d", created)}, nil
+	}
+	var deleted []string
+	client.DeleteFn = func(_ context.Context, id string, _ bool) error {
+		deleted = append(deleted, id)
+		return nil
+	}
+	m := &App{client: client}
+
+	runOutlineJob(t, m, "", "- A\n  - B\n- C\n- D")
+
+	if strings.Join(deleted, ",") != "ab-n2,ab-n1" {
+		t.Fatalf("expected created beads deleted children first, got %v", deleted)
+	}
+	if client.CreateFullCallCount != 3 {
+		t.Fatalf("expected import to stop at the failure, got %d creates", client.CreateFullCallCount)
+	}
+	if !m.showErrorToast || !strings.Contains(m.lastError, `failed at "C"`) || !strings.Contains(m.lastError, "rolled back 2") {
+		t.Fatalf("unexpected error: %q", m.lastError)
+	}
+}
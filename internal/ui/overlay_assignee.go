@@ -0,0 +1,139 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AssigneeOverlay is a single-select popup for reassigning a bead.
+// Offers "Unassigned", "Me (<user>)" and every known assignee; typing a
+// name that isn't listed assigns it as a new assignee.
+type AssigneeOverlay struct {
+	issueID         string
+	beadTitle       string
+	currentAssignee string
+	combo           ComboBox
+}
+
+// AssigneeChangedMsg is sent when a new assignee is confirmed.
+// An empty Assignee clears the assignment.
+type AssigneeChangedMsg struct {
+	IssueID  string
+	Assignee string
+}
+
+// AssigneeCancelledMsg is sent when the overlay is dismissed without changes.
+type AssigneeCancelledMsg struct{}
+
+// NewAssigneeOverlay creates an assignee picker for the given issue.
+func NewAssigneeOverlay(issueID, beadTitle, currentAssignee string, knownAssignees []string) *AssigneeOverlay {
+	contentWidth := OverlayContentWidth(OverlayWidthStandard)
+	combo := NewComboBox(assigneeOptions(knownAssignees)).
+		WithWidth(contentWidth).
+		WithMaxVisible(8).
+		WithPlaceholder("type to filter...").
+		WithAllowNew(true, "New assignee: %s")
+	combo.SetValue(assigneeOptionFor(currentAssignee))
+
+	return &AssigneeOverlay{
+		issueID:         issueID,
+		beadTitle:       beadTitle,
+		currentAssignee: currentAssignee,
+		combo:           combo,
+	}
+}
+
+// Init implements tea.Model.
+func (m *AssigneeOverlay) Init() tea.Cmd {
+	return m.combo.Focus()
+}
+
+// Update implements tea.Model.
+func (m *AssigneeOverlay) Update(msg tea.Msg) (*AssigneeOverlay, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ComboBoxEnterSelectedMsg:
+		// Picking from the dropdown (or typing a new name) confirms immediately
+		return m, m.confirmValue(msg.Value)
+
+	case ComboBoxTabSelectedMsg:
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			if m.combo.IsDropdownOpen() || m.combo.InputValue() != m.combo.Value() {
+				m.combo, _ = m.combo.Update(msg)
+				return m, nil
+			}
+			return m, func() tea.Msg { return AssigneeCancelledMsg{} }
+
+		case tea.KeyEnter:
+			if !m.combo.IsDropdownOpen() && m.combo.InputValue() == m.combo.Value() {
+				return m, m.confirmValue(m.combo.Value())
+			}
+		}
+		var cmd tea.Cmd
+		m.combo, cmd = m.combo.Update(msg)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.combo, cmd = m.combo.Update(msg)
+	return m, cmd
+}
+
+// confirmValue emits AssigneeChangedMsg, or cancels when nothing changed.
+func (m *AssigneeOverlay) confirmValue(value string) tea.Cmd {
+	assignee := normalizeAssigneeOption(value)
+	if assignee == m.currentAssignee {
+		return func() tea.Msg { return AssigneeCancelledMsg{} }
+	}
+	issueID := m.issueID
+	return func() tea.Msg {
+		return AssigneeChangedMsg{IssueID: issueID, Assignee: assignee}
+	}
+}
+
+// View implements tea.Model using the unified overlay framework.
+func (m *AssigneeOverlay) View() string {
+	b := NewOverlayBuilder(OverlaySizeStandard, 0)
+
+	b.Line(styleOverlaySectionLabel().Render("Assignee"))
+
+	title := m.beadTitle
+	maxTitleLen := 30
+	if len(title) > maxTitleLen {
+		title = title[:maxTitleLen-3] + "..."
+	}
+	contextLine := styleID().Render(m.issueID) + styleStatsDim().Render(": ") + styleStatsDim().Render(title)
+	b.Line(contextLine)
+	b.Line(b.Divider())
+	b.BlankLine()
+
+	b.Line(m.combo.View())
+	b.BlankLine()
+
+	b.Footer(m.footerHints())
+
+	return b.Build()
+}
+
+// footerHints returns the dynamic footer based on current state.
+func (m *AssigneeOverlay) footerHints() []footerHint {
+	if m.combo.IsDropdownOpen() {
+		return []footerHint{
+			{"⏎", "Select"},
+			{"↑↓", "Navigate"},
+			{"esc", "Clear"},
+		}
+	}
+	return []footerHint{
+		{"↓", "Browse"},
+		{"⏎", "Save"},
+		{"esc", "Cancel"},
+	}
+}
+
+// Layer returns a centered layer for the assignee overlay.
+func (m *AssigneeOverlay) Layer(width, height, topMargin, bottomMargin int) Layer {
+	return BaseOverlayLayer(m.View, width, height, topMargin, bottomMargin)
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNewAssigneeOverlay(t *testing.T) {
+	t.Setenv("USER", "tester")
+
+	t.Run("OffersUnassignedMeAndKnown", func(t *testing.T) {
+		overlay := NewAssigneeOverlay("ab-1", "Title", "", []string{"alice", "bob"})
+		expected := []string{"Unassigned", "Me (tester)", "alice", "bob"}
+		if len(overlay.combo.Options) != len(expected) {
+			t.Fatalf("expected options %v, got %v", expected, overlay.combo.Options)
+		}
+		for i, opt := range expected {
+			if overlay.combo.Options[i] != opt {
+				t.Errorf("option %d: expected %q, got %q", i, opt, overlay.combo.Options[i])
+			}
+		}
+	})
+
+	t.Run("PreselectsCurrentAssignee", func(t *testing.T) {
+		overlay := NewAssigneeOverlay("ab-1", "Title", "alice", []string{"alice"})
+		if overlay.combo.Value() != "alice" {
+			t.Errorf("expected value alice, got %q", overlay.combo.Value())
+		}
+		unassigned := NewAssigneeOverlay("ab-1", "Title", "", nil)
+		if unassigned.combo.Value() != "Unassigned" {
+			t.Errorf("expected value Unassigned, got %q", unassigned.combo.Value())
+		}
+	})
+}
+
+func TestAssigneeOverlayConfirm(t *testing.T) {
+	t.Setenv("USER", "tester")
+
+	t.Run("SelectionEmitsChange", func(t *testing.T) {
+		overlay := NewAssigneeOverlay("ab-1", "Title", "", []string{"alice"})
+		_, cmd := overlay.Update(ComboBoxEnterSelectedMsg{Value: "Me (tester)"})
+		if cmd == nil {
+			t.Fatal("expected command")
+		}
+		msg, ok := cmd().(AssigneeChangedMsg)
+		if !ok {
+			t.Fatalf("expected AssigneeChangedMsg, got %T", cmd())
+		}
+		if msg.IssueID != "ab-1" || msg.Assignee != "tester" {
+			t.Errorf("unexpected message: %+v", msg)
+		}
+	})
+
+	t.Run("UnassignedClears", func(t *testing.T) {
+		overlay := NewAssigneeOverlay("ab-1", "Title", "alice", []string{"alice"})
+		_, cmd := overlay.Update(ComboBoxEnterSelectedMsg{Value: "Unassigned"})
+		msg, ok := cmd().(AssigneeChangedMsg)
+		if !ok || msg.Assignee != "" {
+			t.Fatalf("expected clearing AssigneeChangedMsg, got %#v", cmd())
+		}
+	})
+
+	t.Run("UnchangedCancels", func(t *testing.T) {
+		overlay := NewAssigneeOverlay("ab-1", "Title", "alice", []string{"alice"})
+		_, cmd := overlay.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if _, ok := cmd().(AssigneeCancelledMsg); !ok {
+			t.Fatalf("expected AssigneeCancelledMsg, got %T", cmd())
+		}
+	})
+
+	t.Run("EscCancels", func(t *testing.T) {
+		overlay := NewAssigneeOverlay("ab-1", "Title", "", nil)
+		_, cmd := overlay.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if cmd == nil {
+			t.Fatal("expected command")
+		}
+		if _, ok := cmd().(AssigneeCancelledMsg); !ok {
+			t.Fatalf("expected AssigneeCancelledMsg, got %T", cmd())
+		}
+	})
+}
+
+func TestAssigneeOverlayView(t *testing.T) {
+	overlay := NewAssigneeOverlay("ab-view", "Assign me", "", nil)
+	view := overlay.View()
+	if !strings.Contains(view, "Assignee") || !strings.Contains(view, "ab-view") {
+		t.Errorf("expected view to contain header and issue ID, got:\n%s", view)
+	}
+}
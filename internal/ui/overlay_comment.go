@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"abacus/internal/beads"
+
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -21,6 +23,13 @@ type CommentAddedMsg struct {
 	Comment string
 }
 
+// CommentEditedMsg is sent when the user saves changes to an existing comment.
+type CommentEditedMsg struct {
+	IssueID   string
+	CommentID int
+	Comment   string
+}
+
 // CommentCancelledMsg is sent when the user cancels the comment modal.
 type CommentCancelledMsg struct{}
 
@@ -29,6 +38,9 @@ type CommentCancelledMsg struct{}
 type CommentOverlay struct {
 	issueID   string
 	beadTitle string
+	header    string
+	// editing is the comment being edited; nil when adding a new comment.
+	editing   *beads.Comment
 	textarea  textarea.Model
 	errorMsg  string
 	termWidth int
@@ -50,10 +62,48 @@ func NewCommentOverlay(issueID, beadTitle string) *CommentOverlay {
 	return &CommentOverlay{
 		issueID:   issueID,
 		beadTitle: beadTitle,
+		header:    "ADD COMMENT",
 		textarea:  taModel,
 	}
 }
 
+// NewEditCommentOverlay opens the comment modal prefilled with an existing comment.
+func NewEditCommentOverlay(issueID, beadTitle string, comment beads.Comment) *CommentOverlay {
+	m := NewCommentOverlay(issueID, beadTitle)
+	m.header = "EDIT COMMENT"
+	m.editing = &comment
+	m.textarea.SetValue(comment.Text)
+	return m
+}
+
+// NewReplyCommentOverlay opens the comment modal with the given comment quoted
+// as a markdown blockquote, ready for the reply to be typed below it.
+func NewReplyCommentOverlay(issueID, beadTitle string, comment beads.Comment) *CommentOverlay {
+	m := NewCommentOverlay(issueID, beadTitle)
+	m.header = "REPLY TO COMMENT"
+	m.textarea.SetValue(quoteComment(comment))
+	return m
+}
+
+// quoteComment formats a comment as an attributed blockquote. The quoted text
+// is capped at half the character limit so there is always room to reply.
+func quoteComment(c beads.Comment) string {
+	text := []rune(strings.TrimSpace(c.Text))
+	if limit := commentCharLimit / 2; len(text) > limit {
+		text = append([]rune(strings.TrimSpace(string(text[:limit]))), '…')
+	}
+	var sb strings.Builder
+	if c.Author != "" {
+		fmt.Fprintf(&sb, "> **%s** wrote:\n", c.Author)
+	}
+	for _, line := range strings.Split(string(text), "\n") {
+		sb.WriteString(strings.TrimRight("> "+line, " "))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
 // Init returns the initial command for the comment overlay.
 func (m *CommentOverlay) Init() tea.Cmd {
 	return textarea.Blink
@@ -93,6 +143,15 @@ func (m *CommentOverlay) submit() (*CommentOverlay, tea.Cmd) {
 		return m, nil
 	}
 	m.errorMsg = ""
+	if m.editing != nil {
+		if text == strings.TrimSpace(m.editing.Text) {
+			return m, func() tea.Msg { return CommentCancelledMsg{} }
+		}
+		commentID := m.editing.ID
+		return m, func() tea.Msg {
+			return CommentEditedMsg{IssueID: m.issueID, CommentID: commentID, Comment: text}
+		}
+	}
 	return m, func() tea.Msg {
 		return CommentAddedMsg{
 			IssueID: m.issueID,
@@ -113,7 +172,7 @@ func (m *CommentOverlay) View() string {
 	m.textarea.SetWidth(taContentWidth)
 
 	// Header
-	b.Header("ADD COMMENT")
+	b.Header(m.header)
 
 	// Bead context line
 	title := m.beadTitle
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"strings"
+
+	"abacus/internal/beads"
+	"abacus/internal/ui/theme"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// commentDeletePreviewLines caps how much of the comment the confirmation shows.
+const commentDeletePreviewLines = 3
+
+// CommentDeleteOverlay is a confirmation modal for deleting a single comment.
+type CommentDeleteOverlay struct {
+	issueID string
+	comment beads.Comment
+}
+
+// CommentDeleteConfirmedMsg is sent when comment deletion is confirmed.
+type CommentDeleteConfirmedMsg struct {
+	IssueID   string
+	CommentID int
+}
+
+// CommentDeleteCancelledMsg is sent when the overlay is dismissed without deletion.
+type CommentDeleteCancelledMsg struct{}
+
+// NewCommentDeleteOverlay creates a new comment delete confirmation overlay.
+func NewCommentDeleteOverlay(issueID string, comment beads.Comment) *CommentDeleteOverlay {
+	return &CommentDeleteOverlay{issueID: issueID, comment: comment}
+}
+
+// Init implements tea.Model.
+func (m *CommentDeleteOverlay) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m *CommentDeleteOverlay) Update(msg tea.Msg) (*CommentDeleteOverlay, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("d"))):
+			issueID, commentID := m.issueID, m.comment.ID
+			return m, func() tea.Msg {
+				return CommentDeleteConfirmedMsg{IssueID: issueID, CommentID: commentID}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("c", "esc"))):
+			return m, func() tea.Msg { return CommentDeleteCancelledMsg{} }
+		}
+	}
+	return m, nil
+}
+
+// View implements tea.Model using the unified overlay framework.
+func (m *CommentDeleteOverlay) View() string {
+	b := NewOverlayBuilder(OverlaySizeStandard, 0)
+
+	overlayBg := theme.Current().BackgroundSecondary()
+	contentWidth := b.ContentWidth()
+
+	titleStyle := lipgloss.NewStyle().
+		Background(overlayBg).
+		Foreground(theme.Current().Error()).
+		Bold(true)
+	b.Line(titleStyle.Render("Delete Comment"))
+	b.Line(b.Divider())
+	b.BlankLine()
+
+	body := lipgloss.NewStyle().
+		Background(overlayBg).
+		Foreground(currentThemeWrapper().Text())
+	muted := lipgloss.NewStyle().
+		Background(overlayBg).
+		Foreground(currentThemeWrapper().TextMuted())
+	warning := lipgloss.NewStyle().
+		Background(overlayBg).
+		Foreground(theme.Current().Warning())
+	dangerIcon := lipgloss.NewStyle().Foreground(theme.Current().Error()).Bold(true).Render("✖")
+
+	b.Line(dangerIcon + " " + body.Bold(true).Render("Delete this comment?"))
+	b.BlankLine()
+	b.Line(styleID().Background(overlayBg).Render(m.issueID) + muted.Render("  "+m.comment.Author+"  "+formatTime(m.comment.CreatedAt)))
+	for _, line := range commentPreview(m.comment.Text, contentWidth-2) {
+		b.Line(muted.Render("  " + line))
+	}
+	b.BlankLine()
+	b.Line(warning.Render("This action cannot be undone."))
+
+	b.BlankLine()
+	b.Footer([]footerHint{
+		{"d", "Delete"},
+		{"c/esc", "Cancel"},
+	})
+
+	return b.BuildDanger()
+}
+
+// commentPreview returns the first few lines of a comment, truncated to width.
+func commentPreview(text string, width int) []string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	truncated := len(lines) > commentDeletePreviewLines
+	if truncated {
+		lines = lines[:commentDeletePreviewLines]
+	}
+	for i, line := range lines {
+		if lipgloss.Width(line) > width {
+			lines[i] = truncateWithEllipsis(line, width)
+		}
+	}
+	if truncated {
+		lines = append(lines, "…")
+	}
+	return lines
+}
+
+// Layer returns a Layer for rendering the comment delete overlay.
+func (m *CommentDeleteOverlay) Layer(width, height, topMargin, bottomMargin int) Layer {
+	return BaseOverlayLayer(m.View, width, height, topMargin, bottomMargin)
+}
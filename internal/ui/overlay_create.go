@@ -2,11 +2,12 @@ package ui
 
 import (
 	"abacus/internal/beads"
-	"fmt"
-	"os"
+	"abacus/internal/config"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -56,6 +57,10 @@ const (
 	FocusPriority
 	FocusLabels
 	FocusAssignee
+	FocusDue      // edit mode only
+	FocusDefer    // edit mode only
+	FocusEstimate // edit mode only
+	FocusTemplate // create mode only, opened with Ctrl+T
 )
 
 // Type options
@@ -69,6 +74,7 @@ var priorityLabels = []string{"Crit", "High", "Med", "Low", "Back"}
 // See docs/CREATE_BEAD_SPEC.md Section 3 for zone layout.
 type CreateOverlay struct {
 	editingBead *beads.FullIssue // nil = create mode, non-nil = edit mode
+	editBase    beads.FullIssue  // Copy of the bead when the form opened, to detect concurrent edits
 	// Track original parent for edits to manage dependencies
 	editingBeadParentID string
 
@@ -105,6 +111,18 @@ type CreateOverlay struct {
 	assigneeCombo   ComboBox
 	assigneeOptions []string
 
+	// Zone 6: Schedule (edit mode only) - due date, defer date, estimate
+	dueInput         textinput.Model
+	deferInput       textinput.Model
+	estimateInput    textinput.Model
+	originalSchedule [3]string // Initial input values, to detect changes
+	scheduleError    string    // Validation message shown under the schedule row
+
+	// Template picker (create mode only, shown when templates exist)
+	templates       []config.BeadTemplate
+	templateCombo   ComboBox
+	appliedTemplate string // Name of the last applied template
+
 	// State management
 	isCreating bool // True during form submission (spec Section 4.1)
 
@@ -116,6 +134,7 @@ type CreateOverlay struct {
 type ParentOption struct {
 	ID      string
 	Display string // e.g., "ab-83s Create and Edit..."
+	Title   string // Full title, for template parent patterns and {{parent.title}}
 }
 
 // BeadCreatedMsg is sent when form submission is confirmed.
@@ -146,11 +165,12 @@ type NewAssigneeAddedMsg struct {
 
 // CreateOverlayOptions configures the create overlay.
 type CreateOverlayOptions struct {
-	DefaultParentID    string         // Pre-selected parent (empty for root)
-	AvailableParents   []ParentOption // All beads that can be parents
-	AvailableLabels    []string       // All labels from existing beads
-	AvailableAssignees []string       // All assignees from existing beads
-	IsRootMode         bool           // True if opened with 'N' (no parent)
+	DefaultParentID    string                // Pre-selected parent (empty for root)
+	AvailableParents   []ParentOption        // All beads that can be parents
+	AvailableLabels    []string              // All labels from existing beads
+	AvailableAssignees []string              // All assignees from existing beads
+	IsRootMode         bool                  // True if opened with 'N' (no parent)
+	Templates          []config.BeadTemplate // Templates from .abacus/templates
 }
 
 // NewCreateOverlay creates a new 5-zone create overlay.
@@ -205,11 +225,7 @@ func NewCreateOverlay(opts CreateOverlayOptions) *CreateOverlay {
 
 	// Zone 5: Assignee combo box (single-select)
 	// Prepend "Unassigned" and "Me ($USER)" options per spec Section 3.5
-	assigneeOpts := []string{"Unassigned"}
-	if user := os.Getenv("USER"); user != "" {
-		assigneeOpts = append(assigneeOpts, fmt.Sprintf("Me (%s)", user))
-	}
-	assigneeOpts = append(assigneeOpts, opts.AvailableAssignees...)
+	assigneeOpts := assigneeOptions(opts.AvailableAssignees)
 	assigneeCombo := NewComboBox(assigneeOpts).
 		WithWidth(44).
 		WithMaxVisible(5).
@@ -217,6 +233,16 @@ func NewCreateOverlay(opts CreateOverlayOptions) *CreateOverlay {
 		WithAllowNew(true, "New assignee: %s")
 	assigneeCombo.SetValue("Unassigned")
 
+	// Template picker, opened with Ctrl+T
+	templateNames := make([]string, len(opts.Templates))
+	for i, t := range opts.Templates {
+		templateNames[i] = t.Name
+	}
+	templateCombo := NewComboBox(templateNames).
+		WithWidth(44).
+		WithMaxVisible(5).
+		WithPlaceholder("^t to pick a template")
+
 	// Focus title input BEFORE assigning to struct (textarea.Model is a value type)
 	ti.Focus()
 
@@ -235,6 +261,11 @@ func NewCreateOverlay(opts CreateOverlayOptions) *CreateOverlay {
 		labelsOptions:    opts.AvailableLabels,
 		assigneeCombo:    assigneeCombo,
 		assigneeOptions:  opts.AvailableAssignees,
+		dueInput:         newScheduleInput("YYYY-MM-DD"),
+		deferInput:       newScheduleInput("YYYY-MM-DD"),
+		estimateInput:    newScheduleInput("e.g. 1h30m"),
+		templates:        opts.Templates,
+		templateCombo:    templateCombo,
 	}
 
 	return m
@@ -244,6 +275,8 @@ func NewCreateOverlay(opts CreateOverlayOptions) *CreateOverlay {
 func NewEditOverlay(bead *beads.FullIssue, opts CreateOverlayOptions) *CreateOverlay {
 	m := NewCreateOverlay(opts)
 	m.editingBead = bead
+	m.editBase = *bead
+	m.editBase.Labels = append([]string(nil), bead.Labels...)
 	m.editingBeadParentID = opts.DefaultParentID
 	// Edit mode should always show parent picker, even for roots.
 	m.isRootMode = false
@@ -281,9 +314,68 @@ func NewEditOverlay(bead *beads.FullIssue, opts CreateOverlayOptions) *CreateOve
 		m.assigneeCombo.SetValue("Unassigned")
 	}
 
+	// Pre-fill schedule fields
+	m.dueInput.SetValue(scheduleInputDate(bead.DueAt))
+	m.deferInput.SetValue(scheduleInputDate(bead.DeferUntil))
+	m.estimateInput.SetValue(formatEstimate(bead.EstimatedMinutes))
+	m.originalSchedule = m.scheduleValues()
+
 	return m
 }
 
+// newScheduleInput creates a compact single-line input for the schedule row.
+func newScheduleInput(placeholder string) textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = placeholder
+	ti.CharLimit = 25
+	ti.Width = 10
+	return ti
+}
+
+// scheduleInputDate shows a stored due/defer value as YYYY-MM-DD for editing.
+func scheduleInputDate(value string) string {
+	t, ok := parseScheduleDate(value)
+	if !ok {
+		return value
+	}
+	return t.Local().Format("2006-01-02")
+}
+
+// scheduleValues returns the trimmed due, defer and estimate inputs.
+func (m *CreateOverlay) scheduleValues() [3]string {
+	return [3]string{
+		strings.TrimSpace(m.dueInput.Value()),
+		strings.TrimSpace(m.deferInput.Value()),
+		strings.TrimSpace(m.estimateInput.Value()),
+	}
+}
+
+// scheduleInput returns the input for a schedule focus zone, or nil.
+func (m *CreateOverlay) scheduleInput(focus CreateFocus) *textinput.Model {
+	switch focus {
+	case FocusDue:
+		return &m.dueInput
+	case FocusDefer:
+		return &m.deferInput
+	case FocusEstimate:
+		return &m.estimateInput
+	}
+	return nil
+}
+
+// validateSchedule checks the schedule inputs, returning the estimate in minutes.
+func (m *CreateOverlay) validateSchedule() (int, error) {
+	values := m.scheduleValues()
+	if err := validateScheduleDate("due", values[0]); err != nil {
+		return 0, err
+	}
+	if err := validateScheduleDate("defer", values[1]); err != nil {
+		return 0, err
+	}
+	return parseEstimate(values[2])
+}
+
 // isEditMode returns true when the overlay is editing an existing bead.
 func (m *CreateOverlay) isEditMode() bool {
 	return m.editingBead != nil
@@ -300,7 +392,8 @@ func (m *CreateOverlay) Init() tea.Cmd {
 // like the error recall should be suppressed.
 func (m *CreateOverlay) IsTextInputActive() bool {
 	switch m.focus {
-	case FocusTitle, FocusDescription, FocusParent, FocusLabels, FocusAssignee:
+	case FocusTitle, FocusDescription, FocusParent, FocusLabels, FocusAssignee,
+		FocusDue, FocusDefer, FocusEstimate, FocusTemplate:
 		return true
 	}
 	if m.parentCombo.IsDropdownOpen() || m.labelsCombo.IsDropdownOpen() || m.assigneeCombo.IsDropdownOpen() {
@@ -348,6 +441,9 @@ func (m *CreateOverlay) Update(msg tea.Msg) (*CreateOverlay, tea.Cmd) {
 		return m, nil
 
 	case ComboBoxEnterSelectedMsg:
+		if m.focus == FocusTemplate {
+			return m.applyTemplate(msg.Value)
+		}
 		// Forward to labelsCombo if in FocusLabels mode (to add chip)
 		if m.focus == FocusLabels {
 			var cmd tea.Cmd
@@ -379,6 +475,9 @@ func (m *CreateOverlay) Update(msg tea.Msg) (*CreateOverlay, tea.Cmd) {
 		return m, cmd
 
 	case tea.KeyMsg:
+		if m.focus == FocusTemplate {
+			return m.handleTemplateKey(msg)
+		}
 		// Handle global keys first
 		switch msg.Type {
 		case tea.KeyEsc:
@@ -408,6 +507,12 @@ func (m *CreateOverlay) Update(msg tea.Msg) (*CreateOverlay, tea.Cmd) {
 				return m.handleSubmit()
 			}
 
+		case tea.KeyCtrlT:
+			if m.hasTemplates() && !m.isCreating {
+				return m.openTemplatePicker()
+			}
+			return m, nil
+
 		case tea.KeyTab:
 			return m.handleTab()
 
@@ -437,4 +542,13 @@ type BeadUpdatedMsg struct {
 	OriginalParentID string
 	Labels           []string
 	Assignee         string
+	DueAt            string
+	DeferUntil       string
+	EstimateMinutes  int
+	ScheduleChanged  bool // True when due, defer or estimate was edited
+
+	// Base is the bead as it was when the form opened. Before saving, the
+	// bead is read again; if its UpdatedAt moved, the edit is merged against
+	// Base instead of overwriting the other change. Empty skips the check.
+	Base beads.FullIssue
 }
@@ -47,6 +47,14 @@ func (m *CreateOverlay) handleSubmit() (*CreateOverlay, tea.Cmd) {
 		return m, titleFlashCmd()
 	}
 
+	if m.isEditMode() {
+		if _, err := m.validateSchedule(); err != nil {
+			m.scheduleError = err.Error()
+			return m, nil
+		}
+		m.scheduleError = ""
+	}
+
 	m.hasBackendError = false
 	m.isCreating = true
 
@@ -105,6 +113,23 @@ func (m *CreateOverlay) handleTab() (*CreateOverlay, tea.Cmd) {
 		}
 
 		m.assigneeCombo.Blur()
+		if m.isEditMode() {
+			m.focus = FocusDue
+			cmds = append(cmds, m.dueInput.Focus())
+		} else {
+			m.focus = FocusTitle
+			cmds = append(cmds, m.titleInput.Focus())
+		}
+	case FocusDue:
+		m.dueInput.Blur()
+		m.focus = FocusDefer
+		cmds = append(cmds, m.deferInput.Focus())
+	case FocusDefer:
+		m.deferInput.Blur()
+		m.focus = FocusEstimate
+		cmds = append(cmds, m.estimateInput.Focus())
+	case FocusEstimate:
+		m.estimateInput.Blur()
 		m.focus = FocusTitle
 		cmds = append(cmds, m.titleInput.Focus())
 	}
@@ -118,6 +143,9 @@ func (m *CreateOverlay) handleShiftTab() (*CreateOverlay, tea.Cmd) {
 	m.parentCombo.Blur()
 	m.assigneeCombo.Blur()
 	m.labelsCombo.Blur()
+	m.dueInput.Blur()
+	m.deferInput.Blur()
+	m.estimateInput.Blur()
 
 	switch m.focus {
 	case FocusTitle:
@@ -147,8 +175,22 @@ func (m *CreateOverlay) handleShiftTab() (*CreateOverlay, tea.Cmd) {
 		cmds = append(cmds, m.labelsCombo.Focus())
 	case FocusParent:
 		m.parentCombo.Blur()
+		if m.isEditMode() {
+			m.focus = FocusEstimate
+			cmds = append(cmds, m.estimateInput.Focus())
+		} else {
+			m.focus = FocusAssignee
+			cmds = append(cmds, m.assigneeCombo.Focus())
+		}
+	case FocusDue:
 		m.focus = FocusAssignee
 		cmds = append(cmds, m.assigneeCombo.Focus())
+	case FocusDefer:
+		m.focus = FocusDue
+		cmds = append(cmds, m.dueInput.Focus())
+	case FocusEstimate:
+		m.focus = FocusDefer
+		cmds = append(cmds, m.deferInput.Focus())
 	}
 
 	return m, tea.Batch(cmds...)
@@ -275,6 +317,12 @@ func (m *CreateOverlay) handleZoneInput(msg tea.KeyMsg) (*CreateOverlay, tea.Cmd
 	case FocusAssignee:
 		m.assigneeCombo, cmd = m.assigneeCombo.Update(msg)
 		return m, cmd
+
+	case FocusDue, FocusDefer, FocusEstimate:
+		input := m.scheduleInput(m.focus)
+		*input, cmd = input.Update(msg)
+		m.scheduleError = ""
+		return m, cmd
 	}
 
 	return m, nil
@@ -294,6 +342,11 @@ func (m *CreateOverlay) passToFocusedZone(msg tea.Msg) (*CreateOverlay, tea.Cmd)
 		m.labelsCombo, cmd = m.labelsCombo.Update(msg)
 	case FocusAssignee:
 		m.assigneeCombo, cmd = m.assigneeCombo.Update(msg)
+	case FocusDue, FocusDefer, FocusEstimate:
+		input := m.scheduleInput(m.focus)
+		*input, cmd = input.Update(msg)
+	case FocusTemplate:
+		m.templateCombo, cmd = m.templateCombo.Update(msg)
 	}
 
 	return m, cmd
@@ -302,7 +355,8 @@ func (m *CreateOverlay) passToFocusedZone(msg tea.Msg) (*CreateOverlay, tea.Cmd)
 func (m *CreateOverlay) isAnyDropdownOpen() bool {
 	return m.parentCombo.IsDropdownOpen() ||
 		m.labelsCombo.IsDropdownOpen() ||
-		m.assigneeCombo.IsDropdownOpen()
+		m.assigneeCombo.IsDropdownOpen() ||
+		m.templateCombo.IsDropdownOpen()
 }
 
 func (m *CreateOverlay) handleTypeHotkey(r rune) {
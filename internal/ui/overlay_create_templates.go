@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"abacus/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Delimiters for {{name}} placeholders in template titles and bodies.
+const templateVarOpen, templateVarClose = "{{", "}}"
+
+// hasTemplates reports whether the template picker is available.
+func (m *CreateOverlay) hasTemplates() bool {
+	return !m.isEditMode() && len(m.templates) > 0
+}
+
+// openTemplatePicker focuses the template combo with its dropdown open.
+func (m *CreateOverlay) openTemplatePicker() (*CreateOverlay, tea.Cmd) {
+	m.blurAll()
+	m.focus = FocusTemplate
+	cmd := m.templateCombo.Focus()
+	m.templateCombo, _ = m.templateCombo.Update(tea.KeyMsg{Type: tea.KeyDown})
+	return m, cmd
+}
+
+// closeTemplatePicker returns focus to the title without applying a template.
+func (m *CreateOverlay) closeTemplatePicker() (*CreateOverlay, tea.Cmd) {
+	m.templateCombo.Blur()
+	m.templateCombo.SetValue(m.appliedTemplate)
+	m.focus = FocusTitle
+	return m, m.titleInput.Focus()
+}
+
+// handleTemplateKey routes keys while the template picker has focus.
+func (m *CreateOverlay) handleTemplateKey(msg tea.KeyMsg) (*CreateOverlay, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyTab, tea.KeyShiftTab:
+		return m.closeTemplatePicker()
+	case tea.KeyEnter:
+		if !m.templateCombo.IsDropdownOpen() {
+			return m.closeTemplatePicker()
+		}
+	}
+	var cmd tea.Cmd
+	m.templateCombo, cmd = m.templateCombo.Update(msg)
+	return m, cmd
+}
+
+// blurAll removes focus from every input so another zone can take it.
+func (m *CreateOverlay) blurAll() {
+	m.titleInput.Blur()
+	m.descriptionInput.Blur()
+	m.parentCombo.Blur()
+	m.labelsCombo.Blur()
+	m.assigneeCombo.Blur()
+	m.templateCombo.Blur()
+}
+
+// applyTemplate fills the form from the named template. Fields the template
+// leaves empty keep their current values; labels are added to any already chosen.
+func (m *CreateOverlay) applyTemplate(name string) (*CreateOverlay, tea.Cmd) {
+	var tmpl *config.BeadTemplate
+	for i := range m.templates {
+		if m.templates[i].Name == name {
+			tmpl = &m.templates[i]
+			break
+		}
+	}
+	if tmpl == nil {
+		return m.closeTemplatePicker()
+	}
+	m.appliedTemplate = tmpl.Name
+
+	if tmpl.Parent != "" {
+		m.selectTemplateParent(tmpl.Parent)
+	}
+	vars := m.templateVars()
+
+	if tmpl.Title != "" {
+		m.titleInput.SetValue(expandTemplateVars(tmpl.Title, vars))
+		m.titleInput.CursorEnd()
+		m.updateTitleHeight()
+	}
+	if tmpl.Body != "" {
+		m.descriptionInput.SetValue(expandTemplateVars(tmpl.Body, vars))
+	}
+	if tmpl.Type != "" {
+		for i, t := range typeOptions {
+			if t == tmpl.Type {
+				m.typeIndex = i
+				m.typeManuallySet = true
+				break
+			}
+		}
+	}
+	if tmpl.Priority >= 0 && tmpl.Priority < len(priorityLabels) {
+		m.priorityIndex = tmpl.Priority
+	}
+	if len(tmpl.Labels) > 0 {
+		m.labelsCombo.SetChips(append(m.labelsCombo.GetChips(), tmpl.Labels...))
+	}
+	if tmpl.Assignee != "" {
+		m.assigneeCombo.SetValue(templateAssigneeOption(tmpl.Assignee))
+	}
+	return m.closeTemplatePicker()
+}
+
+// selectTemplateParent keeps the current parent when it matches the
+// template's parent pattern, otherwise picks the first parent that does.
+func (m *CreateOverlay) selectTemplateParent(pattern string) {
+	if current, ok := m.selectedParent(); ok && parentMatchesPattern(current, pattern) {
+		return
+	}
+	for _, p := range m.parentOptions {
+		if parentMatchesPattern(p, pattern) {
+			m.parentCombo.SetValue(p.Display)
+			m.parentOriginal = p.Display
+			m.isRootMode = false
+			return
+		}
+	}
+}
+
+// selectedParent returns the parent option currently chosen in the form.
+func (m *CreateOverlay) selectedParent() (ParentOption, bool) {
+	display := m.parentCombo.Value()
+	if display == "" {
+		return ParentOption{}, false
+	}
+	for _, p := range m.parentOptions {
+		if p.Display == display {
+			return p, true
+		}
+	}
+	return ParentOption{}, false
+}
+
+// parentMatchesPattern matches a case-insensitive glob against the parent ID
+// or full title.
+func parentMatchesPattern(p ParentOption, pattern string) bool {
+	pattern = strings.ToLower(pattern)
+	for _, candidate := range []string{p.ID, p.Title} {
+		if ok, err := path.Match(pattern, strings.ToLower(candidate)); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// templateVars returns the values available to {{variable}} substitution.
+func (m *CreateOverlay) templateVars() map[string]string {
+	vars := map[string]string{
+		"date": time.Now().Format("2006-01-02"),
+		"user": currentUser(),
+	}
+	if p, ok := m.selectedParent(); ok {
+		vars["parent.id"] = p.ID
+		vars["parent.title"] = p.Title
+	} else {
+		vars["parent.id"] = ""
+		vars["parent.title"] = ""
+	}
+	return vars
+}
+
+// expandTemplateVars replaces {{name}} placeholders. Unknown names are left
+// untouched so typos stay visible in the form.
+func expandTemplateVars(text string, vars map[string]string) string {
+	var sb strings.Builder
+	for {
+		start := strings.Index(text, templateVarOpen)
+		if start < 0 {
+			break
+		}
+		end := strings.Index(text[start:], templateVarClose)
+		if end < 0 {
+			break
+		}
+		end += start
+		name := strings.TrimSpace(text[start+len(templateVarOpen) : end])
+		sb.WriteString(text[:start])
+		if value, ok := vars[name]; ok {
+			sb.WriteString(value)
+		} else {
+			sb.WriteString(text[start : end+len(templateVarClose)])
+		}
+		text = text[end+len(templateVarClose):]
+	}
+	sb.WriteString(text)
+	return sb.String()
+}
+
+// templateAssigneeOption maps a template assignee to a picker value;
+// "me" selects the current user.
+func templateAssigneeOption(assignee string) string {
+	if strings.EqualFold(assignee, "me") {
+		if user := currentUser(); user != "" {
+			return fmt.Sprintf("Me (%s)", user)
+		}
+		return assigneeOptionUnassigned
+	}
+	return assigneeOptionFor(assignee)
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"abacus/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func templateTestOverlay(defaultParent string) *CreateOverlay {
+	return NewCreateOverlay(CreateOverlayOptions{
+		DefaultParentID: defaultParent,
+		AvailableParents: []ParentOption{
+			{ID: "ab-1", Display: "ab-1 Release 2.0", Title: "Release 2.0"},
+			{ID: "ab-2", Display: "ab-2 Bug triage", Title: "Bug triage"},
+		},
+		Templates: []config.BeadTemplate{
+			{
+				Name:     "Bug report",
+				Title:    "Bug: ",
+				Type:     "bug",
+				Priority: 1,
+				Labels:   []string{"bug"},
+				Parent:   "*triage*",
+				Body:     "Filed {{date}} under {{parent.title}}\n\n## Repro\n{{unknown}}",
+			},
+			{Name: "Spike", Type: "task", Priority: -1, Body: "Part of {{parent.id}}"},
+		},
+	})
+}
+
+func TestCreateOverlayTemplatePicker(t *testing.T) {
+	t.Run("applyTemplate", func(t *testing.T) {
+		m := templateTestOverlay("ab-1")
+		m.labelsCombo.SetChips([]string{"ui"})
+
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+		if m.focus != FocusTemplate || !m.templateCombo.IsDropdownOpen() {
+			t.Fatalf("expected template picker open, focus=%v", m.focus)
+		}
+		m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m, _ = m.Update(cmd())
+
+		if m.focus != FocusTitle || m.appliedTemplate != "Bug report" {
+			t.Fatalf("expected template applied and title focused, focus=%v applied=%q", m.focus, m.appliedTemplate)
+		}
+		if m.titleInput.Value() != "Bug: " || typeOptions[m.typeIndex] != "bug" || m.priorityIndex != 1 {
+			t.Fatalf("unexpected fields: title=%q type=%d priority=%d", m.titleInput.Value(), m.typeIndex, m.priorityIndex)
+		}
+		if m.ParentID() != "ab-2" {
+			t.Fatalf("expected parent pattern to select ab-2, got %q", m.ParentID())
+		}
+		if got := strings.Join(m.labelsCombo.GetChips(), ","); got != "ui,bug" {
+			t.Fatalf("expected template labels added to existing ones, got %q", got)
+		}
+		want := "Filed " + time.Now().Format("2006-01-02") + " under Bug triage\n\n## Repro\n{{unknown}}"
+		if m.descriptionInput.Value() != want {
+			t.Fatalf("unexpected description:\n got %q\nwant %q", m.descriptionInput.Value(), want)
+		}
+	})
+
+	t.Run("matchingParentIsKept", func(t *testing.T) {
+		m := templateTestOverlay("ab-2")
+		m.selectTemplateParent("ab-*")
+		if m.ParentID() != "ab-2" {
+			t.Fatalf("expected current parent kept, got %q", m.ParentID())
+		}
+	})
+
+	t.Run("escapeClosesPickerOnly", func(t *testing.T) {
+		m := templateTestOverlay("")
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+		m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if cmd != nil {
+			if _, ok := cmd().(CreateCancelledMsg); ok {
+				t.Fatal("expected esc to close the picker, not the overlay")
+			}
+		}
+		if m.focus != FocusTitle || m.appliedTemplate != "" {
+			t.Fatalf("expected picker closed without applying, focus=%v", m.focus)
+		}
+	})
+
+	t.Run("unavailableWithoutTemplates", func(t *testing.T) {
+		m := NewCreateOverlay(CreateOverlayOptions{})
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+		if m.focus == FocusTemplate || strings.Contains(m.View(), "TEMPLATE") {
+			t.Fatal("expected no template picker without templates")
+		}
+	})
+}
+
+func TestExpandTemplateVars(t *testing.T) {
+	vars := map[string]string{"parent.title": "Epic", "user": "alice"}
+	got := expandTemplateVars("{{ parent.title }} by {{user}} {{nope}} {{unterminated", vars)
+	if got != "Epic by alice {{nope}} {{unterminated" {
+		t.Fatalf("unexpected expansion: %q", got)
+	}
+}
@@ -64,19 +64,15 @@ func typeIndexFromString(issueType string) int {
 
 // getAssigneeValue returns a normalized assignee string for submission.
 func (m *CreateOverlay) getAssigneeValue() string {
-	assignee := m.assigneeCombo.Value()
-	if assignee == "Unassigned" {
-		return ""
-	}
-	if strings.HasPrefix(assignee, "Me (") && strings.HasSuffix(assignee, ")") {
-		return strings.TrimSuffix(strings.TrimPrefix(assignee, "Me ("), ")")
-	}
-	return assignee
+	return normalizeAssigneeOption(m.assigneeCombo.Value())
 }
 
 // submitEdit packages the current form values for update.
+// Schedule inputs are validated in handleSubmit before this runs.
 func (m *CreateOverlay) submitEdit() tea.Cmd {
 	return func() tea.Msg {
+		schedule := m.scheduleValues()
+		estimate, _ := parseEstimate(schedule[2])
 		return BeadUpdatedMsg{
 			ID:          m.editingBead.ID,
 			Title:       strings.TrimSpace(m.titleInput.Value()),
@@ -90,8 +86,13 @@ func (m *CreateOverlay) submitEdit() tea.Cmd {
 				}
 				return ""
 			}(),
-			Labels:   m.labelsCombo.GetChips(),
-			Assignee: m.getAssigneeValue(),
+			Labels:          m.labelsCombo.GetChips(),
+			Assignee:        m.getAssigneeValue(),
+			DueAt:           schedule[0],
+			DeferUntil:      schedule[1],
+			EstimateMinutes: estimate,
+			ScheduleChanged: schedule != m.originalSchedule,
+			Base:            m.editBase,
 		}
 	}
 }
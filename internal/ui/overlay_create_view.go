@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -110,6 +111,13 @@ func (m *CreateOverlay) View() string {
 	ob.Line(ob.Divider())
 	ob.BlankLine()
 
+	// Template picker (only when .abacus/templates has templates)
+	if m.hasTemplates() {
+		ob.Line(m.renderSectionLabel("TEMPLATE", m.focus == FocusTemplate, parentSearchActive))
+		ob.Line(m.templateCombo.View())
+		ob.BlankLine()
+	}
+
 	// Zone 1: Parent (anchor at top) - never dimmed
 	parentLabel := m.renderSectionLabel("PARENT", m.focus == FocusParent, false)
 	hint := "Shift+Tab"
@@ -170,6 +178,21 @@ func (m *CreateOverlay) View() string {
 	ob.Line(assigneeView)
 	ob.BlankLine()
 
+	// Zone 6: Schedule (edit mode only) - dimmed when parent search active
+	if m.isEditMode() {
+		focused := m.focus == FocusDue || m.focus == FocusDefer || m.focus == FocusEstimate
+		ob.Line(m.renderSectionLabel("SCHEDULE", focused, parentSearchActive))
+		scheduleView := m.renderScheduleRow()
+		if parentSearchActive {
+			scheduleView = styleCreateDimmed().Render(scheduleView)
+		}
+		ob.Line(scheduleView)
+		if m.scheduleError != "" {
+			ob.Line(lipgloss.NewStyle().Foreground(currentThemeWrapper().Error()).Render(m.scheduleError))
+		}
+		ob.BlankLine()
+	}
+
 	// Footer - show "Creating bead..." when submitting, otherwise show hints
 	if m.isCreating {
 		ob.FooterText("Creating bead...")
@@ -180,6 +203,23 @@ func (m *CreateOverlay) View() string {
 	return ob.Build()
 }
 
+// renderScheduleRow renders the due, defer and estimate inputs, one per line
+// so they fit the narrowest dialog width.
+func (m *CreateOverlay) renderScheduleRow() string {
+	field := func(label string, input textinput.Model, focus CreateFocus) string {
+		labelStyle := lipgloss.NewStyle().Foreground(currentThemeWrapper().TextMuted()).Width(9)
+		if m.focus == focus {
+			labelStyle = labelStyle.Foreground(currentThemeWrapper().Secondary()).Bold(true)
+		}
+		return labelStyle.Render(label) + input.View()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left,
+		field("Due", m.dueInput, FocusDue),
+		field("Defer", m.deferInput, FocusDefer),
+		field("Estimate", m.estimateInput, FocusEstimate),
+	)
+}
+
 // renderSectionLabel renders a section label with appropriate styling.
 func (m *CreateOverlay) renderSectionLabel(label string, focused, dimmed bool) string {
 	if dimmed {
@@ -220,7 +260,7 @@ func (m *CreateOverlay) renderDescInput(contentWidth int, dimmed bool) string {
 
 // footerHints returns the footer hints based on current state.
 func (m *CreateOverlay) footerHints() []footerHint {
-	if m.parentCombo.IsDropdownOpen() || m.labelsCombo.IsDropdownOpen() || m.assigneeCombo.IsDropdownOpen() {
+	if m.isAnyDropdownOpen() {
 		return []footerHint{
 			{"⏎", "Select"},
 			{"esc", "Revert"},
@@ -243,6 +283,14 @@ func (m *CreateOverlay) footerHints() []footerHint {
 		}
 	}
 	// Default state
+	if m.hasTemplates() {
+		return []footerHint{
+			{"⏎", m.submitFooterText()},
+			{"Tab", "Next"},
+			{"^t", "Template"},
+			{"esc", "Cancel"},
+		}
+	}
 	return []footerHint{
 		{"⏎", m.submitFooterText()},
 		{"Tab", "Next"},
@@ -414,4 +462,5 @@ func (m *CreateOverlay) SetSize(width, height int) {
 	m.parentCombo = m.parentCombo.WithWidth(contentWidth)
 	m.labelsCombo = m.labelsCombo.WithWidth(contentWidth)
 	m.assigneeCombo = m.assigneeCombo.WithWidth(contentWidth)
+	m.templateCombo = m.templateCombo.WithWidth(contentWidth)
 }
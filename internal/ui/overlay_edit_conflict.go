@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// EditConflictOverlay shows, for each field someone else changed while a
+// bead was being edited, the base, mine and theirs values side by side and
+// lets the user pick which one to keep.
+type EditConflictOverlay struct {
+	conflict editConflict
+	cursor   int
+}
+
+// EditConflictResolvedMsg is sent when the merged edit should be saved.
+type EditConflictResolvedMsg struct {
+	Update BeadUpdatedMsg
+}
+
+// EditConflictCancelledMsg is sent to go back to the edit form unsaved.
+type EditConflictCancelledMsg struct{}
+
+// NewEditConflictOverlay creates the merge view for a conflicting edit.
+func NewEditConflictOverlay(conflict editConflict) *EditConflictOverlay {
+	return &EditConflictOverlay{conflict: conflict}
+}
+
+// Init implements tea.Model.
+func (m *EditConflictOverlay) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m *EditConflictOverlay) Update(msg tea.Msg) (*EditConflictOverlay, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(m.conflict.fields) == 0 {
+		return m, nil
+	}
+	f := &m.conflict.fields[m.cursor]
+	switch {
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("esc"))):
+		return m, func() tea.Msg { return EditConflictCancelledMsg{} }
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("enter", "ctrl+s"))):
+		update := m.conflict.resolved()
+		return m, func() tea.Msg { return EditConflictResolvedMsg{Update: update} }
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("j", "down", "tab"))):
+		m.cursor = min(m.cursor+1, len(m.conflict.fields)-1)
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("k", "up", "shift+tab"))):
+		m.cursor = max(m.cursor-1, 0)
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("l", "right"))):
+		m.cycleChoice(f, 1)
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("h", "left"))):
+		m.cycleChoice(f, -1)
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("m"))):
+		f.Choice = choiceMine
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("t"))):
+		f.Choice = choiceTheirs
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("b"))):
+		f.Choice = choiceBase
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("+"))):
+		if slices.Contains(f.Choices(), choiceBoth) {
+			f.Choice = choiceBoth
+		}
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("M"))):
+		m.chooseAll(choiceMine)
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("T"))):
+		m.chooseAll(choiceTheirs)
+	}
+	return m, nil
+}
+
+func (m *EditConflictOverlay) cycleChoice(f *fieldConflict, delta int) {
+	choices := f.Choices()
+	i := slices.Index(choices, f.Choice)
+	f.Choice = choices[(i+delta+len(choices))%len(choices)]
+}
+
+func (m *EditConflictOverlay) chooseAll(c conflictChoice) {
+	for i := range m.conflict.fields {
+		m.conflict.fields[i].Choice = c
+	}
+}
+
+// View implements tea.Model using the unified overlay framework.
+func (m *EditConflictOverlay) View() string {
+	b := NewOverlayBuilder(OverlaySizeWide, 0)
+	contentWidth := b.ContentWidth()
+	warning := lipgloss.NewStyle().Foreground(currentThemeWrapper().Warning())
+
+	b.Line(styleOverlaySectionLabel().Render("Edit Conflict") + styleStatsDim().Render("  "+m.conflict.update.ID))
+	b.Line(b.Divider())
+	b.Line(warning.Render("⚠ This bead was changed while you were editing it."))
+	b.Line(styleStatsDim().Render("Choose what to keep for each field changed since you opened it."))
+
+	for i, f := range m.conflict.fields {
+		b.BlankLine()
+		b.Lines(m.renderField(i, f, contentWidth)...)
+	}
+
+	b.BlankLine()
+	b.Footer(m.footerHints())
+	return b.Build()
+}
+
+func (m *EditConflictOverlay) renderField(index int, f fieldConflict, contentWidth int) []string {
+	status := "changed by someone else"
+	if f.Conflicting {
+		status = "changed by both"
+	}
+	header := styleStatusOption().Render("  " + f.Name)
+	if index == m.cursor {
+		header = styleStatusSelected().Render("› " + f.Name)
+	}
+	lines := []string{header + styleStatsDim().Render("  "+status)}
+
+	for _, c := range f.Choices() {
+		marker := "○"
+		style := styleStatusOption()
+		if c == f.Choice {
+			marker = "●"
+			style = styleLabelChecked()
+		}
+		label := fmt.Sprintf("    %s %-6s  ", marker, c)
+		value := conflictPreview(f.Value(c), contentWidth-lipgloss.Width(label))
+		lines = append(lines, style.Render(label)+styleStatsDim().Render(value))
+	}
+	return lines
+}
+
+// conflictPreview shows the first line of a value, marking anything cut off.
+func conflictPreview(value string, width int) string {
+	if value == "" {
+		return "(empty)"
+	}
+	first, rest, multiline := strings.Cut(value, "\n")
+	if multiline && strings.TrimSpace(rest) != "" {
+		first += " …"
+	}
+	return truncateWithEllipsis(first, width)
+}
+
+func (m *EditConflictOverlay) footerHints() []footerHint {
+	return []footerHint{
+		{"↑↓", "Field"},
+		{"←→", "Choose"},
+		{"m/t/b", "Mine/Theirs/Base"},
+		{"⏎", "Save"},
+		{"esc", "Back"},
+	}
+}
+
+// Layer returns a centered layer for the conflict view.
+func (m *EditConflictOverlay) Layer(width, height, topMargin, bottomMargin int) Layer {
+	return BaseOverlayLayer(m.View, width, height, topMargin, bottomMargin)
+}
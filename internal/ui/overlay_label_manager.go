@@ -0,0 +1,395 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	labelManagerVisibleRows  = 10
+	labelManagerPreviewLines = 4
+)
+
+type labelManagerMode int
+
+const (
+	labelManagerList labelManagerMode = iota
+	labelManagerRename
+	labelManagerMerge
+	labelManagerPreview
+)
+
+// LabelManagerOverlay lists every label in the project with its usage count
+// and previews project-wide rename, merge and delete operations.
+type LabelManagerOverlay struct {
+	usage         []labelUsage
+	labelsByIssue map[string][]string
+	cursor        int
+	offset        int
+	mode          labelManagerMode
+	renameInput   textinput.Model
+	mergeCombo    ComboBox
+	plan          LabelChangePlan
+	errorMsg      string
+}
+
+// LabelChangeConfirmedMsg is sent when a previewed label change is applied.
+type LabelChangeConfirmedMsg struct {
+	Plan LabelChangePlan
+}
+
+// LabelManagerClosedMsg is sent when the label manager is dismissed.
+type LabelManagerClosedMsg struct{}
+
+// NewLabelManagerOverlay creates the label manager from the loaded beads.
+func NewLabelManagerOverlay(usage []labelUsage, labelsByIssue map[string][]string) *LabelManagerOverlay {
+	ti := textinput.New()
+	ti.CharLimit = 100
+	ti.Width = OverlayContentWidth(OverlayWidthStandard) - 4
+	ti.Prompt = ""
+	return &LabelManagerOverlay{
+		usage:         usage,
+		labelsByIssue: labelsByIssue,
+		renameInput:   ti,
+	}
+}
+
+// Init implements tea.Model.
+func (m *LabelManagerOverlay) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m *LabelManagerOverlay) Update(msg tea.Msg) (*LabelManagerOverlay, tea.Cmd) {
+	switch m.mode {
+	case labelManagerRename:
+		return m.updateRename(msg)
+	case labelManagerMerge:
+		return m.updateMerge(msg)
+	case labelManagerPreview:
+		return m.updatePreview(msg)
+	default:
+		return m.updateList(msg)
+	}
+}
+
+func (m *LabelManagerOverlay) updateList(msg tea.Msg) (*LabelManagerOverlay, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch {
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("esc", "q"))):
+		return m, func() tea.Msg { return LabelManagerClosedMsg{} }
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("j", "down"))):
+		m.moveCursor(1)
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("k", "up"))):
+		m.moveCursor(-1)
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("g", "home"))):
+		m.moveCursor(-len(m.usage))
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("G", "end"))):
+		m.moveCursor(len(m.usage))
+	}
+	if len(m.usage) == 0 {
+		return m, nil
+	}
+	selected := m.usage[m.cursor].Name
+	switch {
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("r"))):
+		m.mode = labelManagerRename
+		m.errorMsg = ""
+		m.renameInput.SetValue(selected)
+		m.renameInput.CursorEnd()
+		return m, m.renameInput.Focus()
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("m"))):
+		if len(m.usage) < 2 {
+			m.errorMsg = "No other label to merge into"
+			return m, nil
+		}
+		m.mode = labelManagerMerge
+		m.errorMsg = ""
+		m.mergeCombo = NewComboBox(m.otherLabels(selected)).
+			WithWidth(OverlayContentWidth(OverlayWidthStandard)).
+			WithMaxVisible(6).
+			WithPlaceholder("merge into...")
+		return m, m.mergeCombo.Focus()
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("d", "delete"))):
+		m.showPreview(planLabelChange(LabelChangeDelete, selected, "", m.usage, m.labelsByIssue))
+	}
+	return m, nil
+}
+
+func (m *LabelManagerOverlay) updateRename(msg tea.Msg) (*LabelManagerOverlay, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.Type {
+		case tea.KeyEsc:
+			m.backToList()
+			return m, nil
+		case tea.KeyEnter:
+			from := m.usage[m.cursor].Name
+			to := strings.TrimSpace(m.renameInput.Value())
+			switch {
+			case to == "":
+				m.errorMsg = "Label name cannot be empty"
+			case to == from:
+				m.errorMsg = "Enter a different name"
+			default:
+				m.showPreview(planLabelChange(LabelChangeRename, from, to, m.usage, m.labelsByIssue))
+			}
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.renameInput, cmd = m.renameInput.Update(msg)
+	return m, cmd
+}
+
+func (m *LabelManagerOverlay) updateMerge(msg tea.Msg) (*LabelManagerOverlay, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ComboBoxEnterSelectedMsg:
+		m.showPreview(planLabelChange(LabelChangeMerge, m.usage[m.cursor].Name, msg.Value, m.usage, m.labelsByIssue))
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc:
+			if m.mergeCombo.IsDropdownOpen() || m.mergeCombo.InputValue() != m.mergeCombo.Value() {
+				m.mergeCombo, _ = m.mergeCombo.Update(msg)
+				return m, nil
+			}
+			m.backToList()
+			return m, nil
+		case tea.KeyEnter:
+			if !m.mergeCombo.IsDropdownOpen() && m.mergeCombo.Value() != "" {
+				m.showPreview(planLabelChange(LabelChangeMerge, m.usage[m.cursor].Name, m.mergeCombo.Value(), m.usage, m.labelsByIssue))
+				return m, nil
+			}
+		}
+	}
+	var cmd tea.Cmd
+	m.mergeCombo, cmd = m.mergeCombo.Update(msg)
+	return m, cmd
+}
+
+func (m *LabelManagerOverlay) updatePreview(msg tea.Msg) (*LabelManagerOverlay, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch {
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("enter"))):
+		plan := m.plan
+		return m, func() tea.Msg { return LabelChangeConfirmedMsg{Plan: plan} }
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("esc"))):
+		m.backToList()
+	}
+	return m, nil
+}
+
+func (m *LabelManagerOverlay) moveCursor(delta int) {
+	if len(m.usage) == 0 {
+		return
+	}
+	m.cursor += delta
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	if m.cursor >= len(m.usage) {
+		m.cursor = len(m.usage) - 1
+	}
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+labelManagerVisibleRows {
+		m.offset = m.cursor - labelManagerVisibleRows + 1
+	}
+	m.errorMsg = ""
+}
+
+func (m *LabelManagerOverlay) showPreview(plan LabelChangePlan) {
+	m.plan = plan
+	m.mode = labelManagerPreview
+	m.errorMsg = ""
+	m.renameInput.Blur()
+}
+
+func (m *LabelManagerOverlay) backToList() {
+	m.mode = labelManagerList
+	m.errorMsg = ""
+	m.renameInput.Blur()
+}
+
+func (m *LabelManagerOverlay) otherLabels(exclude string) []string {
+	labels := make([]string, 0, len(m.usage))
+	for _, u := range m.usage {
+		if u.Name != exclude {
+			labels = append(labels, u.Name)
+		}
+	}
+	return labels
+}
+
+// View implements tea.Model using the unified overlay framework.
+func (m *LabelManagerOverlay) View() string {
+	b := NewOverlayBuilder(OverlaySizeStandard, 0)
+	contentWidth := b.ContentWidth()
+
+	b.Line(styleOverlaySectionLabel().Render("Labels") + styleStatsDim().Render(fmt.Sprintf("  %d in project", len(m.usage))))
+	b.Line(b.Divider())
+
+	switch m.mode {
+	case labelManagerRename:
+		b.Line(styleStatsDim().Render("Rename ") + styleLabelChecked().Render(m.usage[m.cursor].Name) + styleStatsDim().Render(" to:"))
+		b.BlankLine()
+		b.Line(m.renameInput.View())
+	case labelManagerMerge:
+		b.Line(styleStatsDim().Render("Merge ") + styleLabelChecked().Render(m.usage[m.cursor].Name) + styleStatsDim().Render(" into:"))
+		b.BlankLine()
+		b.Line(m.mergeCombo.View())
+	case labelManagerPreview:
+		b.Lines(m.renderPreview(contentWidth)...)
+	default:
+		b.Lines(m.renderList(contentWidth)...)
+	}
+
+	if m.errorMsg != "" {
+		b.BlankLine()
+		b.Line(lipgloss.NewStyle().Foreground(currentThemeWrapper().Error()).Render("⚠ " + m.errorMsg))
+	}
+	b.BlankLine()
+	b.Footer(m.footerHints())
+
+	if m.mode == labelManagerPreview && m.plan.Op == LabelChangeDelete {
+		return b.BuildDanger()
+	}
+	return b.Build()
+}
+
+func (m *LabelManagerOverlay) renderList(contentWidth int) []string {
+	if len(m.usage) == 0 {
+		return []string{styleStatsDim().Render("No labels in this project")}
+	}
+	end := m.offset + labelManagerVisibleRows
+	if end > len(m.usage) {
+		end = len(m.usage)
+	}
+	lines := make([]string, 0, end-m.offset+2)
+	if m.offset > 0 {
+		lines = append(lines, styleStatsDim().Render(fmt.Sprintf("  ↑ %d more", m.offset)))
+	}
+	for i := m.offset; i < end; i++ {
+		u := m.usage[i]
+		count := fmt.Sprintf("%d", len(u.IssueIDs))
+		nameWidth := contentWidth - 4 - len(count) - 1
+		name := truncateWithEllipsis(u.Name, nameWidth)
+		row := name + strings.Repeat(" ", max(1, nameWidth-lipgloss.Width(name)+1)) + count
+		if i == m.cursor {
+			lines = append(lines, styleStatusSelected().Render("› "+row))
+		} else {
+			lines = append(lines, styleStatusOption().Render("  "+row))
+		}
+	}
+	if end < len(m.usage) {
+		lines = append(lines, styleStatsDim().Render(fmt.Sprintf("  ↓ %d more", len(m.usage)-end)))
+	}
+	return lines
+}
+
+func (m *LabelManagerOverlay) renderPreview(contentWidth int) []string {
+	p := m.plan
+	var title string
+	switch p.Op {
+	case LabelChangeMerge:
+		title = "Merge label"
+	case LabelChangeDelete:
+		title = "Delete label"
+	default:
+		title = "Rename label"
+	}
+	lines := []string{
+		styleStatusSelected().Render(title) + styleStatsDim().Render("  ") + styleStatusOption().Render(p.Summary()),
+		"",
+		styleStatusOption().Render(fmt.Sprintf("%d %s will be updated", len(p.Steps), beadWord(len(p.Steps)))),
+	}
+	if p.Op == LabelChangeMerge {
+		already := 0
+		for _, s := range p.Steps {
+			if s.Add == "" {
+				already++
+			}
+		}
+		if already > 0 {
+			lines = append(lines, styleStatsDim().Render(fmt.Sprintf("%d already labelled %s", already, p.To)))
+		}
+	}
+
+	ids := make([]string, len(p.Steps))
+	for i, s := range p.Steps {
+		ids[i] = s.IssueID
+	}
+	wrapped := strings.Split(wrapIDs(ids, contentWidth-2), "\n")
+	if len(wrapped) > labelManagerPreviewLines {
+		wrapped = append(wrapped[:labelManagerPreviewLines], "…")
+	}
+	for _, line := range wrapped {
+		lines = append(lines, styleID().Render("  "+line))
+	}
+	if p.Op == LabelChangeDelete {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(currentThemeWrapper().Warning()).Render("This removes the label from every bead."))
+	}
+	return lines
+}
+
+// footerHints returns the footer for the current mode.
+func (m *LabelManagerOverlay) footerHints() []footerHint {
+	switch m.mode {
+	case labelManagerRename, labelManagerMerge:
+		return []footerHint{
+			{"⏎", "Preview"},
+			{"esc", "Back"},
+		}
+	case labelManagerPreview:
+		return []footerHint{
+			{"⏎", "Apply"},
+			{"esc", "Back"},
+		}
+	default:
+		return []footerHint{
+			{"r", "Rename"},
+			{"m", "Merge"},
+			{"d", "Delete"},
+			{"esc", "Close"},
+		}
+	}
+}
+
+// Layer returns a centered layer for the label manager.
+func (m *LabelManagerOverlay) Layer(width, height, topMargin, bottomMargin int) Layer {
+	return BaseOverlayLayer(m.View, width, height, topMargin, bottomMargin)
+}
+
+// wrapIDs joins bead IDs with commas, breaking lines at width.
+func wrapIDs(ids []string, width int) string {
+	var sb strings.Builder
+	lineLen := 0
+	for i, id := range ids {
+		part := id
+		if i < len(ids)-1 {
+			part += ","
+		}
+		if lineLen > 0 && lineLen+1+len(part) > width {
+			sb.WriteString("\n")
+			lineLen = 0
+		} else if lineLen > 0 {
+			sb.WriteString(" ")
+			lineLen++
+		}
+		sb.WriteString(part)
+		lineLen += len(part)
+	}
+	return sb.String()
+}
@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	outlineTextareaLines = 12
+	outlineCharLimit     = 20000
+	outlinePreviewLines  = 14
+)
+
+// OutlineImportConfirmedMsg is sent when a previewed outline should be created.
+type OutlineImportConfirmedMsg struct {
+	ParentID string
+	Steps    []outlineStep
+}
+
+// OutlineImportCancelledMsg is sent when the outline import is dismissed.
+type OutlineImportCancelledMsg struct{}
+
+// OutlineImportOverlay takes a pasted markdown outline and previews the
+// bead tree it will create under the selected parent.
+type OutlineImportOverlay struct {
+	parentID    string
+	parentTitle string
+	textarea    textarea.Model
+	steps       []outlineStep
+	previewing  bool
+	errorMsg    string
+}
+
+// NewOutlineImportOverlay creates the import overlay. An empty parentID
+// creates the outline's top-level items as root beads.
+func NewOutlineImportOverlay(parentID, parentTitle string) *OutlineImportOverlay {
+	ta := NewBaseTextarea(OverlayTextareaWidth(OverlayWidthWide), outlineTextareaLines)
+	ta.Placeholder = "- Epic title #label\n  - [bug] Child task P1 @alice"
+	ta.CharLimit = outlineCharLimit
+	ta.Focus()
+	return &OutlineImportOverlay{
+		parentID:    parentID,
+		parentTitle: parentTitle,
+		textarea:    ta,
+	}
+}
+
+// Init implements tea.Model.
+func (m *OutlineImportOverlay) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+// Update implements tea.Model.
+func (m *OutlineImportOverlay) Update(msg tea.Msg) (*OutlineImportOverlay, tea.Cmd) {
+	if m.previewing {
+		return m.updatePreview(msg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.Type {
+		case tea.KeyEsc:
+			// Multi-stage escape: clear text first, then cancel
+			if strings.TrimSpace(m.textarea.Value()) != "" {
+				m.textarea.SetValue("")
+				m.errorMsg = ""
+				return m, nil
+			}
+			return m, func() tea.Msg { return OutlineImportCancelledMsg{} }
+		case tea.KeyCtrlS:
+			roots, err := parseOutline(m.textarea.Value())
+			if err != nil {
+				m.errorMsg = err.Error()
+				return m, nil
+			}
+			m.errorMsg = ""
+			m.steps = flattenOutline(roots)
+			m.previewing = true
+			m.textarea.Blur()
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+func (m *OutlineImportOverlay) updatePreview(msg tea.Msg) (*OutlineImportOverlay, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.Type {
+	case tea.KeyEnter, tea.KeyCtrlS:
+		parentID, steps := m.parentID, m.steps
+		return m, func() tea.Msg {
+			return OutlineImportConfirmedMsg{ParentID: parentID, Steps: steps}
+		}
+	case tea.KeyEsc:
+		m.previewing = false
+		return m, m.textarea.Focus()
+	}
+	return m, nil
+}
+
+// View implements tea.Model.
+func (m *OutlineImportOverlay) View() string {
+	b := NewOverlayBuilder(OverlaySizeWide, 0)
+	contentWidth := b.ContentWidth()
+
+	if m.parentID != "" {
+		b.HeaderWithContext("IMPORT OUTLINE", m.parentID, m.parentTitle)
+	} else {
+		b.Header("IMPORT OUTLINE")
+		b.Line(styleStatsDim().Render("Top-level items become root beads"))
+		b.BlankLine()
+	}
+
+	if m.previewing {
+		m.renderPreview(b, contentWidth)
+		b.BlankLine()
+		b.Footer([]footerHint{
+			{"⏎", fmt.Sprintf("Create %d %s", len(m.steps), beadWord(len(m.steps)))},
+			{"esc", "Back"},
+		})
+		return b.Build()
+	}
+
+	taContainerWidth := contentWidth - 2
+	m.textarea.SetWidth(TextareaContentWidth(taContainerWidth, commentTextareaPad))
+	taView := PadTextareaView(m.textarea.View(), commentTextareaPad)
+	b.Line(styleCommentTextarea(taContainerWidth).Render(taView))
+	b.Line(styleStatsDim().Render("  Markers: [bug] [epic] …  P0-P4  #label  @assignee"))
+	if m.errorMsg != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(currentThemeWrapper().Error())
+		b.Line(errorStyle.Render("  ⚠ " + m.errorMsg))
+	}
+	b.BlankLine()
+	b.Footer([]footerHint{
+		{"^s", "Preview"},
+		{"esc", "Cancel"},
+	})
+	return b.Build()
+}
+
+// renderPreview draws the bead tree that will be created.
+func (m *OutlineImportOverlay) renderPreview(b *OverlayBuilder, contentWidth int) {
+	for i, step := range m.steps {
+		if i == outlinePreviewLines {
+			b.Line(styleStatsDim().Render(fmt.Sprintf("  … and %d more", len(m.steps)-i)))
+			break
+		}
+		item := step.Item
+		meta := fmt.Sprintf("%s P%d", item.IssueType, item.Priority)
+		for _, l := range item.Labels {
+			meta += " #" + l
+		}
+		if item.Assignee != "" {
+			meta += " @" + item.Assignee
+		}
+		prefix := strings.Repeat("  ", step.Depth) + "• "
+		titleWidth := contentWidth - lipgloss.Width(prefix) - lipgloss.Width(meta) - 2
+		if titleWidth < 10 {
+			titleWidth = 10
+		}
+		b.Line(prefix + truncateTitle(item.Title, titleWidth) + "  " + styleStatsDim().Render(meta))
+	}
+}
+
+// Layer returns a centered layer for the overlay.
+func (m *OutlineImportOverlay) Layer(width, height, topMargin, bottomMargin int) Layer {
+	return BaseOverlayLayer(m.View, width, height, topMargin, bottomMargin)
+}
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -93,6 +95,18 @@ func (m *PriorityOverlay) selectByValue(value int) tea.Cmd {
 	return nil
 }
 
+// clickOption selects and confirms the option shown on the given
+// (plain-text) overlay line.
+func (m *PriorityOverlay) clickOption(line string) tea.Cmd {
+	for i, opt := range m.options {
+		if strings.Contains(line, opt.label+"  "+opt.name) {
+			m.selected = i
+			return m.confirm()
+		}
+	}
+	return nil
+}
+
 func (m *PriorityOverlay) confirm() tea.Cmd {
 	newPriority := m.options[m.selected].value
 	issueID := m.issueID
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"strings"
+
 	"abacus/internal/domain"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -119,6 +121,21 @@ func (m *StatusOverlay) selectByValue(value string) tea.Cmd {
 	return nil
 }
 
+// clickOption selects and confirms the enabled option shown on the given
+// (plain-text) overlay line.
+func (m *StatusOverlay) clickOption(line string) tea.Cmd {
+	for i, opt := range m.options {
+		if strings.Contains(line, "○ "+opt.label) || strings.Contains(line, "● "+opt.label) {
+			if opt.disabled {
+				return nil
+			}
+			m.selected = i
+			return m.confirm()
+		}
+	}
+	return nil
+}
+
 func (m *StatusOverlay) moveDown() {
 	// Move to next enabled option
 	for i := 1; i <= len(m.options); i++ {
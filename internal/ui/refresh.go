@@ -2,10 +2,7 @@ package ui
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"io/fs"
-	"os"
 	"strings"
 	"sync"
 	"time"
@@ -83,34 +80,7 @@ func (m *App) latestDBModTime() (time.Time, error) {
 	if strings.TrimSpace(m.dbPath) == "" {
 		return time.Time{}, fmt.Errorf("database path is empty")
 	}
-	return latestModTimeForDB(m.dbPath)
-}
-
-func latestModTimeForDB(dbPath string) (time.Time, error) {
-	info, err := os.Stat(dbPath)
-	if err != nil {
-		return time.Time{}, err
-	}
-	latest := info.ModTime()
-	for _, path := range []string{dbPath + "-wal", dbPath + "-shm"} {
-		if modTime, err := optionalModTime(path); err != nil {
-			return time.Time{}, err
-		} else if modTime.After(latest) {
-			latest = modTime
-		}
-	}
-	return latest, nil
-}
-
-func optionalModTime(path string) (time.Time, error) {
-	info, err := os.Stat(path)
-	if err != nil {
-		if errors.Is(err, fs.ErrNotExist) {
-			return time.Time{}, nil
-		}
-		return time.Time{}, err
-	}
-	return info.ModTime(), nil
+	return beads.LatestModTime(m.dbPath)
 }
 
 func (m *App) applyRefresh(newRoots []*graph.Node, newDigest map[string]string, newModTime time.Time) {
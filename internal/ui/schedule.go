@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"abacus/internal/beads"
+)
+
+// dueSoonWindow is how far ahead a due date counts as "due soon".
+const dueSoonWindow = 72 * time.Hour
+
+// DueState classifies a bead's due date relative to now.
+type DueState int
+
+const (
+	DueStateNone    DueState = iota // no due date, or already closed
+	DueStateLater                   // due beyond the due-soon window
+	DueStateSoon                    // due within the due-soon window
+	DueStateOverdue                 // due date has passed
+)
+
+// scheduleDateLayouts are the accepted due/defer formats, most specific first.
+var scheduleDateLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseScheduleDate parses a due/defer value. Date-only values resolve to the
+// end of that day in local time so a bead due "today" is not yet overdue.
+func parseScheduleDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range scheduleDateLayouts {
+		if layout == "2006-01-02" {
+			if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+				return t.Add(24*time.Hour - time.Nanosecond), true
+			}
+			continue
+		}
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// issueDueState reports whether an open bead is overdue or due soon.
+func issueDueState(iss beads.FullIssue, now time.Time) DueState {
+	if iss.Status == "closed" {
+		return DueStateNone
+	}
+	due, ok := parseScheduleDate(iss.DueAt)
+	if !ok {
+		return DueStateNone
+	}
+	switch {
+	case now.After(due):
+		return DueStateOverdue
+	case due.Sub(now) <= dueSoonWindow:
+		return DueStateSoon
+	default:
+		return DueStateLater
+	}
+}
+
+// dueIndicator returns the tree glyph for beads at risk of missing their due date.
+func dueIndicator(state DueState) string {
+	switch state {
+	case DueStateOverdue:
+		return "⚠"
+	case DueStateSoon:
+		return "◷"
+	default:
+		return ""
+	}
+}
+
+// formatDueRelative renders a due date as whole days from today:
+// "today", "3d", "2w" ahead, or "-3d" when overdue.
+func formatDueRelative(due, now time.Time) string {
+	days := calendarDaysBetween(now, due, time.Local)
+	abs := days
+	if abs < 0 {
+		abs = -abs
+	}
+	var text string
+	switch {
+	case days == 0:
+		return "today"
+	case abs >= 14:
+		text = fmt.Sprintf("%dw", abs/7)
+	default:
+		text = fmt.Sprintf("%dd", abs)
+	}
+	if days < 0 {
+		return "-" + text
+	}
+	return text
+}
+
+// calendarDaysBetween counts the calendar days from from to to as seen in
+// loc, so a due date stored in UTC lands on the user's own calendar day and
+// days that are 23 or 25 hours long across a DST change still count as one.
+func calendarDaysBetween(from, to time.Time, loc *time.Location) int {
+	from, to = from.In(loc), to.In(loc)
+	// Midnights in UTC are always 24 hours apart.
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay) / (24 * time.Hour))
+}
+
+// formatScheduleDate renders a due/defer value as a local calendar date.
+func formatScheduleDate(value string) string {
+	t, ok := parseScheduleDate(value)
+	if !ok {
+		return value
+	}
+	return t.Local().Format("Jan 02, 2006")
+}
+
+// formatEstimate renders an estimate in minutes as "45m", "2h" or "1h30m".
+func formatEstimate(minutes int) string {
+	if minutes <= 0 {
+		return ""
+	}
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+}
+
+// parseEstimate accepts a bare number of minutes or a duration such as
+// "90m" or "1h30m". An empty value clears the estimate.
+func parseEstimate(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	if n, err := strconv.Atoi(value); err == nil {
+		if n < 0 {
+			return 0, fmt.Errorf("estimate must not be negative")
+		}
+		return n, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid estimate %q (use minutes or e.g. 1h30m)", value)
+	}
+	return int(d.Minutes()), nil
+}
+
+// validateScheduleDate checks a due/defer input; empty clears the field.
+func validateScheduleDate(label, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	if _, ok := parseScheduleDate(value); !ok {
+		return fmt.Errorf("invalid %s date %q (use YYYY-MM-DD)", label, value)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"abacus/internal/beads"
+	"abacus/internal/graph"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestIssueDueState(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		name string
+		iss  beads.FullIssue
+		want DueState
+	}{
+		{"noDueDate", beads.FullIssue{Status: "open"}, DueStateNone},
+		{"closedIgnored", beads.FullIssue{Status: "closed", DueAt: "2025-03-01"}, DueStateNone},
+		{"overdue", beads.FullIssue{Status: "open", DueAt: "2025-03-09"}, DueStateOverdue},
+		{"dueTodayNotOverdue", beads.FullIssue{Status: "open", DueAt: "2025-03-10"}, DueStateSoon},
+		{"dueSoonRFC3339", beads.FullIssue{Status: "in_progress", DueAt: "2025-03-12T09:00:00Z"}, DueStateSoon},
+		{"later", beads.FullIssue{Status: "open", DueAt: "2025-04-01"}, DueStateLater},
+		{"unparseable", beads.FullIssue{Status: "open", DueAt: "next week"}, DueStateNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := issueDueState(tt.iss, now); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatDueRelative(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		due  time.Time
+		want string
+	}{
+		{time.Date(2025, 3, 10, 23, 0, 0, 0, time.Local), "today"},
+		{time.Date(2025, 3, 13, 0, 0, 0, 0, time.Local), "3d"},
+		{time.Date(2025, 3, 8, 0, 0, 0, 0, time.Local), "-2d"},
+		{time.Date(2025, 4, 7, 0, 0, 0, 0, time.Local), "4w"},
+	}
+	for _, tt := range tests {
+		if got := formatDueRelative(tt.due, now); got != tt.want {
+			t.Errorf("formatDueRelative(%v) = %q, want %q", tt.due, got, tt.want)
+		}
+	}
+}
+
+func TestCalendarDaysBetween(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	tokyo := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		name     string
+		from, to time.Time
+		loc      *time.Location
+		want     int
+	}{
+		{
+			// 02:00 UTC on the 11th is still the 10th in New York.
+			name: "utc due date in a zone behind UTC",
+			from: time.Date(2025, 3, 10, 12, 0, 0, 0, newYork),
+			to:   time.Date(2025, 3, 11, 2, 0, 0, 0, time.UTC),
+			loc:  newYork,
+			want: 0,
+		},
+		{
+			// 20:00 UTC on the 10th is already the 11th in Tokyo.
+			name: "utc due date in a zone ahead of UTC",
+			from: time.Date(2025, 3, 10, 9, 0, 0, 0, tokyo),
+			to:   time.Date(2025, 3, 10, 20, 0, 0, 0, time.UTC),
+			loc:  tokyo,
+			want: 1,
+		},
+		{
+			// The night of March 9 is only 23 hours long in New York.
+			name: "spring forward",
+			from: time.Date(2025, 3, 8, 12, 0, 0, 0, newYork),
+			to:   time.Date(2025, 3, 10, 0, 30, 0, 0, newYork),
+			loc:  newYork,
+			want: 2,
+		},
+		{
+			// The night of November 2 is 25 hours long in New York.
+			name: "fall back",
+			from: time.Date(2025, 11, 2, 23, 30, 0, 0, newYork),
+			to:   time.Date(2025, 11, 1, 0, 0, 0, 0, newYork),
+			loc:  newYork,
+			want: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calendarDaysBetween(tt.from, tt.to, tt.loc); got != tt.want {
+				t.Errorf("calendarDaysBetween = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAndFormatEstimate(t *testing.T) {
+	tests := []struct {
+		input   string
+		minutes int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"45", 45, false},
+		{"90m", 90, false},
+		{"1h30m", 90, false},
+		{"-5", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseEstimate(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.minutes {
+			t.Errorf("parseEstimate(%q) = %d, %v", tt.input, got, err)
+		}
+	}
+	for minutes, want := range map[int]string{0: "", 45: "45m", 120: "2h", 90: "1h30m"} {
+		if got := formatEstimate(minutes); got != want {
+			t.Errorf("formatEstimate(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+}
+
+func TestDueFilterToken(t *testing.T) {
+	origNow := timeNow
+	timeNow = func() time.Time { return time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local) }
+	t.Cleanup(func() { timeNow = origNow })
+
+	overdue := &graph.Node{Issue: beads.FullIssue{ID: "ab-late", Title: "Ship", Status: "open", DueAt: "2025-03-01"}}
+	soon := &graph.Node{Issue: beads.FullIssue{ID: "ab-soon", Title: "Ship", Status: "open", DueAt: "2025-03-11"}}
+	none := &graph.Node{Issue: beads.FullIssue{ID: "ab-none", Title: "Ship", Status: "open"}}
+
+	tests := []struct {
+		filter string
+		node   *graph.Node
+		want   bool
+	}{
+		{"due:overdue", overdue, true},
+		{"due:overdue", soon, false},
+		{"due:soon", soon, true},
+		{"due:any", none, false},
+		{"due:overdue due:soon", soon, true},
+		{"ship due:any", overdue, true},
+	}
+	for _, tt := range tests {
+		if got := nodeMatchesFilter(tt.filter, tt.node); got != tt.want {
+			t.Errorf("nodeMatchesFilter(%q, %s) = %v, want %v", tt.filter, tt.node.Issue.ID, got, tt.want)
+		}
+	}
+}
+
+func TestEditOverlaySchedule(t *testing.T) {
+	bead := &beads.FullIssue{
+		ID: "ab-sched", Title: "Release", IssueType: "task",
+		DueAt: "2025-03-20", EstimatedMinutes: 90,
+	}
+
+	t.Run("prefillsAndTabsThroughFields", func(t *testing.T) {
+		m := NewEditOverlay(bead, CreateOverlayOptions{})
+		if got := m.dueInput.Value(); got != "2025-03-20" {
+			t.Fatalf("expected due prefilled, got %q", got)
+		}
+		if got := m.estimateInput.Value(); got != "1h30m" {
+			t.Fatalf("expected estimate prefilled, got %q", got)
+		}
+		m.focus = FocusAssignee
+		for _, want := range []CreateFocus{FocusDue, FocusDefer, FocusEstimate, FocusTitle} {
+			m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+			if m.Focus() != want {
+				t.Fatalf("expected focus %v, got %v", want, m.Focus())
+			}
+		}
+	})
+
+	t.Run("unchangedScheduleNotFlagged", func(t *testing.T) {
+		m := NewEditOverlay(bead, CreateOverlayOptions{})
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		msg, ok := cmd().(BeadUpdatedMsg)
+		if !ok {
+			t.Fatal("expected BeadUpdatedMsg")
+		}
+		if msg.ScheduleChanged {
+			t.Fatal("expected untouched schedule not to be flagged as changed")
+		}
+	})
+
+	t.Run("editedScheduleSubmitted", func(t *testing.T) {
+		m := NewEditOverlay(bead, CreateOverlayOptions{})
+		m.deferInput.SetValue("2025-03-15")
+		m.estimateInput.SetValue("2h")
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		msg := cmd().(BeadUpdatedMsg)
+		if !msg.ScheduleChanged || msg.DueAt != "2025-03-20" || msg.DeferUntil != "2025-03-15" || msg.EstimateMinutes != 120 {
+			t.Fatalf("unexpected schedule in update: %+v", msg)
+		}
+	})
+
+	t.Run("invalidDateBlocksSubmit", func(t *testing.T) {
+		m := NewEditOverlay(bead, CreateOverlayOptions{})
+		m.dueInput.SetValue("tomorrow")
+		m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if cmd != nil {
+			t.Fatal("expected no submit with invalid due date")
+		}
+		if m.scheduleError == "" {
+			t.Fatal("expected schedule validation message")
+		}
+	})
+}
+
+func TestUpdateCmdWritesScheduleOnlyWhenChanged(t *testing.T) {
+	client := beads.NewMockClient()
+	m := &App{client: client}
+
+	m.executeUpdateCmd(BeadUpdatedMsg{ID: "ab-1", Title: "T"})()
+	if client.UpdateScheduleCallCount != 0 {
+		t.Fatalf("expected no schedule write, got %d", client.UpdateScheduleCallCount)
+	}
+
+	m.executeUpdateCmd(BeadUpdatedMsg{ID: "ab-1", Title: "T", DueAt: "2025-03-20", ScheduleChanged: true})()
+	if client.UpdateScheduleCallCount != 1 || client.UpdateScheduleCallArgs[0].Due != "2025-03-20" {
+		t.Fatalf("expected schedule write, got %+v", client.UpdateScheduleCallArgs)
+	}
+}
@@ -13,14 +13,106 @@ package ui
 //   then items with fewer blockers (easier to unblock), then by priority
 // - sortBlocked: Items that will become ready first (fewest other blockers)
 //   appear first, showing what gets unblocked when this issue is completed
+//
+// The user can override these defaults with a SortMode (cycled with S). Any
+// mode other than SortModeSmart is applied uniformly to tree siblings and to
+// every relationship section, replacing the per-section heuristics above.
 
 import (
 	"sort"
+	"strings"
 	"time"
 
+	"abacus/internal/config"
 	"abacus/internal/graph"
 )
 
+// SortMode selects how sibling beads are ordered in the tree and detail pane.
+type SortMode int
+
+const (
+	SortModeSmart        SortMode = iota // Status-aware default ordering
+	SortModePriority                     // P0 first
+	SortModeUpdated                      // Most recently updated first
+	SortModeCreated                      // Oldest first
+	SortModeTitle                        // Alphabetical by title
+	SortModeAssignee                     // Grouped by assignee, unassigned last
+	SortModeBlockerDepth                 // Shortest chain of open blockers first
+	sortModeCount                        // internal: number of modes for cycling
+)
+
+// sortModeConfigValues maps each SortMode to its persisted config value.
+var sortModeConfigValues = [...]string{
+	SortModeSmart:        "smart",
+	SortModePriority:     "priority",
+	SortModeUpdated:      "updated",
+	SortModeCreated:      "created",
+	SortModeTitle:        "title",
+	SortModeAssignee:     "assignee",
+	SortModeBlockerDepth: "blocker-depth",
+}
+
+// String returns the display name of the sort mode.
+func (s SortMode) String() string {
+	switch s {
+	case SortModePriority:
+		return "Priority"
+	case SortModeUpdated:
+		return "Updated"
+	case SortModeCreated:
+		return "Created"
+	case SortModeTitle:
+		return "Title"
+	case SortModeAssignee:
+		return "Assignee"
+	case SortModeBlockerDepth:
+		return "Blocker Depth"
+	default:
+		return "Smart"
+	}
+}
+
+// ConfigValue returns the value stored in config for this sort mode.
+func (s SortMode) ConfigValue() string {
+	if s < 0 || s >= sortModeCount {
+		return sortModeConfigValues[SortModeSmart]
+	}
+	return sortModeConfigValues[s]
+}
+
+// Next returns the next sort mode in the cycle.
+func (s SortMode) Next() SortMode {
+	return SortMode((int(s) + 1) % int(sortModeCount))
+}
+
+// ParseSortMode converts a config value to a SortMode, defaulting to smart.
+func ParseSortMode(value string) SortMode {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for mode, v := range sortModeConfigValues {
+		if v == value {
+			return SortMode(mode)
+		}
+	}
+	return SortModeSmart
+}
+
+// sortConfigKey returns the config key that stores the sort mode for a view.
+func sortConfigKey(view ViewMode) string {
+	switch view {
+	case ViewModeActive:
+		return config.KeyTreeSortActive
+	case ViewModeReady:
+		return config.KeyTreeSortReady
+	default:
+		return config.KeyTreeSortAll
+	}
+}
+
+// loadSortMode reads the persisted sort mode for a view.
+func loadSortMode(view ViewMode) SortMode {
+	return ParseSortMode(config.GetString(sortConfigKey(view)))
+}
+
 // Status categories for sorting (lower = higher priority)
 const (
 	statusInProgress = 1
@@ -212,3 +304,125 @@ func sortBlocked(nodes []*graph.Node) []*graph.Node {
 
 	return result
 }
+
+// sortNodesByMode returns a sorted copy of nodes for an explicit sort mode.
+// SortModeSmart returns the input unchanged; callers keep their own default
+// ordering (graph builder order for tree siblings, section heuristics for
+// relationship lists).
+func sortNodesByMode(mode SortMode, nodes []*graph.Node) []*graph.Node {
+	if mode == SortModeSmart || len(nodes) <= 1 {
+		return nodes
+	}
+
+	result := make([]*graph.Node, len(nodes))
+	copy(result, nodes)
+
+	var depths map[string]int
+	if mode == SortModeBlockerDepth {
+		depths = make(map[string]int, len(result))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		switch mode {
+		case SortModePriority:
+			if a.Issue.Priority != b.Issue.Priority {
+				return a.Issue.Priority < b.Issue.Priority
+			}
+			if catA, catB := nodeStatusCategory(a), nodeStatusCategory(b); catA != catB {
+				return catA < catB
+			}
+		case SortModeUpdated:
+			tA := parseIssueTime(a.Issue.UpdatedAt, a.Issue.CreatedAt)
+			tB := parseIssueTime(b.Issue.UpdatedAt, b.Issue.CreatedAt)
+			if !tA.Equal(tB) {
+				return tA.After(tB) // Most recent first; unparseable (zero) last
+			}
+		case SortModeCreated:
+			tA := parseIssueTime(a.Issue.CreatedAt)
+			tB := parseIssueTime(b.Issue.CreatedAt)
+			if !tA.Equal(tB) {
+				if tA.IsZero() || tB.IsZero() {
+					return tB.IsZero() // Unparseable timestamps sort last
+				}
+				return tA.Before(tB)
+			}
+		case SortModeTitle:
+			titleA := strings.ToLower(a.Issue.Title)
+			titleB := strings.ToLower(b.Issue.Title)
+			if titleA != titleB {
+				return titleA < titleB
+			}
+		case SortModeAssignee:
+			assigneeA := strings.ToLower(strings.TrimSpace(a.Issue.Assignee))
+			assigneeB := strings.ToLower(strings.TrimSpace(b.Issue.Assignee))
+			if assigneeA != assigneeB {
+				if assigneeA == "" || assigneeB == "" {
+					return assigneeB == "" // Unassigned last
+				}
+				return assigneeA < assigneeB
+			}
+			if a.Issue.Priority != b.Issue.Priority {
+				return a.Issue.Priority < b.Issue.Priority
+			}
+		case SortModeBlockerDepth:
+			depthA := blockerDepth(a, depths, map[string]bool{})
+			depthB := blockerDepth(b, depths, map[string]bool{})
+			if depthA != depthB {
+				return depthA < depthB
+			}
+			if a.Issue.Priority != b.Issue.Priority {
+				return a.Issue.Priority < b.Issue.Priority
+			}
+		}
+		return a.Issue.ID < b.Issue.ID
+	})
+
+	return result
+}
+
+// blockerDepth returns the length of the longest chain of open blockers
+// in front of a node: 0 when nothing blocks it, 1 when its blockers are
+// themselves unblocked, and so on. Results are memoized in depths; the
+// visiting set guards against cycles in blocking dependencies.
+func blockerDepth(n *graph.Node, depths map[string]int, visiting map[string]bool) int {
+	if d, ok := depths[n.Issue.ID]; ok {
+		return d
+	}
+	if visiting[n.Issue.ID] {
+		return 0
+	}
+	visiting[n.Issue.ID] = true
+	depth := 0
+	for _, b := range n.BlockedBy {
+		if b.Issue.Status == "closed" {
+			continue
+		}
+		if d := blockerDepth(b, depths, visiting) + 1; d > depth {
+			depth = d
+		}
+	}
+	delete(visiting, n.Issue.ID)
+	depths[n.Issue.ID] = depth
+	return depth
+}
+
+// parseIssueTime returns the first parseable RFC3339 timestamp among values,
+// or zero time if none parse.
+func parseIssueTime(values ...string) time.Time {
+	for _, v := range values {
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(v)); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// sortRelationships orders a detail-pane relationship list, using the
+// section's smart heuristic unless an explicit sort mode is active.
+func (m *App) sortRelationships(nodes []*graph.Node, smart func([]*graph.Node) []*graph.Node) []*graph.Node {
+	if m.sortMode == SortModeSmart {
+		return smart(nodes)
+	}
+	return sortNodesByMode(m.sortMode, nodes)
+}
@@ -304,3 +304,91 @@ func TestSortBlocked(t *testing.T) {
 		}
 	})
 }
+
+func TestSortNodesByMode(t *testing.T) {
+	alpha := &graph.Node{Issue: beads.FullIssue{
+		ID: "ab-a", Title: "Zebra", Status: "open", Priority: 2, Assignee: "bob",
+		CreatedAt: "2025-01-03T00:00:00Z", UpdatedAt: "2025-01-03T00:00:00Z",
+	}}
+	beta := &graph.Node{Issue: beads.FullIssue{
+		ID: "ab-b", Title: "apple", Status: "open", Priority: 0,
+		CreatedAt: "2025-01-01T00:00:00Z", UpdatedAt: "2025-01-05T00:00:00Z",
+	}}
+	gamma := &graph.Node{Issue: beads.FullIssue{
+		ID: "ab-c", Title: "Mango", Status: "open", Priority: 1, Assignee: "alice",
+		CreatedAt: "2025-01-02T00:00:00Z", UpdatedAt: "2025-01-04T00:00:00Z",
+	}}
+	input := []*graph.Node{alpha, beta, gamma}
+
+	cases := []struct {
+		mode     SortMode
+		expected []string
+	}{
+		{SortModeSmart, []string{"ab-a", "ab-b", "ab-c"}},
+		{SortModePriority, []string{"ab-b", "ab-c", "ab-a"}},
+		{SortModeUpdated, []string{"ab-b", "ab-c", "ab-a"}},
+		{SortModeCreated, []string{"ab-b", "ab-c", "ab-a"}},
+		{SortModeTitle, []string{"ab-b", "ab-c", "ab-a"}},
+		{SortModeAssignee, []string{"ab-c", "ab-a", "ab-b"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.mode.String(), func(t *testing.T) {
+			result := sortNodesByMode(tc.mode, input)
+			for i, id := range tc.expected {
+				if result[i].Issue.ID != id {
+					t.Fatalf("position %d: expected %s, got %s", i, id, result[i].Issue.ID)
+				}
+			}
+		})
+	}
+
+	t.Run("doesNotMutateInput", func(t *testing.T) {
+		_ = sortNodesByMode(SortModeTitle, input)
+		if input[0] != alpha || input[1] != beta || input[2] != gamma {
+			t.Fatal("expected input slice order to be preserved")
+		}
+	})
+}
+
+func TestSortNodesByBlockerDepth(t *testing.T) {
+	root := &graph.Node{Issue: beads.FullIssue{ID: "ab-root", Status: "open"}}
+	mid := &graph.Node{Issue: beads.FullIssue{ID: "ab-mid", Status: "open"}, BlockedBy: []*graph.Node{root}}
+	leaf := &graph.Node{Issue: beads.FullIssue{ID: "ab-leaf", Status: "open"}, BlockedBy: []*graph.Node{mid}}
+	done := &graph.Node{Issue: beads.FullIssue{ID: "ab-done", Status: "closed"}}
+	unblocked := &graph.Node{Issue: beads.FullIssue{ID: "ab-free", Status: "open", Priority: 1}, BlockedBy: []*graph.Node{done}}
+
+	result := sortNodesByMode(SortModeBlockerDepth, []*graph.Node{leaf, mid, unblocked, root})
+
+	expected := []string{"ab-root", "ab-free", "ab-mid", "ab-leaf"}
+	for i, id := range expected {
+		if result[i].Issue.ID != id {
+			t.Fatalf("position %d: expected %s, got %s", i, id, result[i].Issue.ID)
+		}
+	}
+
+	t.Run("cycleTerminates", func(t *testing.T) {
+		a := &graph.Node{Issue: beads.FullIssue{ID: "ab-x", Status: "open"}}
+		b := &graph.Node{Issue: beads.FullIssue{ID: "ab-y", Status: "open"}, BlockedBy: []*graph.Node{a}}
+		a.BlockedBy = []*graph.Node{b}
+		if got := sortNodesByMode(SortModeBlockerDepth, []*graph.Node{a, b}); len(got) != 2 {
+			t.Fatalf("expected 2 nodes, got %d", len(got))
+		}
+	})
+}
+
+func TestParseSortMode(t *testing.T) {
+	for mode := SortModeSmart; mode < sortModeCount; mode++ {
+		if got := ParseSortMode(mode.ConfigValue()); got != mode {
+			t.Errorf("round-trip %q: expected %v, got %v", mode.ConfigValue(), mode, got)
+		}
+	}
+	if got := ParseSortMode(" Priority "); got != SortModePriority {
+		t.Errorf("expected case-insensitive parse, got %v", got)
+	}
+	if got := ParseSortMode("bogus"); got != SortModeSmart {
+		t.Errorf("expected unknown value to fall back to smart, got %v", got)
+	}
+	if got := SortModeBlockerDepth.Next(); got != SortModeSmart {
+		t.Errorf("expected Next to wrap to smart, got %v", got)
+	}
+}
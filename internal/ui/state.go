@@ -63,6 +63,14 @@ func (m *App) recalcVisibleRows() {
 	filterLower := strings.ToLower(m.filterText)
 	filterActive := m.isFilterActive()
 
+	if m.groupMode != GroupModeNone {
+		m.filterEval = nil
+		m.recalcGroupedRows(filterLower)
+		m.clampCursor()
+		return
+	}
+	m.groupMemberships = nil
+
 	if filterActive {
 		m.filterEval = m.computeFilterEval(filterLower)
 	} else {
@@ -99,12 +107,12 @@ func (m *App) recalcVisibleRows() {
 					expanded = m.shouldExpandFilteredRow(row, hasMatchingChild)
 				}
 				if expanded {
-					traverse(node.Children, node, depth+1)
+					traverse(sortNodesByMode(m.sortMode, node.Children), node, depth+1)
 				}
 			}
 		}
 	}
-	traverse(m.roots, nil, 0)
+	traverse(sortNodesByMode(m.sortMode, m.roots), nil, 0)
 	m.clampCursor()
 }
 
@@ -31,14 +31,17 @@ func (m *App) isRowExpandedForTraversal(row graph.TreeRow) bool {
 }
 
 func (m *App) isFilterActive() bool {
-	return m.filterText != "" || m.viewMode != ViewModeAll
+	return m.filterText != "" || m.viewMode != ViewModeAll || m.myWorkOnly
 }
 
 func (m *App) isNodeExpandedInView(row graph.TreeRow) bool {
 	node := row.Node
-	if len(node.Children) == 0 {
+	if !m.rowHasExpandableChildren(row) {
 		return false
 	}
+	if isGroupNode(node) {
+		return !m.collapsedGroups[node.Issue.ID]
+	}
 
 	if !m.isFilterActive() {
 		return m.isRowExpandedForTraversal(row)
@@ -105,6 +108,10 @@ func copyBoolMapAll(src map[string]bool) map[string]bool {
 
 func (m *App) expandNodeForView(row graph.TreeRow) {
 	node := row.Node
+	if isGroupNode(node) {
+		m.setGroupExpanded(node.Issue.ID, true)
+		return
+	}
 	key := treeRowStateKey(row)
 
 	// Track per-instance state for multi-parent nodes
@@ -135,6 +142,10 @@ func (m *App) expandNodeForView(row graph.TreeRow) {
 
 func (m *App) collapseNodeForView(row graph.TreeRow) {
 	node := row.Node
+	if isGroupNode(node) {
+		m.setGroupExpanded(node.Issue.ID, false)
+		return
+	}
 	key := treeRowStateKey(row)
 
 	// Track per-instance state for multi-parent nodes
@@ -7,7 +7,111 @@ import (
 	"abacus/internal/graph"
 )
 
+// Filter tokens narrow the search beyond title/ID text, e.g. "assignee:me",
+// "assignee:alice", "due:overdue", "due:soon", "ref:any" or "ref:none".
+const (
+	assigneeFilterPrefix = "assignee:"
+	dueFilterPrefix      = "due:"
+	refFilterPrefix      = "ref:"
+)
+
+// filterQuery is a lowercased search filter split into free text and tokens.
+type filterQuery struct {
+	text      string
+	assignees []string
+	due       []string
+	refs      []string
+}
+
+// parseFilterQuery separates filter tokens from the free-text part of a
+// lowercased filter string.
+func parseFilterQuery(filterLower string) filterQuery {
+	if !strings.Contains(filterLower, assigneeFilterPrefix) && !strings.Contains(filterLower, dueFilterPrefix) &&
+		!strings.Contains(filterLower, refFilterPrefix) {
+		return filterQuery{text: filterLower}
+	}
+	var q filterQuery
+	var words []string
+	for _, field := range strings.Fields(filterLower) {
+		if name, ok := strings.CutPrefix(field, assigneeFilterPrefix); ok && name != "" {
+			q.assignees = append(q.assignees, name)
+			continue
+		}
+		if state, ok := strings.CutPrefix(field, dueFilterPrefix); ok && state != "" {
+			q.due = append(q.due, state)
+			continue
+		}
+		if ref, ok := strings.CutPrefix(field, refFilterPrefix); ok && ref != "" {
+			q.refs = append(q.refs, ref)
+			continue
+		}
+		words = append(words, field)
+	}
+	q.text = strings.Join(words, " ")
+	return q
+}
+
+// nodeMatchesAssignee reports whether the node is assigned to name, where
+// "me" matches the configured identity and its aliases.
+func nodeMatchesAssignee(name string, node *graph.Node) bool {
+	if name == "me" {
+		return isAssignedToMe(node)
+	}
+	return strings.EqualFold(strings.TrimSpace(node.Issue.Assignee), name)
+}
+
+// nodeMatchesDue reports whether the node's due state matches a due: token
+// ("overdue", "soon", or "any" for any due date).
+func nodeMatchesDue(state string, node *graph.Node) bool {
+	due := issueDueState(node.Issue, timeNow())
+	switch state {
+	case "overdue":
+		return due == DueStateOverdue
+	case "soon":
+		return due == DueStateSoon
+	case "any":
+		return strings.TrimSpace(node.Issue.DueAt) != ""
+	default:
+		return false
+	}
+}
+
+// nodeMatchesRef reports whether the node's external ref matches a ref:
+// token: "any" for beads with a ref, "none" for beads without one, and
+// anything else as a case-insensitive substring of the ref.
+func nodeMatchesRef(token string, node *graph.Node) bool {
+	ref := strings.TrimSpace(node.Issue.ExternalRef)
+	switch token {
+	case "any":
+		return ref != ""
+	case "none":
+		return ref == ""
+	default:
+		return strings.Contains(strings.ToLower(ref), token)
+	}
+}
+
+// matchesAny reports whether match holds for at least one token; an empty
+// token list matches everything.
+func matchesAny(tokens []string, node *graph.Node, match func(string, *graph.Node) bool) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	for _, token := range tokens {
+		if match(token, node) {
+			return true
+		}
+	}
+	return false
+}
+
 func nodeMatchesFilter(filterLower string, node *graph.Node) bool {
+	q := parseFilterQuery(filterLower)
+	if !matchesAny(q.assignees, node, nodeMatchesAssignee) || !matchesAny(q.due, node, nodeMatchesDue) ||
+		!matchesAny(q.refs, node, nodeMatchesRef) {
+		return false
+	}
+	filterLower = q.text
 	if filterLower == "" {
 		return true
 	}
@@ -66,10 +170,11 @@ func (m *App) computeFilterEval(filterLower string) map[string]filterEvaluation
 	evals := make(map[string]filterEvaluation)
 	var walk func(node *graph.Node) bool
 	walk = func(node *graph.Node) bool {
-		// Check BOTH ViewMode AND text filter
+		// Check ViewMode, my-work AND text filters
 		viewModeMatch := nodeMatchesViewMode(m.viewMode, node)
 		textMatch := nodeMatchesFilter(filterLower, node)
-		directMatch := viewModeMatch && textMatch // Node itself matches both filters
+		myWorkMatch := !m.myWorkOnly || isAssignedToMe(node)
+		directMatch := viewModeMatch && textMatch && myWorkMatch // Node itself matches all filters
 
 		hasChildMatch := false
 		for _, child := range node.Children {
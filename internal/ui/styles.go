@@ -124,6 +124,20 @@ func styleID() lipgloss.Style {
 	return applyBold(style, false)
 }
 
+// styleMyID highlights the ID of beads assigned to the current user.
+func styleMyID() lipgloss.Style {
+	style := baseStyle().Foreground(currentThemeWrapper().Success()).Underline(true)
+	return applyBold(style, false)
+}
+
+// styleDueIndicator colors the overdue / due-soon glyph in tree rows.
+func styleDueIndicator(state DueState) lipgloss.Style {
+	if state == DueStateOverdue {
+		return applyBold(baseStyle().Foreground(currentThemeWrapper().Error()), false)
+	}
+	return baseStyle().Foreground(currentThemeWrapper().Warning())
+}
+
 // App header styles
 
 func styleAppHeader() lipgloss.Style {
@@ -207,6 +221,10 @@ func styleCommentHeader() lipgloss.Style {
 	return applyBold(style, false)
 }
 
+func styleCommentSelectBar() lipgloss.Style {
+	return baseStyle().Foreground(currentThemeWrapper().Accent())
+}
+
 // Toast styles
 
 func styleErrorToast() lipgloss.Style {
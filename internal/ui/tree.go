@@ -6,6 +6,7 @@ import (
 
 	"abacus/internal/config"
 	"abacus/internal/domain"
+	"abacus/internal/graph"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -77,9 +78,19 @@ func (m *App) buildTreeLines(totalWidth int) ([]string, int, int) {
 
 	for i, row := range m.visibleRows {
 		node := row.Node
+		if isGroupNode(node) {
+			if i == m.cursor {
+				cursorStart = len(lines)
+			}
+			lines = append(lines, m.buildGroupHeaderRow(row, i == m.cursor, totalWidth))
+			if i == m.cursor {
+				cursorEnd = len(lines)
+			}
+			continue
+		}
 		indent := strings.Repeat("  ", row.Depth)
 		marker := " •"
-		if len(node.Children) > 0 {
+		if m.rowHasExpandableChildren(row) {
 			if m.isNodeExpandedInView(row) {
 				marker = " ▼"
 			} else {
@@ -113,19 +124,40 @@ func (m *App) buildTreeLines(totalWidth int) ([]string, int, int) {
 
 		// Add * indicator for multi-parent items
 		idDisplay := node.Issue.ID
-		if row.HasMultipleParents() {
+		if m.rowIsDuplicated(row) {
 			idDisplay = node.Issue.ID + "*"
 		}
 
 		// Format priority (e.g., "P2") or empty string if not shown
 		priorityStr := formatPriority(node.Issue.Priority, showPriority)
 
+		// Overdue / due-soon glyph shown ahead of the title
+		dueState := issueDueState(node.Issue, timeNow())
+		dueMark := dueIndicator(dueState)
+
+		// Configured labels (labels.styles) shown as icons/dots after the due glyph
+		labelMarks := treeLabelMarks(node.Issue.Labels)
+		plainMarks := plainLabelMarks(labelMarks)
+
 		totalPrefixWidth := treePrefixWidth(indent, marker, iconStr, priorityStr, idDisplay)
+		if dueMark != "" {
+			totalPrefixWidth += lipgloss.Width(dueMark) + 1
+		}
+		if plainMarks != "" {
+			totalPrefixWidth += lipgloss.Width(plainMarks) + 1
+		}
 		availableWidth := treeWidth - totalPrefixWidth
 		if availableWidth < 1 {
 			availableWidth = 1
 		}
 		titleLines := []string{truncateWithEllipsis(node.Issue.Title, availableWidth)}
+		markedTitle := titleLines[0]
+		if plainMarks != "" {
+			markedTitle = plainMarks + " " + markedTitle
+		}
+		if dueMark != "" {
+			markedTitle = dueMark + " " + markedTitle
+		}
 
 		// Cross-highlighting: same node appears under multiple parents
 		isCrossHighlight := i != m.cursor && node.Issue.ID == selectedID
@@ -143,7 +175,7 @@ func (m *App) buildTreeLines(totalWidth int) ([]string, int, int) {
 				iconStyle,
 				priorityStr,
 				idDisplay,
-				titleLines[0],
+				markedTitle,
 				textStyle,
 				treeWidth,
 				totalWidth,
@@ -160,7 +192,7 @@ func (m *App) buildTreeLines(totalWidth int) ([]string, int, int) {
 				iconStyle,
 				priorityStr,
 				idDisplay,
-				titleLines[0],
+				markedTitle,
 				textStyle,
 				treeWidth,
 				totalWidth,
@@ -174,7 +206,18 @@ func (m *App) buildTreeLines(totalWidth int) ([]string, int, int) {
 			if priorityStr != "" {
 				line1 += stylePriority().Render(priorityStr) + sp
 			}
-			line1 += styleID().Render(idDisplay) + sp + textStyle.Render(titleLines[0])
+			idStyle := styleID()
+			if isAssignedToMe(node) {
+				idStyle = styleMyID()
+			}
+			line1 += idStyle.Render(idDisplay) + sp
+			if dueMark != "" {
+				line1 += styleDueIndicator(dueState).Render(dueMark) + sp
+			}
+			if len(labelMarks) > 0 {
+				line1 += renderLabelMarks(labelMarks) + sp
+			}
+			line1 += textStyle.Render(titleLines[0])
 			if showColumns {
 				// Pad tree content to treeWidth so columns align vertically
 				line1 = padToWidth(line1, treeWidth, styleNormalText())
@@ -327,6 +370,31 @@ func buildSelectedRow(indent, marker, icon string, iconStyle lipgloss.Style, pri
 		Render(treeContent)
 }
 
+// buildGroupHeaderRow renders a group header in group-by mode: expand marker,
+// group label and member count, full width when selected.
+func (m *App) buildGroupHeaderRow(row graph.TreeRow, selected bool, totalWidth int) string {
+	marker := " ▶"
+	if m.isNodeExpandedInView(row) {
+		marker = " ▼"
+	}
+	label := truncateWithEllipsis(row.Node.Issue.Title, totalWidth-lipgloss.Width(marker)-8)
+	count := fmt.Sprintf("(%d)", len(row.Node.Children))
+
+	if selected {
+		t := currentThemeWrapper()
+		selectedBase := lipgloss.NewStyle().Background(t.BackgroundSecondary())
+		content := selectedBase.Bold(true).Foreground(t.Primary()).Render(" "+marker+" ") +
+			selectedBase.Bold(true).Foreground(t.Accent()).Render(label) +
+			selectedBase.Render(" ") +
+			selectedBase.Foreground(t.TextMuted()).Render(count)
+		return selectedBase.Width(totalWidth).Render(content)
+	}
+
+	sp := styleNormalText().Render(" ")
+	return styleNormalText().Render(" ") + styleIconOpen().Render(marker) + sp +
+		styleSectionHeader().Render(label) + sp + styleStatsDim().Render(count)
+}
+
 // buildCrossHighlightRow creates a full-width row with cross-highlight background.
 // treeWidth is the width for the tree portion (before columns), totalWidth is the full row width.
 func buildCrossHighlightRow(indent, marker, icon string, iconStyle lipgloss.Style, priority, id, title string, textStyle lipgloss.Style, treeWidth, totalWidth int, columns string) string {
@@ -37,6 +37,11 @@ var defaultTreeColumns = []treeColumn{
 		Width:     5,
 		Render:    renderCommentsColumn,
 	},
+	{
+		ConfigKey: config.KeyTreeColumnsDue,
+		Width:     6,
+		Render:    renderDueColumn,
+	},
 }
 
 type columnState struct {
@@ -179,6 +184,17 @@ func renderCommentsColumn(node *graph.Node) string {
 	}
 }
 
+func renderDueColumn(node *graph.Node) string {
+	if node == nil || node.Issue.Status == "closed" {
+		return ""
+	}
+	due, ok := parseScheduleDate(node.Issue.DueAt)
+	if !ok {
+		return ""
+	}
+	return formatDueRelative(due, timeNow())
+}
+
 func renderAssigneeColumn(node *graph.Node) string {
 	if node == nil || node.Issue.Assignee == "" {
 		return ""
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -15,14 +16,24 @@ func (m *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return model, cmd
 	}
 
+	// Retry queue for failed writes
+	if model, cmd, handled := m.handleMutationMsg(msg); handled {
+		return model, cmd
+	}
+
 	// Background and system messages
 	if model, cmd, handled := m.handleBackgroundMsg(msg); handled {
 		return model, cmd
 	}
 
-	// Key messages
+	// Key and mouse messages may move the selection, so load its history
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
-		return m.handleKeyMsg(keyMsg)
+		model, cmd := m.handleKeyMsg(keyMsg)
+		return model, tea.Batch(cmd, m.loadSelectedHistory())
+	}
+	if mouseMsg, ok := msg.(tea.MouseMsg); ok {
+		model, cmd := m.handleMouseMsg(mouseMsg)
+		return model, tea.Batch(cmd, m.loadSelectedHistory())
 	}
 
 	// Viewport updates when detail pane is focused
@@ -99,7 +110,8 @@ func (m *App) handleBackgroundMsg(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 		if modTime, err := m.latestDBModTime(); err == nil && !modTime.IsZero() {
 			m.lastDBModTime = modTime
 		}
-		return m, scheduleBackgroundCommentLoad(), true
+		m.history = nil
+		return m, tea.Batch(scheduleBackgroundCommentLoad(), m.loadGitRefs(), m.loadSelectedHistory()), true
 
 	case eventualRefreshMsg:
 		if m.activeOverlay != OverlayCreate {
@@ -127,6 +139,62 @@ func (m *App) handleBackgroundMsg(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 		}
 		return m, scheduleCopyToastTick(), true
 
+	case linkOpenedMsg:
+		if msg.err != nil {
+			m.lastError = fmt.Sprintf("Failed to open %s: %v", msg.url, msg.err)
+			m.lastErrorSource = errorSourceOperation
+			m.showErrorToast = true
+			m.errorToastStart = time.Now()
+			return m, scheduleErrorToastTick(), true
+		}
+		m.linkToastURL = msg.url
+		m.linkToastVisible = true
+		m.linkToastStart = time.Now()
+		return m, scheduleLinkToastTick(), true
+
+	case gitRefsLoadedMsg:
+		m.applyGitRefs(msg)
+		m.updateViewportContent()
+		return m, nil, true
+
+	case historyLoadedMsg:
+		m.applyHistory(msg)
+		m.updateViewportContent()
+		return m, nil, true
+
+	case branchCreatedMsg:
+		if msg.err != nil {
+			m.lastError = fmt.Sprintf("Failed to create branch %s: %v", msg.name, msg.err)
+			m.lastErrorSource = errorSourceOperation
+			m.showErrorToast = true
+			m.errorToastStart = time.Now()
+			return m, scheduleErrorToastTick(), true
+		}
+		m.branchToastName = msg.name
+		m.branchToastVisible = true
+		m.branchToastStart = time.Now()
+		return m, tea.Batch(scheduleBranchToastTick(), m.loadGitRefs()), true
+
+	case branchToastTickMsg:
+		if !m.branchToastVisible {
+			return m, nil, true
+		}
+		if time.Since(m.branchToastStart) >= 3*time.Second {
+			m.branchToastVisible = false
+			return m, nil, true
+		}
+		return m, scheduleBranchToastTick(), true
+
+	case linkToastTickMsg:
+		if !m.linkToastVisible {
+			return m, nil, true
+		}
+		if time.Since(m.linkToastStart) >= 3*time.Second {
+			m.linkToastVisible = false
+			return m, nil, true
+		}
+		return m, scheduleLinkToastTick(), true
+
 	case updateAvailableMsg:
 		if msg.info != nil && msg.info.UpdateAvailable {
 			m.updateInfo = msg.info
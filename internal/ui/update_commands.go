@@ -2,9 +2,11 @@ package ui
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"time"
 
+	"abacus/internal/beads"
 	"abacus/internal/graph"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -22,22 +24,16 @@ func scheduleStatusToastTick() tea.Cmd {
 
 // executeStatusChangeCmd runs the bd update command asynchronously without toast.
 func (m *App) executeStatusChangeCmd(issueID, newStatus string) tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), statusCommandTimeout)
-		defer cancel()
-		err := m.client.UpdateStatus(ctx, issueID, newStatus)
+	return m.executeMutations(func(err error) tea.Msg {
 		return statusUpdateCompleteMsg{err: err}
-	}
+	}, pendingMutation{Kind: mutationStatus, IssueID: issueID, Value: newStatus})
 }
 
 // executeReopenCmd runs the bd reopen command asynchronously.
 func (m *App) executeReopenCmd(issueID string) tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), statusCommandTimeout)
-		defer cancel()
-		err := m.client.Reopen(ctx, issueID)
+	return m.executeMutations(func(err error) tea.Msg {
 		return statusUpdateCompleteMsg{err: err}
-	}
+	}, pendingMutation{Kind: mutationReopen, IssueID: issueID})
 }
 
 // displayStatusToast displays a success toast for status changes.
@@ -116,22 +112,43 @@ func (m *App) getAllAssignees() []string {
 	return assignees
 }
 
-// executeLabelsUpdate runs the bd label add/remove commands asynchronously.
+// executeLabelsUpdate applies label additions and removals asynchronously as
+// one batch, so a failure leaves the bead's labels as they were. If the batch
+// failed because the backend was busy, the whole change is queued for retry.
 func (m *App) executeLabelsUpdate(msg LabelsUpdatedMsg) tea.Cmd {
+	batch := beads.NewBatch()
+	var writes []pendingMutation
+	for _, label := range msg.Added {
+		batch.AddLabel(msg.IssueID, label)
+		writes = append(writes, pendingMutation{Kind: mutationAddLabel, IssueID: msg.IssueID, Value: label})
+	}
+	for _, label := range msg.Removed {
+		batch.RemoveLabel(msg.IssueID, label)
+		writes = append(writes, pendingMutation{Kind: mutationRemoveLabel, IssueID: msg.IssueID, Value: label})
+	}
+	if len(writes) > 0 && m.mutations.hasIssue(msg.IssueID) {
+		return func() tea.Msg { return mutationQueuedMsg{writes: writes} }
+	}
 	return func() tea.Msg {
-		ctx := context.Background()
-		for _, label := range msg.Added {
-			if err := m.client.AddLabel(ctx, msg.IssueID, label); err != nil {
-				return labelUpdateCompleteMsg{err: err}
+		res := batch.Apply(context.Background(), m.client)
+		if failed, ok := res.Failed(); ok && beads.IsRetryable(failed.Err) && !beads.IsTimeout(failed.Err) {
+			if fullyUndone(res) {
+				return mutationQueuedMsg{writes: writes, cause: failed.Err}
 			}
 		}
-		for _, label := range msg.Removed {
-			if err := m.client.RemoveLabel(ctx, msg.IssueID, label); err != nil {
-				return labelUpdateCompleteMsg{err: err}
-			}
+		return labelUpdateCompleteMsg{err: res.Err}
+	}
+}
+
+// fullyUndone reports whether a failed batch left nothing behind, so it can
+// be replayed from the start.
+func fullyUndone(res beads.BatchResult) bool {
+	for _, op := range res.Ops {
+		if op.UndoErr != nil {
+			return false
 		}
-		return labelUpdateCompleteMsg{err: nil}
 	}
+	return true
 }
 
 // displayLabelsToast displays a success toast for label changes.
@@ -159,6 +176,7 @@ func (m *App) getAvailableParents() []ParentOption {
 			parents = append(parents, ParentOption{
 				ID:      n.Issue.ID,
 				Display: display,
+				Title:   n.Issue.Title,
 			})
 			collectParents(n.Children)
 		}
@@ -200,31 +218,60 @@ func (m *App) validateUpdate(msg BeadUpdatedMsg) error {
 	return nil
 }
 
-// executeUpdateCmd runs the bd update command asynchronously.
+// executeUpdateCmd applies an edit asynchronously as one batch, so a failed
+// schedule or parent change rolls the whole edit back instead of leaving the
+// bead half-updated (or without a parent).
+//
+// When the edit carries a base snapshot, the bead is read again first. If
+// someone else saved it since the form opened and changed fields the edit
+// would overwrite, an editConflictMsg is returned instead of saving.
 func (m *App) executeUpdateCmd(msg BeadUpdatedMsg) tea.Cmd {
+	before := beads.FullIssue{ID: msg.ID, Title: msg.Title, IssueType: msg.IssueType, Priority: msg.Priority,
+		Labels: msg.Labels, Assignee: msg.Assignee, Description: msg.Description,
+		DueAt: msg.DueAt, DeferUntil: msg.DeferUntil, EstimatedMinutes: msg.EstimateMinutes}
+	if node := m.findNodeByID(msg.ID); node != nil {
+		before = node.Issue
+	}
+
 	return func() tea.Msg {
 		ctx := context.Background()
-		if err := m.client.UpdateFull(ctx, msg.ID, msg.Title, msg.IssueType, msg.Priority, msg.Labels, msg.Assignee, msg.Description); err != nil {
-			return updateCompleteMsg{ID: msg.ID, Title: msg.Title, Err: err}
-		}
-
-		if msg.ParentID != msg.OriginalParentID {
-			if msg.OriginalParentID != "" {
-				if err := m.client.RemoveDependency(ctx, msg.ID, msg.OriginalParentID, "parent-child"); err != nil {
-					return updateCompleteMsg{ID: msg.ID, Title: msg.Title, Err: err}
-				}
+		if msg.Base.UpdatedAt != "" {
+			current, err := m.showIssue(ctx, msg.ID)
+			if err != nil {
+				return updateCompleteMsg{ID: msg.ID, Title: msg.Title, Err: err}
 			}
-			if msg.ParentID != "" {
-				if err := m.client.AddDependency(ctx, msg.ID, msg.ParentID, "parent-child"); err != nil {
-					return updateCompleteMsg{ID: msg.ID, Title: msg.Title, Err: err}
+			if current.UpdatedAt != msg.Base.UpdatedAt {
+				if conflict := newEditConflict(msg, current); len(conflict.fields) > 0 {
+					return editConflictMsg{conflict: conflict}
 				}
 			}
+			before = current
 		}
 
-		return updateCompleteMsg{ID: msg.ID, Title: msg.Title, Err: nil}
+		batch := beads.NewBatch().
+			UpdateFull(before, msg.Title, msg.IssueType, msg.Priority, msg.Labels, msg.Assignee, msg.Description)
+		if msg.ScheduleChanged {
+			batch.UpdateSchedule(before, msg.DueAt, msg.DeferUntil, msg.EstimateMinutes)
+		}
+		batch.SetParent(msg.ID, msg.OriginalParentID, msg.ParentID)
+
+		res := batch.Apply(ctx, m.client)
+		return updateCompleteMsg{ID: msg.ID, Title: msg.Title, Err: res.Err}
 	}
 }
 
+// showIssue reads one bead from the backend.
+func (m *App) showIssue(ctx context.Context, id string) (beads.FullIssue, error) {
+	issues, err := m.client.Show(ctx, []string{id})
+	if err != nil {
+		return beads.FullIssue{}, fmt.Errorf("re-read %s: %w", id, err)
+	}
+	if len(issues) == 0 {
+		return beads.FullIssue{}, fmt.Errorf("re-read %s: %w", id, beads.ErrNotFound)
+	}
+	return issues[0], nil
+}
+
 // displayCreateToast displays a success toast for bead creation.
 func (m *App) displayCreateToast(title string, isUpdate bool) {
 	m.createToastTitle = title
@@ -256,10 +303,9 @@ func scheduleDeleteToastTick() tea.Cmd {
 // executeDelete runs the bd delete command asynchronously and shows toast.
 func (m *App) executeDelete(issueID string, cascade bool, childIDs []string) tea.Cmd {
 	m.displayDeleteToast(issueID, cascade, len(childIDs))
-	return func() tea.Msg {
-		err := m.client.Delete(context.Background(), issueID, cascade)
+	return m.executeMutations(func(err error) tea.Msg {
 		return deleteCompleteMsg{issueID: issueID, children: childIDs, cascade: cascade, err: err}
-	}
+	}, pendingMutation{Kind: mutationDelete, IssueID: issueID, Cascade: cascade})
 }
 
 // displayDeleteToast displays a success toast for deletion.
@@ -279,19 +325,90 @@ func scheduleCommentToastTick() tea.Cmd {
 
 // executeAddComment runs the bd comments add command asynchronously.
 func (m *App) executeAddComment(msg CommentAddedMsg) tea.Cmd {
+	return m.executeMutations(func(err error) tea.Msg {
+		return commentCompleteMsg{issueID: msg.IssueID, action: "added", err: err}
+	}, pendingMutation{Kind: mutationAddComment, IssueID: msg.IssueID, Value: msg.Comment})
+}
+
+// executeUpdateComment rewrites an existing comment asynchronously.
+func (m *App) executeUpdateComment(msg CommentEditedMsg) tea.Cmd {
+	return func() tea.Msg {
+		err := m.client.UpdateComment(context.Background(), msg.IssueID, msg.CommentID, msg.Comment)
+		return commentCompleteMsg{issueID: msg.IssueID, action: "updated", err: err}
+	}
+}
+
+// executeDeleteComment removes a comment asynchronously.
+func (m *App) executeDeleteComment(msg CommentDeleteConfirmedMsg) tea.Cmd {
 	return func() tea.Msg {
-		err := m.client.AddComment(context.Background(), msg.IssueID, msg.Comment)
-		return commentCompleteMsg{issueID: msg.IssueID, err: err}
+		err := m.client.DeleteComment(context.Background(), msg.IssueID, msg.CommentID)
+		return commentCompleteMsg{issueID: msg.IssueID, action: "deleted", err: err}
 	}
 }
 
-// displayCommentToast displays a success toast for comment addition.
-func (m *App) displayCommentToast(issueID string) {
+// displayCommentToast displays a success toast for a comment change.
+func (m *App) displayCommentToast(issueID, action string) {
 	m.commentToastBeadID = issueID
+	m.commentToastAction = action
 	m.commentToastVisible = true
 	m.commentToastStart = time.Now()
 }
 
+// executeLabelStep applies one bead's share of a project-wide label change.
+// The new label is added before the old one is removed, in one batch, so a
+// failed step leaves the bead with its original label and can be retried.
+func (m *App) executeLabelStep(plan LabelChangePlan, index int) tea.Cmd {
+	step := plan.Steps[index]
+	batch := beads.NewBatch()
+	if step.Add != "" {
+		batch.AddLabel(step.IssueID, step.Add)
+	}
+	batch.RemoveLabel(step.IssueID, step.Remove)
+	return func() tea.Msg {
+		res := batch.Apply(context.Background(), m.client)
+		return labelStepCompleteMsg{index: index, err: res.Err}
+	}
+}
+
+// executeOutlineStep creates one bead of an outline import under the bead
+// created for its parent item (or the import target for top-level items).
+func (m *App) executeOutlineStep(job *outlineJob, index int) tea.Cmd {
+	item := job.steps[index].Item
+	parentID := job.parentFor(index)
+	return func() tea.Msg {
+		issue, err := m.client.CreateFull(context.Background(), item.Title, item.IssueType, item.Priority,
+			item.Labels, item.Assignee, "", parentID)
+		return outlineStepCompleteMsg{index: index, id: issue.ID, err: err}
+	}
+}
+
+// executeOutlineRollback deletes the beads created by a failed outline import,
+// children first. Every bead is attempted; the first error is reported.
+func (m *App) executeOutlineRollback(ids []string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var firstErr error
+		for i := len(ids) - 1; i >= 0; i-- {
+			if err := m.client.Delete(ctx, ids[i], false); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("delete %s: %w", ids[i], err)
+			}
+		}
+		return outlineRollbackCompleteMsg{err: firstErr}
+	}
+}
+
+func scheduleOutlineJobToastTick() tea.Cmd {
+	return tea.Tick(200*time.Millisecond, func(_ time.Time) tea.Msg {
+		return outlineJobToastTickMsg{}
+	})
+}
+
+func scheduleLabelJobToastTick() tea.Cmd {
+	return tea.Tick(200*time.Millisecond, func(_ time.Time) tea.Msg {
+		return labelJobToastTickMsg{}
+	})
+}
+
 func schedulePriorityToastTick() tea.Cmd {
 	return tea.Tick(200*time.Millisecond, func(_ time.Time) tea.Msg {
 		return priorityToastTickMsg{}
@@ -300,12 +417,40 @@ func schedulePriorityToastTick() tea.Cmd {
 
 // executePriorityChangeCmd runs the UpdatePriority command asynchronously.
 func (m *App) executePriorityChangeCmd(issueID string, priority int) tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), statusCommandTimeout)
-		defer cancel()
-		err := m.client.UpdatePriority(ctx, issueID, priority)
+	return m.executeMutations(func(err error) tea.Msg {
 		return priorityUpdateCompleteMsg{issueID: issueID, err: err}
-	}
+	}, pendingMutation{Kind: mutationPriority, IssueID: issueID, Priority: priority})
+}
+
+func scheduleAssigneeToastTick() tea.Cmd {
+	return tea.Tick(200*time.Millisecond, func(_ time.Time) tea.Msg {
+		return assigneeToastTickMsg{}
+	})
+}
+
+// executeAssigneeChangeCmd runs the UpdateAssignee command asynchronously.
+func (m *App) executeAssigneeChangeCmd(issueID, assignee string) tea.Cmd {
+	return m.executeMutations(func(err error) tea.Msg {
+		return assigneeUpdateCompleteMsg{issueID: issueID, err: err}
+	}, pendingMutation{Kind: mutationAssignee, IssueID: issueID, Value: assignee})
+}
+
+// executeTakeBeadCmd assigns the bead to the current user and starts it.
+func (m *App) executeTakeBeadCmd(issueID, assignee string) tea.Cmd {
+	return m.executeMutations(func(err error) tea.Msg {
+		return assigneeUpdateCompleteMsg{issueID: issueID, err: err}
+	},
+		pendingMutation{Kind: mutationAssignee, IssueID: issueID, Value: assignee},
+		pendingMutation{Kind: mutationStatus, IssueID: issueID, Value: "in_progress"})
+}
+
+// displayAssigneeToast displays a success toast for assignee changes.
+func (m *App) displayAssigneeToast(issueID, assignee string, taken bool) {
+	m.assigneeToastBeadID = issueID
+	m.assigneeToastName = assignee
+	m.assigneeToastTaken = taken
+	m.assigneeToastVisible = true
+	m.assigneeToastStart = time.Now()
 }
 
 // displayPriorityToast displays a success toast for priority changes.
@@ -2,8 +2,11 @@ package ui
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
+	"abacus/internal/beads"
 	"abacus/internal/config"
 	"abacus/internal/ui/theme"
 	"abacus/internal/update"
@@ -45,6 +48,10 @@ func (m *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	if handled, commentCmd := m.handleCommentSelectionKey(msg); handled {
+		return m, commentCmd
+	}
+
 	if handled, detailCmd := m.handleDetailNavigationKey(msg); handled {
 		return m, detailCmd
 	}
@@ -101,11 +108,36 @@ func (m *App) delegateToOverlay(msg tea.KeyMsg) (tea.Cmd, bool) {
 		return cmd, true
 	}
 
+	if m.activeOverlay == OverlayCommentDelete && m.commentDeleteOverlay != nil {
+		m.commentDeleteOverlay, cmd = m.commentDeleteOverlay.Update(msg)
+		return cmd, true
+	}
+
+	if m.activeOverlay == OverlayLabelManager && m.labelManagerOverlay != nil {
+		m.labelManagerOverlay, cmd = m.labelManagerOverlay.Update(msg)
+		return cmd, true
+	}
+
+	if m.activeOverlay == OverlayOutlineImport && m.outlineOverlay != nil {
+		m.outlineOverlay, cmd = m.outlineOverlay.Update(msg)
+		return cmd, true
+	}
+
+	if m.activeOverlay == OverlayEditConflict && m.editConflictOverlay != nil {
+		m.editConflictOverlay, cmd = m.editConflictOverlay.Update(msg)
+		return cmd, true
+	}
+
 	if m.activeOverlay == OverlayPriority && m.priorityOverlay != nil {
 		m.priorityOverlay, cmd = m.priorityOverlay.Update(msg)
 		return cmd, true
 	}
 
+	if m.activeOverlay == OverlayAssignee && m.assigneeOverlay != nil {
+		m.assigneeOverlay, cmd = m.assigneeOverlay.Update(msg)
+		return cmd, true
+	}
+
 	return nil, false
 }
 
@@ -188,18 +220,27 @@ func (m *App) handleGlobalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleBackspaceKey()
 	case key.Matches(msg, m.keys.Copy):
 		return m.handleCopyKey()
+	case key.Matches(msg, m.keys.OpenLink):
+		return m.handleOpenLinkKey()
+	case key.Matches(msg, m.keys.CreateBranch):
+		return m.handleCreateBranchKey()
+	case key.Matches(msg, m.keys.History):
+		m.showHistory = !m.showHistory
+		m.updateViewportContent()
 	case key.Matches(msg, m.keys.Theme):
 		return m.handleThemeKey(true)
 	case key.Matches(msg, m.keys.ThemePrev):
 		return m.handleThemeKey(false)
 	case key.Matches(msg, m.keys.CycleViewMode):
-		m.viewMode = m.viewMode.Next()
-		m.recalcVisibleRows()
+		m.setViewMode(m.viewMode.Next())
 		return m, nil
 	case key.Matches(msg, m.keys.CycleViewModeBack):
-		m.viewMode = m.viewMode.Prev()
-		m.recalcVisibleRows()
+		m.setViewMode(m.viewMode.Prev())
 		return m, nil
+	case key.Matches(msg, m.keys.CycleSort):
+		return m.handleCycleSortKey()
+	case key.Matches(msg, m.keys.CycleGroup):
+		return m.handleCycleGroupKey()
 	case key.Matches(msg, m.keys.ToggleColumns):
 		return m.handleToggleColumnsKey()
 	case key.Matches(msg, m.keys.Error):
@@ -215,8 +256,16 @@ func (m *App) handleGlobalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleStatusKey()
 	case key.Matches(msg, m.keys.Labels):
 		return m.handleLabelsKey()
+	case key.Matches(msg, m.keys.LabelManager):
+		return m.handleLabelManagerKey()
 	case key.Matches(msg, m.keys.Priority):
 		return m.handlePriorityKey()
+	case key.Matches(msg, m.keys.Assignee):
+		return m.handleAssigneeKey()
+	case key.Matches(msg, m.keys.TakeBead):
+		return m.handleTakeBeadKey()
+	case key.Matches(msg, m.keys.MyWork):
+		return m.handleMyWorkKey()
 	case key.Matches(msg, m.keys.Edit):
 		return m.handleEditKey()
 	case key.Matches(msg, m.keys.Comment):
@@ -225,6 +274,8 @@ func (m *App) handleGlobalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleNewBeadKey(false)
 	case key.Matches(msg, m.keys.NewRootBead):
 		return m.handleNewBeadKey(true)
+	case key.Matches(msg, m.keys.ImportOutline):
+		return m.handleImportOutlineKey()
 	case key.Matches(msg, m.keys.Update):
 		return m.handleUpdateKey()
 	case key.Matches(msg, m.keys.Layout):
@@ -240,7 +291,7 @@ func (m *App) handleTreeExpand() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	row := m.visibleRows[m.cursor]
-	if len(row.Node.Children) > 0 {
+	if m.rowHasExpandableChildren(row) {
 		if m.isNodeExpandedInView(row) {
 			m.collapseNodeForView(row)
 		} else {
@@ -257,7 +308,7 @@ func (m *App) handleTreeCollapse() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 	row := m.visibleRows[m.cursor]
-	if len(row.Node.Children) > 0 && m.isNodeExpandedInView(row) {
+	if m.rowHasExpandableChildren(row) && m.isNodeExpandedInView(row) {
 		m.collapseNodeForView(row)
 		m.recalcVisibleRows()
 	}
@@ -266,7 +317,7 @@ func (m *App) handleTreeCollapse() (tea.Model, tea.Cmd) {
 
 // handleDeleteKey opens the delete confirmation overlay.
 func (m *App) handleDeleteKey() (tea.Model, tea.Cmd) {
-	if m.activeOverlay == OverlayNone && !m.searching && len(m.visibleRows) > 0 {
+	if m.activeOverlay == OverlayNone && !m.searching && len(m.visibleRows) > 0 && !m.cursorOnGroupHeader() {
 		row := m.visibleRows[m.cursor]
 		childInfo, descendantIDs := collectChildInfo(row.Node)
 		m.deleteOverlay = NewDeleteOverlay(row.Node.Issue.ID, row.Node.Issue.Title, childInfo, descendantIDs)
@@ -283,7 +334,7 @@ func (m *App) handleBackspaceKey() (tea.Model, tea.Cmd) {
 		m.updateViewportContent()
 		return m, nil
 	}
-	if m.activeOverlay == OverlayNone && !m.searching && m.filterText == "" && len(m.visibleRows) > 0 {
+	if m.activeOverlay == OverlayNone && !m.searching && m.filterText == "" && len(m.visibleRows) > 0 && !m.cursorOnGroupHeader() {
 		row := m.visibleRows[m.cursor]
 		childInfo, descendantIDs := collectChildInfo(row.Node)
 		m.deleteOverlay = NewDeleteOverlay(row.Node.Issue.ID, row.Node.Issue.Title, childInfo, descendantIDs)
@@ -294,7 +345,7 @@ func (m *App) handleBackspaceKey() (tea.Model, tea.Cmd) {
 
 // handleCopyKey copies the current bead ID to clipboard.
 func (m *App) handleCopyKey() (tea.Model, tea.Cmd) {
-	if len(m.visibleRows) > 0 {
+	if len(m.visibleRows) > 0 && !m.cursorOnGroupHeader() {
 		id := m.visibleRows[m.cursor].Node.Issue.ID
 		if err := clipboard.WriteAll(id); err == nil {
 			m.copiedBeadID = id
@@ -306,6 +357,25 @@ func (m *App) handleCopyKey() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleOpenLinkKey opens the current bead's external ref in the browser.
+func (m *App) handleOpenLinkKey() (tea.Model, tea.Cmd) {
+	if len(m.visibleRows) == 0 || m.cursorOnGroupHeader() {
+		return m, nil
+	}
+	ref := strings.TrimSpace(m.visibleRows[m.cursor].Node.Issue.ExternalRef)
+	if ref == "" {
+		m.lastError = "This bead has no external ref"
+	} else if url, ok := externalRefURL(ref); ok {
+		return m, openURL(url)
+	} else {
+		m.lastError = fmt.Sprintf("No links.patterns rule matches external ref %q", ref)
+	}
+	m.lastErrorSource = errorSourceOperation
+	m.showErrorToast = true
+	m.errorToastStart = time.Now()
+	return m, scheduleErrorToastTick()
+}
+
 // handleToggleColumnsKey toggles the columns display.
 func (m *App) handleToggleColumnsKey() (tea.Model, tea.Cmd) {
 	current := config.GetBool(config.KeyTreeShowColumns)
@@ -318,6 +388,47 @@ func (m *App) handleToggleColumnsKey() (tea.Model, tea.Cmd) {
 	return m, scheduleColumnsToastTick()
 }
 
+// setViewMode switches the view filter and restores that view's sort mode.
+func (m *App) setViewMode(mode ViewMode) {
+	m.viewMode = mode
+	m.sortMode = loadSortMode(mode)
+	m.recalcVisibleRows()
+	m.updateViewportContent()
+}
+
+// handleCycleSortKey advances the sort mode for the current view and persists it.
+func (m *App) handleCycleSortKey() (tea.Model, tea.Cmd) {
+	var selectedID string
+	if len(m.visibleRows) > 0 && m.cursor >= 0 && m.cursor < len(m.visibleRows) {
+		selectedID = m.visibleRows[m.cursor].Node.Issue.ID
+	}
+	m.sortMode = m.sortMode.Next()
+	configKey := sortConfigKey(m.viewMode)
+	_ = config.Set(configKey, m.sortMode.ConfigValue())
+	_ = config.SaveSortMode(configKey, m.sortMode.ConfigValue())
+	m.recalcVisibleRows()
+	m.restoreCursorToID(selectedID)
+	m.updateViewportContent()
+	m.sortToastVisible = true
+	m.sortToastStart = time.Now()
+	return m, scheduleSortToastTick()
+}
+
+// handleCycleGroupKey advances the group-by mode, keeping the selected bead in view.
+func (m *App) handleCycleGroupKey() (tea.Model, tea.Cmd) {
+	var selectedID string
+	if len(m.visibleRows) > 0 && m.cursor >= 0 && m.cursor < len(m.visibleRows) && !m.cursorOnGroupHeader() {
+		selectedID = m.visibleRows[m.cursor].Node.Issue.ID
+	}
+	m.groupMode = m.groupMode.Next()
+	m.recalcVisibleRows()
+	m.restoreCursorToID(selectedID)
+	m.updateViewportContent()
+	m.groupToastVisible = true
+	m.groupToastStart = time.Now()
+	return m, scheduleGroupToastTick()
+}
+
 // handleThemeKey cycles the theme forward or backward.
 func (m *App) handleThemeKey(forward bool) (tea.Model, tea.Cmd) {
 	var newTheme string
@@ -338,7 +449,7 @@ func (m *App) handleThemeKey(forward bool) (tea.Model, tea.Cmd) {
 
 // handleStatusKey opens the status overlay.
 func (m *App) handleStatusKey() (tea.Model, tea.Cmd) {
-	if len(m.visibleRows) > 0 {
+	if len(m.visibleRows) > 0 && !m.cursorOnGroupHeader() {
 		row := m.visibleRows[m.cursor]
 		m.statusOverlay = NewStatusOverlay(row.Node.Issue.ID, row.Node.Issue.Title, row.Node.Issue.Status)
 		m.activeOverlay = OverlayStatus
@@ -348,7 +459,7 @@ func (m *App) handleStatusKey() (tea.Model, tea.Cmd) {
 
 // handlePriorityKey opens the priority overlay.
 func (m *App) handlePriorityKey() (tea.Model, tea.Cmd) {
-	if len(m.visibleRows) > 0 {
+	if len(m.visibleRows) > 0 && !m.cursorOnGroupHeader() {
 		row := m.visibleRows[m.cursor]
 		m.priorityOverlay = NewPriorityOverlay(row.Node.Issue.ID, row.Node.Issue.Title, row.Node.Issue.Priority)
 		m.activeOverlay = OverlayPriority
@@ -356,9 +467,86 @@ func (m *App) handlePriorityKey() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleAssigneeKey opens the assignee overlay.
+func (m *App) handleAssigneeKey() (tea.Model, tea.Cmd) {
+	if len(m.visibleRows) > 0 && !m.cursorOnGroupHeader() {
+		row := m.visibleRows[m.cursor]
+		m.assigneeOverlay = NewAssigneeOverlay(
+			row.Node.Issue.ID,
+			row.Node.Issue.Title,
+			row.Node.Issue.Assignee,
+			m.getAllAssignees(),
+		)
+		m.activeOverlay = OverlayAssignee
+		return m, m.assigneeOverlay.Init()
+	}
+	return m, nil
+}
+
+// handleTakeBeadKey claims the selected bead: assigns it to the current user
+// and moves it to in_progress.
+func (m *App) handleTakeBeadKey() (tea.Model, tea.Cmd) {
+	if len(m.visibleRows) == 0 || m.cursorOnGroupHeader() {
+		return m, nil
+	}
+	user := currentUser()
+	if user == "" {
+		m.lastError = "cannot take bead: current user is unknown"
+		m.lastErrorSource = errorSourceOperation
+		m.showErrorToast = true
+		m.errorToastStart = time.Now()
+		return m, scheduleErrorToastTick()
+	}
+	issueID := m.visibleRows[m.cursor].Node.Issue.ID
+	m.displayAssigneeToast(issueID, user, true)
+	return m, tea.Batch(m.executeTakeBeadCmd(issueID, user), scheduleAssigneeToastTick())
+}
+
+// handleMyWorkKey toggles the filter limiting the tree to the current user's beads.
+func (m *App) handleMyWorkKey() (tea.Model, tea.Cmd) {
+	var selectedID string
+	if len(m.visibleRows) > 0 && m.cursor >= 0 && m.cursor < len(m.visibleRows) {
+		selectedID = m.visibleRows[m.cursor].Node.Issue.ID
+	}
+	m.myWorkOnly = !m.myWorkOnly
+	m.recalcVisibleRows()
+	m.restoreCursorToID(selectedID)
+	m.updateViewportContent()
+	return m, nil
+}
+
+// handleLabelManagerKey opens the project-wide label manager. It stays closed
+// while a previous label change is still being applied.
+func (m *App) handleLabelManagerKey() (tea.Model, tea.Cmd) {
+	if m.labelJob != nil && !m.labelJob.finished() {
+		return m, nil
+	}
+	usage, labelsByIssue := collectLabelUsage(m.roots)
+	m.labelManagerOverlay = NewLabelManagerOverlay(usage, labelsByIssue)
+	m.activeOverlay = OverlayLabelManager
+	return m, m.labelManagerOverlay.Init()
+}
+
+// handleImportOutlineKey opens the outline import under the selected bead,
+// or for root beads when the tree is empty or a group header is selected.
+// It stays closed while a previous import is still running.
+func (m *App) handleImportOutlineKey() (tea.Model, tea.Cmd) {
+	if m.outlineJob != nil && !m.outlineJob.done {
+		return m, nil
+	}
+	parentID, parentTitle := "", ""
+	if len(m.visibleRows) > 0 && !m.cursorOnGroupHeader() {
+		issue := m.visibleRows[m.cursor].Node.Issue
+		parentID, parentTitle = issue.ID, issue.Title
+	}
+	m.outlineOverlay = NewOutlineImportOverlay(parentID, parentTitle)
+	m.activeOverlay = OverlayOutlineImport
+	return m, m.outlineOverlay.Init()
+}
+
 // handleLabelsKey opens the labels overlay.
 func (m *App) handleLabelsKey() (tea.Model, tea.Cmd) {
-	if len(m.visibleRows) > 0 {
+	if len(m.visibleRows) > 0 && !m.cursorOnGroupHeader() {
 		row := m.visibleRows[m.cursor]
 		allLabels := m.getAllLabels()
 		m.labelsOverlay = NewLabelsOverlay(
@@ -375,7 +563,7 @@ func (m *App) handleLabelsKey() (tea.Model, tea.Cmd) {
 
 // handleEditKey opens the edit overlay for the current bead.
 func (m *App) handleEditKey() (tea.Model, tea.Cmd) {
-	if len(m.visibleRows) > 0 {
+	if len(m.visibleRows) > 0 && !m.cursorOnGroupHeader() {
 		row := m.visibleRows[m.cursor]
 		parentID := ""
 		if row.Parent != nil {
@@ -397,7 +585,7 @@ func (m *App) handleEditKey() (tea.Model, tea.Cmd) {
 
 // handleCommentKey opens the comment overlay.
 func (m *App) handleCommentKey() (tea.Model, tea.Cmd) {
-	if len(m.visibleRows) > 0 {
+	if len(m.visibleRows) > 0 && !m.cursorOnGroupHeader() {
 		row := m.visibleRows[m.cursor]
 		m.commentOverlay = NewCommentOverlay(row.Node.Issue.ID, row.Node.Issue.Title)
 		m.commentOverlay.SetSize(m.width, m.height)
@@ -407,24 +595,98 @@ func (m *App) handleCommentKey() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleCommentSelectionKey moves the comment cursor in the focused detail pane
+// and routes edit, delete and quote-reply to the selected comment.
+func (m *App) handleCommentSelectionKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if !m.detailFocusActive() {
+		return false, nil
+	}
+	switch {
+	case key.Matches(msg, m.keys.NextComment):
+		return m.moveCommentSelection(1), nil
+	case key.Matches(msg, m.keys.PrevComment):
+		return m.moveCommentSelection(-1), nil
+	}
+
+	node, comment, ok := m.selectedCommentTarget()
+	if !ok {
+		return false, nil
+	}
+	switch {
+	case key.Matches(msg, m.keys.Escape):
+		m.clearCommentSelection()
+		m.updateViewportContent()
+		return true, nil
+	case key.Matches(msg, m.keys.Edit) && !m.canEditComments():
+		return true, m.showCommentEditUnsupported("edit")
+	case key.Matches(msg, m.keys.Edit):
+		m.commentOverlay = NewEditCommentOverlay(node.Issue.ID, node.Issue.Title, comment)
+	case key.Matches(msg, m.keys.ReplyComment):
+		m.commentOverlay = NewReplyCommentOverlay(node.Issue.ID, node.Issue.Title, comment)
+	case key.Matches(msg, m.keys.Delete) && !m.canEditComments():
+		return true, m.showCommentEditUnsupported("delete")
+	case key.Matches(msg, m.keys.Delete):
+		m.commentDeleteOverlay = NewCommentDeleteOverlay(node.Issue.ID, comment)
+		m.activeOverlay = OverlayCommentDelete
+		return true, nil
+	default:
+		return false, nil
+	}
+	m.commentOverlay.SetSize(m.width, m.height)
+	m.activeOverlay = OverlayComment
+	return true, m.commentOverlay.Init()
+}
+
+// canEditComments reports whether comments can be edited and deleted: the
+// client does not rule it out and no attempt has come back unsupported.
+func (m *App) canEditComments() bool {
+	return !m.commentEditUnsupported && beads.CanEditComments(m.client)
+}
+
+// showCommentEditUnsupported shows the error toast for an edit or delete
+// the backend cannot perform, before the user types or confirms anything.
+func (m *App) showCommentEditUnsupported(action string) tea.Cmd {
+	backend := m.backend
+	if backend == "" {
+		backend = "This backend"
+	}
+	m.lastError = fmt.Sprintf("%s cannot %s comments", backend, action)
+	m.lastErrorSource = errorSourceOperation
+	m.showErrorToast = true
+	m.errorToastStart = time.Now()
+	return scheduleErrorToastTick()
+}
+
 // handleNewBeadKey opens the create overlay for a new bead.
 func (m *App) handleNewBeadKey(isRoot bool) (tea.Model, tea.Cmd) {
 	defaultParent := ""
 	// When no beads exist, 'n' should behave like 'N' (create root node)
 	if len(m.visibleRows) == 0 {
 		isRoot = true
+	} else if m.cursorOnGroupHeader() {
+		isRoot = true
 	} else if !isRoot {
 		defaultParent = m.visibleRows[m.cursor].Node.Issue.ID
 	}
+	// Broken template files are skipped; the rest stay usable.
+	templates, templateErr := config.LoadTemplates(m.projectRoot())
 	m.createOverlay = NewCreateOverlay(CreateOverlayOptions{
 		DefaultParentID:    defaultParent,
 		AvailableParents:   m.getAvailableParents(),
 		AvailableLabels:    m.getAllLabels(),
 		AvailableAssignees: m.getAllAssignees(),
 		IsRootMode:         isRoot,
+		Templates:          templates,
 	})
 	m.createOverlay.SetSize(m.width, m.height)
 	m.activeOverlay = OverlayCreate
+	if templateErr != nil {
+		m.lastError = "templates: " + templateErr.Error()
+		m.lastErrorSource = errorSourceOperation
+		m.showErrorToast = true
+		m.errorToastStart = time.Now()
+		return m, tea.Batch(m.createOverlay.Init(), scheduleErrorToastTick())
+	}
 	return m, m.createOverlay.Init()
 }
 
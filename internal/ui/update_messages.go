@@ -38,11 +38,33 @@ type deleteToastTickMsg struct{}
 // Message types for comment operations
 type commentCompleteMsg struct {
 	issueID string
+	action  string // "added", "updated" or "deleted"
 	err     error
 }
 
 type commentToastTickMsg struct{}
 
+// Message types for project-wide label changes
+type labelStepCompleteMsg struct {
+	index int
+	err   error
+}
+
+type labelJobToastTickMsg struct{}
+
+// Message types for outline import
+type outlineStepCompleteMsg struct {
+	index int
+	id    string
+	err   error
+}
+
+type outlineRollbackCompleteMsg struct {
+	err error
+}
+
+type outlineJobToastTickMsg struct{}
+
 // Message types for priority operations
 type priorityUpdateCompleteMsg struct {
 	issueID string
@@ -50,3 +72,11 @@ type priorityUpdateCompleteMsg struct {
 }
 
 type priorityToastTickMsg struct{}
+
+// Message types for assignee operations
+type assigneeUpdateCompleteMsg struct {
+	issueID string
+	err     error
+}
+
+type assigneeToastTickMsg struct{}
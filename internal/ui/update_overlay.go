@@ -3,8 +3,11 @@ package ui
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"abacus/internal/beads"
+
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -164,8 +167,28 @@ func (m *App) handleOverlayMsg(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 		}
 		return m, m.executeUpdateCmd(msg), true
 
+	case editConflictMsg:
+		// Keep the edit form underneath so Esc can return to it.
+		m.editConflictOverlay = NewEditConflictOverlay(msg.conflict)
+		m.activeOverlay = OverlayEditConflict
+		return m, nil, true
+
+	case EditConflictResolvedMsg:
+		m.editConflictOverlay = nil
+		m.activeOverlay = OverlayCreate
+		return m, m.executeUpdateCmd(msg.Update), true
+
+	case EditConflictCancelledMsg:
+		m.editConflictOverlay = nil
+		m.activeOverlay = OverlayCreate
+		if m.createOverlay != nil {
+			m.createOverlay.isCreating = false
+		}
+		return m, nil, true
+
 	case updateCompleteMsg:
 		m.activeOverlay = OverlayNone
+		m.editConflictOverlay = nil
 		m.createOverlay = nil
 		if msg.Err != nil {
 			m.showErrorToast = true
@@ -207,6 +230,16 @@ func (m *App) handleOverlayMsg(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 		}
 		return m, scheduleThemeToastTick(), true
 
+	case queuedToastTickMsg:
+		if !m.queuedToastVisible {
+			return m, nil, true
+		}
+		if time.Since(m.queuedToastStart) >= 3*time.Second {
+			m.queuedToastVisible = false
+			return m, nil, true
+		}
+		return m, scheduleQueuedToastTick(), true
+
 	case columnsToastTickMsg:
 		if !m.columnsToastVisible {
 			return m, nil, true
@@ -217,6 +250,26 @@ func (m *App) handleOverlayMsg(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 		}
 		return m, scheduleColumnsToastTick(), true
 
+	case sortToastTickMsg:
+		if !m.sortToastVisible {
+			return m, nil, true
+		}
+		if time.Since(m.sortToastStart) >= 3*time.Second {
+			m.sortToastVisible = false
+			return m, nil, true
+		}
+		return m, scheduleSortToastTick(), true
+
+	case groupToastTickMsg:
+		if !m.groupToastVisible {
+			return m, nil, true
+		}
+		if time.Since(m.groupToastStart) >= 3*time.Second {
+			m.groupToastVisible = false
+			return m, nil, true
+		}
+		return m, scheduleGroupToastTick(), true
+
 	case layoutToastTickMsg:
 		if !m.layoutToastVisible {
 			return m, nil, true
@@ -267,12 +320,31 @@ func (m *App) handleOverlayMsg(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 		m.commentOverlay = nil
 		return m, tea.Batch(m.executeAddComment(msg), scheduleCommentToastTick()), true
 
+	case CommentEditedMsg:
+		m.activeOverlay = OverlayNone
+		m.commentOverlay = nil
+		return m, m.executeUpdateComment(msg), true
+
 	case CommentCancelledMsg:
 		m.activeOverlay = OverlayNone
 		m.commentOverlay = nil
 		return m, nil, true
 
+	case CommentDeleteConfirmedMsg:
+		m.activeOverlay = OverlayNone
+		m.commentDeleteOverlay = nil
+		return m, m.executeDeleteComment(msg), true
+
+	case CommentDeleteCancelledMsg:
+		m.activeOverlay = OverlayNone
+		m.commentDeleteOverlay = nil
+		return m, nil, true
+
 	case commentCompleteMsg:
+		if msg.action != "added" && errors.Is(msg.err, beads.ErrNotSupported) {
+			// Don't offer what the backend just refused.
+			m.commentEditUnsupported = true
+		}
 		if msg.err != nil {
 			m.lastError = msg.err.Error()
 			m.lastErrorSource = errorSourceOperation
@@ -280,7 +352,7 @@ func (m *App) handleOverlayMsg(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 			m.errorToastStart = time.Now()
 			return m, scheduleErrorToastTick(), true
 		}
-		m.displayCommentToast(msg.issueID)
+		m.displayCommentToast(msg.issueID, msg.action)
 		return m, tea.Batch(m.forceRefresh(), scheduleCommentToastTick()), true
 
 	case commentToastTickMsg:
@@ -293,6 +365,114 @@ func (m *App) handleOverlayMsg(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 		}
 		return m, scheduleCommentToastTick(), true
 
+	case LabelManagerClosedMsg:
+		m.activeOverlay = OverlayNone
+		m.labelManagerOverlay = nil
+		return m, nil, true
+
+	case LabelChangeConfirmedMsg:
+		m.activeOverlay = OverlayNone
+		m.labelManagerOverlay = nil
+		if len(msg.Plan.Steps) == 0 {
+			return m, nil, true
+		}
+		m.labelJob = &labelJob{plan: msg.Plan}
+		m.labelJobToastVisible = true
+		m.labelJobToastStart = time.Now()
+		return m, m.executeLabelStep(msg.Plan, 0), true
+
+	case labelStepCompleteMsg:
+		job := m.labelJob
+		if job == nil {
+			return m, nil, true
+		}
+		job.done++
+		if msg.err != nil {
+			job.failed = append(job.failed, job.plan.Steps[msg.index].IssueID)
+			if job.err == nil {
+				job.err = msg.err
+			}
+		}
+		if !job.finished() {
+			return m, m.executeLabelStep(job.plan, job.done), true
+		}
+		m.labelJobToastStart = time.Now()
+		if job.err != nil {
+			m.lastError = fmt.Sprintf("%s label %s: %d of %d beads failed (%s): %v",
+				strings.ToLower(job.plan.Verb()), job.plan.Summary(), len(job.failed), len(job.plan.Steps),
+				strings.Join(job.failed, ", "), job.err)
+			m.lastErrorSource = errorSourceOperation
+			m.showErrorToast = true
+			m.errorToastStart = time.Now()
+			return m, tea.Batch(m.forceRefresh(), scheduleLabelJobToastTick(), scheduleErrorToastTick()), true
+		}
+		return m, tea.Batch(m.forceRefresh(), scheduleLabelJobToastTick()), true
+
+	case labelJobToastTickMsg:
+		if !m.labelJobToastVisible {
+			return m, nil, true
+		}
+		if time.Since(m.labelJobToastStart) >= 3*time.Second {
+			m.labelJobToastVisible = false
+			m.labelJob = nil
+			return m, nil, true
+		}
+		return m, scheduleLabelJobToastTick(), true
+
+	case OutlineImportCancelledMsg:
+		m.activeOverlay = OverlayNone
+		m.outlineOverlay = nil
+		return m, nil, true
+
+	case OutlineImportConfirmedMsg:
+		m.activeOverlay = OverlayNone
+		m.outlineOverlay = nil
+		if len(msg.Steps) == 0 {
+			return m, nil, true
+		}
+		m.outlineJob = &outlineJob{parentID: msg.ParentID, steps: msg.Steps}
+		m.outlineJobToastVisible = true
+		m.outlineJobToastStart = time.Now()
+		return m, m.executeOutlineStep(m.outlineJob, 0), true
+
+	case outlineStepCompleteMsg:
+		job := m.outlineJob
+		if job == nil {
+			return m, nil, true
+		}
+		if msg.err != nil {
+			job.err = msg.err
+			job.failedTitle = job.steps[msg.index].Item.Title
+			if len(job.created) == 0 {
+				return m, m.finishOutlineJob(), true
+			}
+			job.rollingBack = true
+			return m, m.executeOutlineRollback(job.created), true
+		}
+		job.created = append(job.created, msg.id)
+		if len(job.created) < len(job.steps) {
+			return m, m.executeOutlineStep(job, len(job.created)), true
+		}
+		return m, m.finishOutlineJob(), true
+
+	case outlineRollbackCompleteMsg:
+		if m.outlineJob == nil {
+			return m, nil, true
+		}
+		m.outlineJob.rollbackErr = msg.err
+		return m, m.finishOutlineJob(), true
+
+	case outlineJobToastTickMsg:
+		if !m.outlineJobToastVisible {
+			return m, nil, true
+		}
+		if m.outlineJob != nil && m.outlineJob.done && time.Since(m.outlineJobToastStart) >= 3*time.Second {
+			m.outlineJobToastVisible = false
+			m.outlineJob = nil
+			return m, nil, true
+		}
+		return m, scheduleOutlineJobToastTick(), true
+
 	case PriorityChangedMsg:
 		m.activeOverlay = OverlayNone
 		m.priorityOverlay = nil
@@ -314,6 +494,38 @@ func (m *App) handleOverlayMsg(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 		}
 		return m, m.forceRefresh(), true
 
+	case AssigneeChangedMsg:
+		m.activeOverlay = OverlayNone
+		m.assigneeOverlay = nil
+		m.displayAssigneeToast(msg.IssueID, msg.Assignee, false)
+		return m, tea.Batch(m.executeAssigneeChangeCmd(msg.IssueID, msg.Assignee), scheduleAssigneeToastTick()), true
+
+	case AssigneeCancelledMsg:
+		m.activeOverlay = OverlayNone
+		m.assigneeOverlay = nil
+		return m, nil, true
+
+	case assigneeUpdateCompleteMsg:
+		if msg.err != nil {
+			m.assigneeToastVisible = false
+			m.lastError = msg.err.Error()
+			m.lastErrorSource = errorSourceOperation
+			m.showErrorToast = true
+			m.errorToastStart = time.Now()
+			return m, scheduleErrorToastTick(), true
+		}
+		return m, m.forceRefresh(), true
+
+	case assigneeToastTickMsg:
+		if !m.assigneeToastVisible {
+			return m, nil, true
+		}
+		if time.Since(m.assigneeToastStart) >= 7*time.Second {
+			m.assigneeToastVisible = false
+			return m, nil, true
+		}
+		return m, scheduleAssigneeToastTick(), true
+
 	case priorityToastTickMsg:
 		if !m.priorityToastVisible {
 			return m, nil, true
@@ -377,3 +589,29 @@ func (m *App) handleCreateComplete(msg createCompleteMsg) (tea.Model, tea.Cmd, b
 	m.displayCreateToast("", false)
 	return m, tea.Batch(m.forceRefresh(), scheduleCreateToastTick()), true
 }
+
+// finishOutlineJob marks the outline import done, surfaces any failure and
+// refreshes the tree so created (or rolled back) beads show up.
+func (m *App) finishOutlineJob() tea.Cmd {
+	job := m.outlineJob
+	job.done = true
+	job.rollingBack = false
+	m.outlineJobToastStart = time.Now()
+	cmds := []tea.Cmd{m.forceRefresh(), scheduleOutlineJobToastTick()}
+	if job.err != nil {
+		m.lastError = fmt.Sprintf("outline import failed at %q: %v", job.failedTitle, job.err)
+		if len(job.created) > 0 {
+			if job.rollbackErr != nil {
+				m.lastError += fmt.Sprintf("; rollback incomplete, remove manually (%s): %v",
+					strings.Join(job.created, ", "), job.rollbackErr)
+			} else {
+				m.lastError += fmt.Sprintf("; rolled back %d created %s", len(job.created), beadWord(len(job.created)))
+			}
+		}
+		m.lastErrorSource = errorSourceOperation
+		m.showErrorToast = true
+		m.errorToastStart = time.Now()
+		cmds = append(cmds, scheduleErrorToastTick())
+	}
+	return tea.Batch(cmds...)
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"abacus/internal/beads"
@@ -91,6 +92,39 @@ func TestExecuteUpdateCmdNoParentChange(t *testing.T) {
 	}
 }
 
+func TestExecuteUpdateCmdRollsBackFailedReparent(t *testing.T) {
+	mockClient := beads.NewMockClient()
+	mockClient.AddDependencyFn = func(_ context.Context, _, toID, _ string) error {
+		if toID == "ab-new" {
+			return errors.New("dep add failed")
+		}
+		return nil
+	}
+
+	node := &graph.Node{Issue: beads.FullIssue{ID: "ab-1", Title: "Old Title", IssueType: "task", Priority: 3, Labels: []string{"old"}}}
+	app := &App{client: mockClient, roots: []*graph.Node{node}}
+
+	res := app.executeUpdateCmd(BeadUpdatedMsg{
+		ID:               "ab-1",
+		Title:            "New Title",
+		IssueType:        "task",
+		Priority:         1,
+		ParentID:         "ab-new",
+		OriginalParentID: "ab-old",
+	})()
+	if updateMsg := res.(updateCompleteMsg); updateMsg.Err == nil {
+		t.Fatal("expected the failed parent change to be reported")
+	}
+
+	// The old parent is linked again and the edit itself is reverted.
+	if got := mockClient.AddDependencyCallArgs; len(got) != 2 || got[1][1] != "ab-old" || got[1][2] != "parent-child" {
+		t.Errorf("expected old parent to be restored, got %v", got)
+	}
+	if got := mockClient.UpdateFullCallArgs; len(got) != 2 || got[1].Title != "Old Title" || got[1].Priority != 3 || got[1].Labels[0] != "old" {
+		t.Errorf("expected UpdateFull to restore the original fields, got %+v", got)
+	}
+}
+
 func TestBeadUpdatedMsgRejectsNonEpicParent(t *testing.T) {
 	mockClient := beads.NewMockClient()
 	mockClient.UpdateFullFn = func(_ context.Context, _id, _title, _issueType string, _priority int, _labels []string, _assignee, _description string) error {
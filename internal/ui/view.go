@@ -54,6 +54,23 @@ func (m *App) View() string {
 		status += " " + styleFilterInfo().Render(modeLabel)
 	}
 
+	// Show my-work indicator when limited to the current user's beads
+	if m.myWorkOnly {
+		status += " " + styleFilterInfo().Render("[My work]")
+	}
+
+	// Show sort indicator when not using the smart default
+	if m.sortMode != SortModeSmart {
+		sortLabel := fmt.Sprintf("[Sort: %s]", m.sortMode.String())
+		status += " " + styleFilterInfo().Render(sortLabel)
+	}
+
+	// Show group indicator when the hierarchy is replaced by groups
+	if m.groupMode != GroupModeNone {
+		groupLabel := fmt.Sprintf("[Group: %s]", m.groupMode.String())
+		status += " " + styleFilterInfo().Render(groupLabel)
+	}
+
 	if m.filterText != "" {
 		filterLabel := fmt.Sprintf("Filter: %s", m.filterText)
 		status += " " + styleFilterInfo().Render(filterLabel)
@@ -144,6 +161,7 @@ func (m *App) View() string {
 		headerHeight = 1
 	}
 	mainBodyStart := headerHeight
+	m.mainBodyTop = mainBodyStart
 	mainBodyHeight := lipgloss.Height(mainBody)
 	if mainBodyHeight <= 0 {
 		mainBodyHeight = listHeight
@@ -152,6 +170,7 @@ func (m *App) View() string {
 	if bottomMargin <= 0 {
 		bottomMargin = 1
 	}
+	m.footerHeight = bottomMargin
 
 	// Determine whether we need to show an overlay (status, labels, create, delete, help)
 	var overlayLayers []Layer
@@ -175,10 +194,30 @@ func (m *App) View() string {
 		if layer := m.commentOverlay.Layer(m.width, m.height, headerHeight, bottomMargin); layer != nil {
 			overlayLayers = append(overlayLayers, layer)
 		}
+	} else if m.activeOverlay == OverlayCommentDelete && m.commentDeleteOverlay != nil {
+		if layer := m.commentDeleteOverlay.Layer(m.width, m.height, headerHeight, bottomMargin); layer != nil {
+			overlayLayers = append(overlayLayers, layer)
+		}
+	} else if m.activeOverlay == OverlayLabelManager && m.labelManagerOverlay != nil {
+		if layer := m.labelManagerOverlay.Layer(m.width, m.height, headerHeight, bottomMargin); layer != nil {
+			overlayLayers = append(overlayLayers, layer)
+		}
+	} else if m.activeOverlay == OverlayOutlineImport && m.outlineOverlay != nil {
+		if layer := m.outlineOverlay.Layer(m.width, m.height, headerHeight, bottomMargin); layer != nil {
+			overlayLayers = append(overlayLayers, layer)
+		}
+	} else if m.activeOverlay == OverlayEditConflict && m.editConflictOverlay != nil {
+		if layer := m.editConflictOverlay.Layer(m.width, m.height, headerHeight, bottomMargin); layer != nil {
+			overlayLayers = append(overlayLayers, layer)
+		}
 	} else if m.activeOverlay == OverlayPriority && m.priorityOverlay != nil {
 		if layer := m.priorityOverlay.Layer(m.width, m.height, headerHeight, bottomMargin); layer != nil {
 			overlayLayers = append(overlayLayers, layer)
 		}
+	} else if m.activeOverlay == OverlayAssignee && m.assigneeOverlay != nil {
+		if layer := m.assigneeOverlay.Layer(m.width, m.height, headerHeight, bottomMargin); layer != nil {
+			overlayLayers = append(overlayLayers, layer)
+		}
 	} else if m.showHelp {
 		overlayLayers = append(overlayLayers, newHelpOverlayLayer(m.keys, m.width, m.height, headerHeight, bottomMargin))
 	}
@@ -199,19 +238,27 @@ func (m *App) View() string {
 	toastFactories := []func(int, int, int, int) Layer{
 		m.themeToastLayer,
 		m.layoutToastLayer,
+		m.sortToastLayer,
+		m.groupToastLayer,
 		m.columnsToastLayer,
 		m.updateSuccessToastLayer,
 		m.updateFailureToastLayer,
 		m.updateToastLayer,
 		m.deleteToastLayer,
+		m.queuedToastLayer,
 		m.createToastLayer,
 		m.commentToastLayer,
 		m.priorityToastLayer,
+		m.assigneeToastLayer,
 		m.newAssigneeToastLayer,
 		m.newLabelToastLayer,
 		m.labelsToastLayer,
+		m.labelJobToastLayer,
+		m.outlineJobToastLayer,
 		m.statusToastLayer,
 		m.copyToastLayer,
+		m.linkToastLayer,
+		m.branchToastLayer,
 	}
 	// Only add errorToastLayer if not already handled by overlayErrorLayer
 	// to avoid double-rendering error toasts when create overlay is open
@@ -77,6 +77,62 @@ func (m *App) copyToastLayer(width, height, mainBodyStart, mainBodyHeight int) L
 	return newToastLayer(styleSuccessToast().Render(content), width, height, mainBodyStart, mainBodyHeight)
 }
 
+// linkToastLayer renders the toast confirming an external link was opened.
+func (m *App) linkToastLayer(width, height, mainBodyStart, mainBodyHeight int) Layer {
+	if !m.linkToastVisible || m.linkToastURL == "" {
+		return nil
+	}
+	elapsed := time.Since(m.linkToastStart)
+	remaining := 3 - int(elapsed.Seconds())
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	msgLine := "Opened " + truncateTitle(m.linkToastURL, 60)
+	countdownStr := fmt.Sprintf("[%ds]", remaining)
+
+	toastWidth := lipgloss.Width(msgLine)
+	if toastWidth < 30 {
+		toastWidth = 30
+	}
+
+	padding := toastWidth - len(countdownStr)
+	if padding < 0 {
+		padding = 0
+	}
+	content := fmt.Sprintf("%s\n%s%s", msgLine, strings.Repeat(" ", padding), countdownStr)
+
+	return newToastLayer(styleSuccessToast().Render(content), width, height, mainBodyStart, mainBodyHeight)
+}
+
+// branchToastLayer renders the toast confirming a git branch was created.
+func (m *App) branchToastLayer(width, height, mainBodyStart, mainBodyHeight int) Layer {
+	if !m.branchToastVisible || m.branchToastName == "" {
+		return nil
+	}
+	elapsed := time.Since(m.branchToastStart)
+	remaining := 3 - int(elapsed.Seconds())
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	msgLine := fmt.Sprintf("Created branch '%s'.", truncateTitle(m.branchToastName, 60))
+	countdownStr := fmt.Sprintf("[%ds]", remaining)
+
+	toastWidth := lipgloss.Width(msgLine)
+	if toastWidth < 30 {
+		toastWidth = 30
+	}
+
+	padding := toastWidth - len(countdownStr)
+	if padding < 0 {
+		padding = 0
+	}
+	content := fmt.Sprintf("%s\n%s%s", msgLine, strings.Repeat(" ", padding), countdownStr)
+
+	return newToastLayer(styleSuccessToast().Render(content), width, height, mainBodyStart, mainBodyHeight)
+}
+
 // statusToastLayer renders the status change success toast if visible.
 func (m *App) statusToastLayer(width, height, mainBodyStart, mainBodyHeight int) Layer {
 	if !m.statusToastVisible || m.statusToastNewStatus == "" {
@@ -320,7 +376,60 @@ func (m *App) deleteToastLayer(width, height, mainBodyStart, mainBodyHeight int)
 	return newToastLayer(styleSuccessToast().Render(content), width, height, mainBodyStart, mainBodyHeight)
 }
 
-// commentToastLayer renders the comment added success toast if visible.
+// labelJobToastLayer renders progress for a project-wide label change, then
+// its result for a few seconds after the last bead is updated.
+func (m *App) labelJobToastLayer(width, height, mainBodyStart, mainBodyHeight int) Layer {
+	if !m.labelJobToastVisible || m.labelJob == nil {
+		return nil
+	}
+	job := m.labelJob
+	total := len(job.plan.Steps)
+	summary := styleID().Render(job.plan.Summary())
+	var content string
+	switch {
+	case !job.finished():
+		content = " ⟳ " + styleStatsDim().Render(job.plan.Verb()+" label ") + summary +
+			styleStatsDim().Render(fmt.Sprintf("  %d/%d", job.done, total))
+	case len(job.failed) > 0:
+		content = " ⚠ " + styleStatsDim().Render("Label ") + summary +
+			styleStatsDim().Render(fmt.Sprintf(": %d/%d updated, %d failed", total-len(job.failed), total, len(job.failed)))
+	default:
+		content = " ✓ " + styleStatsDim().Render("Label ") + summary +
+			styleStatsDim().Render(fmt.Sprintf(": %d %s updated", total, beadWord(total)))
+	}
+	return newToastLayer(styleSuccessToast().Render(content), width, height, mainBodyStart, mainBodyHeight)
+}
+
+// outlineJobToastLayer renders outline import progress, then its result for
+// a few seconds after the last bead is created or the import is rolled back.
+func (m *App) outlineJobToastLayer(width, height, mainBodyStart, mainBodyHeight int) Layer {
+	if !m.outlineJobToastVisible || m.outlineJob == nil {
+		return nil
+	}
+	job := m.outlineJob
+	total := len(job.steps)
+	var content string
+	switch {
+	case job.rollingBack:
+		content = " ⟳ " + styleStatsDim().Render(fmt.Sprintf("Rolling back %d %s", len(job.created), beadWord(len(job.created))))
+	case !job.done:
+		content = " ⟳ " + styleStatsDim().Render(fmt.Sprintf("Importing outline  %d/%d", len(job.created), total))
+	case job.err != nil:
+		content = " ⚠ " + styleStatsDim().Render("Outline import failed, nothing created")
+		if job.rollbackErr != nil {
+			content = " ⚠ " + styleStatsDim().Render("Outline import failed, rollback incomplete")
+		}
+	default:
+		target := "as root beads"
+		if job.parentID != "" {
+			target = "under " + styleID().Render(job.parentID)
+		}
+		content = " ✓ " + styleStatsDim().Render(fmt.Sprintf("Imported %d %s ", total, beadWord(total))) + target
+	}
+	return newToastLayer(styleSuccessToast().Render(content), width, height, mainBodyStart, mainBodyHeight)
+}
+
+// commentToastLayer renders the comment success toast if visible.
 func (m *App) commentToastLayer(width, height, mainBodyStart, mainBodyHeight int) Layer {
 	if !m.commentToastVisible || m.commentToastBeadID == "" {
 		return nil
@@ -331,8 +440,12 @@ func (m *App) commentToastLayer(width, height, mainBodyStart, mainBodyHeight int
 		remaining = 0
 	}
 
-	// Line 1: "✓ Comment added"
-	heroLine := " ✓ " + styleStatsDim().Render("Comment added")
+	// Line 1: "✓ Comment added" (or updated/deleted)
+	action := m.commentToastAction
+	if action == "" {
+		action = "added"
+	}
+	heroLine := " ✓ " + styleStatsDim().Render("Comment "+action)
 
 	// Line 2: bead ID + countdown
 	beadID := styleID().Render(m.commentToastBeadID)
@@ -396,6 +509,53 @@ func (m *App) priorityToastLayer(width, height, mainBodyStart, mainBodyHeight in
 	return newToastLayer(styleSuccessToast().Render(content), width, height, mainBodyStart, mainBodyHeight)
 }
 
+// assigneeToastLayer renders the assignee change success toast if visible.
+func (m *App) assigneeToastLayer(width, height, mainBodyStart, mainBodyHeight int) Layer {
+	if !m.assigneeToastVisible || m.assigneeToastBeadID == "" {
+		return nil
+	}
+	elapsed := time.Since(m.assigneeToastStart)
+	remaining := 7 - int(elapsed.Seconds())
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	// Line 1: "Assignee → alice" or "Took → alice ◐ In Progress"
+	name := m.assigneeToastName
+	if name == "" {
+		name = "Unassigned"
+	}
+	var heroLine string
+	if m.assigneeToastTaken {
+		heroLine = " " + styleStatsDim().Render("Took →") + " " + styleID().Render(name) +
+			" " + styleIconInProgress().Render("◐") + " " + styleInProgressText().Render("In Progress")
+	} else {
+		heroLine = " " + styleStatsDim().Render("Assignee →") + " " + styleID().Render(name)
+	}
+
+	// Line 2: bead ID + right-aligned countdown
+	beadID := styleID().Render(m.assigneeToastBeadID)
+	countdownStr := styleStatsDim().Render(fmt.Sprintf("[%ds]", remaining))
+
+	leftPart := " " + beadID
+	heroWidth := lipgloss.Width(heroLine)
+	leftWidth := lipgloss.Width(leftPart)
+	countdownWidth := lipgloss.Width(countdownStr)
+
+	targetWidth := heroWidth
+	if targetWidth < 20 {
+		targetWidth = 20
+	}
+	padding := targetWidth - leftWidth - countdownWidth
+	if padding < 2 {
+		padding = 2
+	}
+
+	infoLine := leftPart + strings.Repeat(" ", padding) + countdownStr
+	content := heroLine + "\n" + infoLine
+	return newToastLayer(styleSuccessToast().Render(content), width, height, mainBodyStart, mainBodyHeight)
+}
+
 // priorityName returns the display name for a priority value.
 func priorityName(priority int) string {
 	switch priority {
@@ -638,6 +798,56 @@ func (m *App) layoutToastLayer(width, height, mainBodyStart, mainBodyHeight int)
 	return newToastLayer(styleSuccessToast().Render(content), width, height, mainBodyStart, mainBodyHeight)
 }
 
+// queuedToastLayer renders the toast for writes handed to the retry queue.
+func (m *App) queuedToastLayer(width, height, mainBodyStart, mainBodyHeight int) Layer {
+	if !m.queuedToastVisible || len(m.queuedToastWrites) == 0 {
+		return nil
+	}
+
+	// Line 1: "⟳ Queued: status of ab-1 → closed (+1 more)"
+	icon := baseStyle().Render(" ⟳ ")
+	label := styleStatsDim().Render("Queued:")
+	space := baseStyle().Render(" ")
+	write := styleID().Render(m.queuedToastWrites[0].String())
+	heroLine := lipgloss.JoinHorizontal(lipgloss.Left, icon, label, space, write)
+	if more := len(m.queuedToastWrites) - 1; more > 0 {
+		heroLine = lipgloss.JoinHorizontal(lipgloss.Left, heroLine, space, styleStatsDim().Render(fmt.Sprintf("(+%d more)", more)))
+	}
+
+	// Line 2: why it is waiting
+	reason := "Database busy, will retry"
+	if m.queuedToastBehind {
+		reason = "Waiting for earlier writes"
+	}
+	content := heroLine + "\n" + baseStyle().Render("   ") + styleStatsDim().Render(reason)
+	return newToastLayer(styleInfoToast().Render(content), width, height, mainBodyStart, mainBodyHeight)
+}
+
+// sortToastLayer renders the sort mode toast if visible.
+func (m *App) sortToastLayer(width, height, mainBodyStart, mainBodyHeight int) Layer {
+	if !m.sortToastVisible {
+		return nil
+	}
+	label := styleStatsDim().Render("Sort:")
+	space := baseStyle().Render(" ")
+	name := styleID().Render(m.sortMode.String())
+	view := styleStatsDim().Render(fmt.Sprintf("(%s view)", m.viewMode.String()))
+	content := lipgloss.JoinHorizontal(lipgloss.Left, label, space, name, space, view)
+	return newToastLayer(styleSuccessToast().Render(content), width, height, mainBodyStart, mainBodyHeight)
+}
+
+// groupToastLayer renders the group-by mode toast if visible.
+func (m *App) groupToastLayer(width, height, mainBodyStart, mainBodyHeight int) Layer {
+	if !m.groupToastVisible {
+		return nil
+	}
+	label := styleStatsDim().Render("Group by:")
+	space := baseStyle().Render(" ")
+	name := styleID().Render(m.groupMode.String())
+	content := lipgloss.JoinHorizontal(lipgloss.Left, label, space, name)
+	return newToastLayer(styleSuccessToast().Render(content), width, height, mainBodyStart, mainBodyHeight)
+}
+
 // columnsToastLayer renders the columns toggle toast if visible.
 func (m *App) columnsToastLayer(width, height, mainBodyStart, mainBodyHeight int) Layer {
 	if !m.columnsToastVisible {